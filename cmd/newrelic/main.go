@@ -9,21 +9,27 @@ import (
 
 	// Commands
 	"github.com/newrelic/newrelic-cli/internal/agent"
+	"github.com/newrelic/newrelic-cli/internal/alerts"
 	"github.com/newrelic/newrelic-cli/internal/apiaccess"
 	"github.com/newrelic/newrelic-cli/internal/apm"
 	"github.com/newrelic/newrelic-cli/internal/config"
 	"github.com/newrelic/newrelic-cli/internal/credentials"
+	"github.com/newrelic/newrelic-cli/internal/dashboard"
 	"github.com/newrelic/newrelic-cli/internal/decode"
 	diagnose "github.com/newrelic/newrelic-cli/internal/diagnose"
 	"github.com/newrelic/newrelic-cli/internal/edge"
 	"github.com/newrelic/newrelic-cli/internal/entities"
 	"github.com/newrelic/newrelic-cli/internal/events"
+	"github.com/newrelic/newrelic-cli/internal/incidents"
 	"github.com/newrelic/newrelic-cli/internal/install"
 	"github.com/newrelic/newrelic-cli/internal/nerdgraph"
 	"github.com/newrelic/newrelic-cli/internal/nerdstorage"
 	"github.com/newrelic/newrelic-cli/internal/nrql"
+	"github.com/newrelic/newrelic-cli/internal/recipe"
 	"github.com/newrelic/newrelic-cli/internal/reporting"
+	"github.com/newrelic/newrelic-cli/internal/synthetics"
 	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-cli/internal/utils/terraform"
 	"github.com/newrelic/newrelic-cli/internal/workload"
 )
 
@@ -35,24 +41,33 @@ var (
 func init() {
 	// Bind imported sub-commands
 	Command.AddCommand(agent.Command)
+	Command.AddCommand(alerts.Command)
 	Command.AddCommand(apiaccess.Command)
 	Command.AddCommand(apm.Command)
 	Command.AddCommand(config.Command)
 	Command.AddCommand(credentials.Command)
+	Command.AddCommand(dashboard.Command)
 	Command.AddCommand(decode.Command)
 	Command.AddCommand(diagnose.Command)
 	Command.AddCommand(edge.Command)
 	Command.AddCommand(entities.Command)
 	Command.AddCommand(events.Command)
+	Command.AddCommand(incidents.Command)
 	Command.AddCommand(install.Command)
 	Command.AddCommand(install.TestCommand)
+	Command.AddCommand(install.UninstallCommand)
 	Command.AddCommand(nerdgraph.Command)
 	Command.AddCommand(nerdstorage.Command)
 	Command.AddCommand(nrql.Command)
+	Command.AddCommand(recipe.Command)
 	Command.AddCommand(reporting.Command)
+	Command.AddCommand(synthetics.Command)
 	Command.AddCommand(utils.Command)
 	Command.AddCommand(workload.Command)
 
+	// Bound here rather than in the utils package to avoid an import cycle
+	utils.Command.AddCommand(terraform.Command)
+
 	CheckPrereleaseMode(Command)
 
 	os.Setenv("NEW_RELIC_CLI_VERSION", version)
@@ -13,6 +13,7 @@ require (
 	github.com/golangci/golangci-lint v1.39.0
 	github.com/google/uuid v1.2.0
 	github.com/goreleaser/goreleaser v0.157.0
+	github.com/hashicorp/hcl/v2 v2.10.0
 	github.com/hokaccha/go-prettyjson v0.0.0-20210113012101-fb4e108d2519
 	github.com/imdario/mergo v0.3.12
 	github.com/itchyny/gojq v0.12.4
@@ -36,6 +37,7 @@ require (
 	golang.org/x/term v0.0.0-20210503060354-a79de5458b56
 	golang.org/x/tools v0.1.0
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 	gotest.tools/gotestsum v1.6.4
 	mvdan.cc/sh/v3 v3.3.0
 )
@@ -0,0 +1,433 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+// PolicyBundle is a portable representation of an alert policy, its NRQL
+// conditions and its notification channel associations.  Bundles contain no
+// account or object IDs, so they can be applied to any account.
+type PolicyBundle struct {
+	Policy     BundlePolicy      `json:"policy" yaml:"policy"`
+	Conditions []BundleCondition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Channels   []BundleChannel   `json:"channels,omitempty" yaml:"channels,omitempty"`
+}
+
+// BundlePolicy holds the portable fields of an alert policy.
+type BundlePolicy struct {
+	Name               string `json:"name" yaml:"name"`
+	IncidentPreference string `json:"incidentPreference" yaml:"incidentPreference"`
+}
+
+// BundleCondition holds the portable fields of a NRQL alert condition.
+type BundleCondition struct {
+	Name                        string                     `json:"name" yaml:"name"`
+	Type                        string                     `json:"type" yaml:"type"`
+	Description                 string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	Enabled                     bool                       `json:"enabled" yaml:"enabled"`
+	RunbookURL                  string                     `json:"runbookUrl,omitempty" yaml:"runbookUrl,omitempty"`
+	Query                       string                     `json:"query" yaml:"query"`
+	EvaluationOffset            int                        `json:"evaluationOffset,omitempty" yaml:"evaluationOffset,omitempty"`
+	Terms                       []BundleConditionTerm      `json:"terms" yaml:"terms"`
+	ViolationTimeLimitSeconds   int                        `json:"violationTimeLimitSeconds,omitempty" yaml:"violationTimeLimitSeconds,omitempty"`
+	ValueFunction               string                     `json:"valueFunction,omitempty" yaml:"valueFunction,omitempty"`
+	BaselineDirection           string                     `json:"baselineDirection,omitempty" yaml:"baselineDirection,omitempty"`
+	ExpectedGroups              *int                       `json:"expectedGroups,omitempty" yaml:"expectedGroups,omitempty"`
+	OpenViolationOnGroupOverlap *bool                      `json:"openViolationOnGroupOverlap,omitempty" yaml:"openViolationOnGroupOverlap,omitempty"`
+	Expiration                  *BundleConditionExpiration `json:"expiration,omitempty" yaml:"expiration,omitempty"`
+	Signal                      *BundleConditionSignal     `json:"signal,omitempty" yaml:"signal,omitempty"`
+}
+
+// BundleConditionTerm holds a single threshold of a NRQL alert condition.
+type BundleConditionTerm struct {
+	Priority             string   `json:"priority" yaml:"priority"`
+	Operator             string   `json:"operator" yaml:"operator"`
+	Threshold            *float64 `json:"threshold" yaml:"threshold"`
+	ThresholdDuration    int      `json:"thresholdDuration" yaml:"thresholdDuration"`
+	ThresholdOccurrences string   `json:"thresholdOccurrences" yaml:"thresholdOccurrences"`
+}
+
+// BundleConditionExpiration holds the loss of signal settings of a NRQL alert condition.
+type BundleConditionExpiration struct {
+	ExpirationDuration          *int `json:"expirationDuration,omitempty" yaml:"expirationDuration,omitempty"`
+	CloseViolationsOnExpiration bool `json:"closeViolationsOnExpiration" yaml:"closeViolationsOnExpiration"`
+	OpenViolationOnExpiration   bool `json:"openViolationOnExpiration" yaml:"openViolationOnExpiration"`
+}
+
+// BundleConditionSignal holds the signal settings of a NRQL alert condition.
+type BundleConditionSignal struct {
+	AggregationWindow *int     `json:"aggregationWindow,omitempty" yaml:"aggregationWindow,omitempty"`
+	EvaluationOffset  *int     `json:"evaluationOffset,omitempty" yaml:"evaluationOffset,omitempty"`
+	FillOption        string   `json:"fillOption,omitempty" yaml:"fillOption,omitempty"`
+	FillValue         *float64 `json:"fillValue,omitempty" yaml:"fillValue,omitempty"`
+}
+
+// BundleChannel identifies a notification channel by name and type, since
+// channel IDs differ between accounts.
+type BundleChannel struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"`
+}
+
+// policyBundleClient is the subset of the alerts client needed to export and
+// apply policy bundles.
+type policyBundleClient interface {
+	nrqlConditionMutator
+	QueryPolicyWithContext(context.Context, int, string) (*alerts.AlertsPolicy, error)
+	QueryPolicySearchWithContext(context.Context, int, alerts.AlertsPoliciesSearchCriteriaInput) ([]*alerts.AlertsPolicy, error)
+	CreatePolicyMutationWithContext(context.Context, int, alerts.AlertsPolicyInput) (*alerts.AlertsPolicy, error)
+	UpdatePolicyMutationWithContext(context.Context, int, string, alerts.AlertsPolicyUpdateInput) (*alerts.AlertsPolicy, error)
+	SearchNrqlConditionsQueryWithContext(context.Context, int, alerts.NrqlConditionsSearchCriteria) ([]*alerts.NrqlAlertCondition, error)
+	ListChannelsWithContext(context.Context) ([]*alerts.Channel, error)
+	UpdatePolicyChannelsWithContext(context.Context, int, []int) (*alerts.PolicyChannels, error)
+}
+
+// exportPolicyBundle fetches a policy, its NRQL conditions and its channel
+// associations and returns them as a portable bundle.  Notification channels
+// are read with the REST API, which only sees channelsAccountID, the account of
+// the API key, so channels are left out when exporting from another account.
+func exportPolicyBundle(ctx context.Context, client policyBundleClient, accountID, channelsAccountID int, policyID string) (*PolicyBundle, error) {
+	policy, err := client.QueryPolicyWithContext(ctx, accountID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := client.SearchNrqlConditionsQueryWithContext(ctx, accountID, alerts.NrqlConditionsSearchCriteria{PolicyID: policyID})
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &PolicyBundle{
+		Policy: BundlePolicy{
+			Name:               policy.Name,
+			IncidentPreference: string(policy.IncidentPreference),
+		},
+	}
+
+	for _, c := range conditions {
+		bundle.Conditions = append(bundle.Conditions, toBundleCondition(c))
+	}
+
+	if !channelsInAccount(accountID, channelsAccountID) {
+		log.Warnf("notification channels can only be read from account %d, the account of the API key; "+
+			"the bundle will not include the policy's channels", channelsAccountID)
+		return bundle, nil
+	}
+
+	id, err := strconv.Atoi(policyID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy ID %s: %s", policyID, err)
+	}
+
+	channels, err := client.ListChannelsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range filterChannelsByPolicy(channels, id) {
+		bundle.Channels = append(bundle.Channels, BundleChannel{Name: c.Name, Type: string(c.Type)})
+	}
+
+	return bundle, nil
+}
+
+// applyPolicyBundle creates or updates the bundle's policy, conditions and
+// channel associations in the given account.  Policies are matched by name,
+// and conditions are matched by name within their policy, so applying the same
+// bundle more than once is safe.
+func applyPolicyBundle(ctx context.Context, client policyBundleClient, accountID int, bundle *PolicyBundle) (*alerts.AlertsPolicy, error) {
+	policy, err := applyBundlePolicy(ctx, client, accountID, bundle.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := client.SearchNrqlConditionsQueryWithContext(ctx, accountID, alerts.NrqlConditionsSearchCriteria{PolicyID: policy.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	existingByName := map[string]*alerts.NrqlAlertCondition{}
+	for _, c := range existing {
+		existingByName[c.Name] = c
+	}
+
+	for _, c := range bundle.Conditions {
+		input := fromBundleCondition(c)
+
+		if e, ok := existingByName[c.Name]; ok {
+			log.Debugf("updating NRQL condition %s (%s)", c.Name, e.ID)
+			if _, err = updateNrqlCondition(ctx, client, accountID, e.ID, input); err != nil {
+				return nil, fmt.Errorf("could not update condition %s: %s", c.Name, err)
+			}
+			continue
+		}
+
+		log.Debugf("creating NRQL condition %s", c.Name)
+		if _, err = createNrqlCondition(ctx, client, accountID, policy.ID, input); err != nil {
+			return nil, fmt.Errorf("could not create condition %s: %s", c.Name, err)
+		}
+	}
+
+	if len(bundle.Channels) > 0 {
+		if err = applyBundleChannels(ctx, client, policy.ID, bundle.Channels); err != nil {
+			return nil, err
+		}
+	}
+
+	return policy, nil
+}
+
+// checkBundleChannelsAccount returns an error if the bundle has channel
+// associations and the given account is not channelsAccountID, the account of
+// the API key.  Channels are matched with the REST API, which only sees the API
+// key's account, so such a bundle must be rejected before anything is applied.
+func checkBundleChannelsAccount(bundle *PolicyBundle, accountID, channelsAccountID int) error {
+	if len(bundle.Channels) == 0 || channelsInAccount(accountID, channelsAccountID) {
+		return nil
+	}
+
+	return fmt.Errorf("the bundle's notification channels can only be applied to account %d, the account of the API key", channelsAccountID)
+}
+
+// channelsInAccount returns whether the notification channels visible to the
+// API key belong to the given account.  An unknown API key account is assumed
+// to match.
+func channelsInAccount(accountID, channelsAccountID int) bool {
+	return channelsAccountID == 0 || channelsAccountID == accountID
+}
+
+func applyBundlePolicy(ctx context.Context, client policyBundleClient, accountID int, p BundlePolicy) (*alerts.AlertsPolicy, error) {
+	preference, err := parseIncidentPreference(p.IncidentPreference)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := client.QueryPolicySearchWithContext(ctx, accountID, alerts.AlertsPoliciesSearchCriteriaInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range policies {
+		if existing.Name != p.Name {
+			continue
+		}
+
+		log.Debugf("updating alert policy %s (%s)", p.Name, existing.ID)
+		return client.UpdatePolicyMutationWithContext(ctx, accountID, existing.ID, alerts.AlertsPolicyUpdateInput{
+			Name:               p.Name,
+			IncidentPreference: preference,
+		})
+	}
+
+	log.Debugf("creating alert policy %s", p.Name)
+	return client.CreatePolicyMutationWithContext(ctx, accountID, alerts.AlertsPolicyInput{
+		Name:               p.Name,
+		IncidentPreference: preference,
+	})
+}
+
+func applyBundleChannels(ctx context.Context, client policyBundleClient, policyID string, bundleChannels []BundleChannel) error {
+	id, err := strconv.Atoi(policyID)
+	if err != nil {
+		return fmt.Errorf("invalid policy ID %s: %s", policyID, err)
+	}
+
+	channels, err := client.ListChannelsWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	channelIDs := []int{}
+	for _, bc := range bundleChannels {
+		found := false
+		for _, c := range channels {
+			if c.Name == bc.Name && string(c.Type) == bc.Type {
+				channelIDs = append(channelIDs, c.ID)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			log.Warnf("notification channel %s (%s) does not exist in the target account, skipping", bc.Name, bc.Type)
+		}
+	}
+
+	if len(channelIDs) == 0 {
+		return nil
+	}
+
+	_, err = client.UpdatePolicyChannelsWithContext(ctx, id, channelIDs)
+	return err
+}
+
+func toBundleCondition(c *alerts.NrqlAlertCondition) BundleCondition {
+	bc := BundleCondition{
+		Name:                        c.Name,
+		Type:                        string(c.Type),
+		Description:                 c.Description,
+		Enabled:                     c.Enabled,
+		RunbookURL:                  c.RunbookURL,
+		Query:                       c.Nrql.Query,
+		EvaluationOffset:            c.Nrql.EvaluationOffset,
+		ViolationTimeLimitSeconds:   c.ViolationTimeLimitSeconds,
+		ExpectedGroups:              c.ExpectedGroups,
+		OpenViolationOnGroupOverlap: c.OpenViolationOnGroupOverlap,
+	}
+
+	if c.ValueFunction != nil {
+		bc.ValueFunction = string(*c.ValueFunction)
+	}
+
+	if c.BaselineDirection != nil {
+		bc.BaselineDirection = string(*c.BaselineDirection)
+	}
+
+	for _, t := range c.Terms {
+		bc.Terms = append(bc.Terms, BundleConditionTerm{
+			Priority:             string(t.Priority),
+			Operator:             string(t.Operator),
+			Threshold:            t.Threshold,
+			ThresholdDuration:    t.ThresholdDuration,
+			ThresholdOccurrences: string(t.ThresholdOccurrences),
+		})
+	}
+
+	if c.Expiration != nil {
+		bc.Expiration = &BundleConditionExpiration{
+			ExpirationDuration:          c.Expiration.ExpirationDuration,
+			CloseViolationsOnExpiration: c.Expiration.CloseViolationsOnExpiration,
+			OpenViolationOnExpiration:   c.Expiration.OpenViolationOnExpiration,
+		}
+	}
+
+	if c.Signal != nil {
+		bc.Signal = &BundleConditionSignal{
+			AggregationWindow: c.Signal.AggregationWindow,
+			EvaluationOffset:  c.Signal.EvaluationOffset,
+			FillValue:         c.Signal.FillValue,
+		}
+
+		if c.Signal.FillOption != nil {
+			bc.Signal.FillOption = string(*c.Signal.FillOption)
+		}
+	}
+
+	return bc
+}
+
+func fromBundleCondition(bc BundleCondition) alerts.NrqlConditionInput {
+	input := alerts.NrqlConditionInput{
+		NrqlConditionBase: alerts.NrqlConditionBase{
+			Name:        bc.Name,
+			Type:        alerts.NrqlConditionType(strings.ToUpper(bc.Type)),
+			Description: bc.Description,
+			Enabled:     bc.Enabled,
+			RunbookURL:  bc.RunbookURL,
+			Nrql: alerts.NrqlConditionQuery{
+				Query:            bc.Query,
+				EvaluationOffset: bc.EvaluationOffset,
+			},
+			ViolationTimeLimitSeconds: bc.ViolationTimeLimitSeconds,
+		},
+		ExpectedGroups:              bc.ExpectedGroups,
+		OpenViolationOnGroupOverlap: bc.OpenViolationOnGroupOverlap,
+	}
+
+	if input.Type == "" {
+		input.Type = alerts.NrqlConditionTypes.Static
+	}
+
+	if bc.ValueFunction != "" {
+		v := alerts.NrqlConditionValueFunction(bc.ValueFunction)
+		input.ValueFunction = &v
+	}
+
+	if bc.BaselineDirection != "" {
+		d := alerts.NrqlBaselineDirection(bc.BaselineDirection)
+		input.BaselineDirection = &d
+	}
+
+	for _, t := range bc.Terms {
+		input.Terms = append(input.Terms, alerts.NrqlConditionTerm{
+			Priority:             alerts.NrqlConditionPriority(t.Priority),
+			Operator:             alerts.AlertsNRQLConditionTermsOperator(t.Operator),
+			Threshold:            t.Threshold,
+			ThresholdDuration:    t.ThresholdDuration,
+			ThresholdOccurrences: alerts.ThresholdOccurrence(t.ThresholdOccurrences),
+		})
+	}
+
+	if bc.Expiration != nil {
+		input.Expiration = &alerts.AlertsNrqlConditionExpiration{
+			ExpirationDuration:          bc.Expiration.ExpirationDuration,
+			CloseViolationsOnExpiration: bc.Expiration.CloseViolationsOnExpiration,
+			OpenViolationOnExpiration:   bc.Expiration.OpenViolationOnExpiration,
+		}
+	}
+
+	if bc.Signal != nil {
+		input.Signal = &alerts.AlertsNrqlConditionSignal{
+			AggregationWindow: bc.Signal.AggregationWindow,
+			EvaluationOffset:  bc.Signal.EvaluationOffset,
+			FillValue:         bc.Signal.FillValue,
+		}
+
+		if bc.Signal.FillOption != "" {
+			f := alerts.AlertsFillOption(bc.Signal.FillOption)
+			input.Signal.FillOption = &f
+		}
+	}
+
+	return input
+}
+
+// marshalPolicyBundle serializes a bundle as JSON when the target file has a
+// .json extension, and as YAML otherwise.
+func marshalPolicyBundle(bundle *PolicyBundle, file string) ([]byte, error) {
+	if isJSONFile(file) {
+		return json.MarshalIndent(bundle, "", "  ")
+	}
+
+	return yaml.Marshal(bundle)
+}
+
+// readPolicyBundle reads a JSON or YAML bundle from the given file.
+func readPolicyBundle(file string) (*PolicyBundle, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read bundle file %s: %s", file, err)
+	}
+
+	bundle := &PolicyBundle{}
+	if isJSONFile(file) {
+		err = json.Unmarshal(data, bundle)
+	} else {
+		err = yaml.Unmarshal(data, bundle)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse bundle file %s: %s", file, err)
+	}
+
+	if bundle.Policy.Name == "" {
+		return nil, fmt.Errorf("bundle file %s does not define a policy name", file)
+	}
+
+	return bundle, nil
+}
+
+func isJSONFile(file string) bool {
+	return strings.EqualFold(filepath.Ext(file), ".json")
+}
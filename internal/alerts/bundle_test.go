@@ -0,0 +1,224 @@
+// +build unit
+
+package alerts
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+func TestExportPolicyBundle(t *testing.T) {
+	threshold := 5.0
+	valueFunction := alerts.NrqlConditionValueFunctions.SingleValue
+
+	c := &mockPolicyBundleClient{
+		policies: []*alerts.AlertsPolicy{
+			{AccountID: 1, ID: "10", Name: "Production", IncidentPreference: alerts.AlertsIncidentPreferenceTypes.PER_CONDITION},
+		},
+		conditions: []*alerts.NrqlAlertCondition{
+			{
+				ID:       "100",
+				PolicyID: "10",
+				NrqlConditionBase: alerts.NrqlConditionBase{
+					Name:    "High error rate",
+					Type:    alerts.NrqlConditionTypes.Static,
+					Enabled: true,
+					Nrql:    alerts.NrqlConditionQuery{Query: "SELECT count(*) FROM TransactionError"},
+					Terms: []alerts.NrqlConditionTerm{
+						{Priority: "CRITICAL", Operator: "ABOVE", Threshold: &threshold, ThresholdDuration: 300, ThresholdOccurrences: "ALL"},
+					},
+				},
+				ValueFunction: &valueFunction,
+			},
+		},
+		channels: []*alerts.Channel{
+			{ID: 7, Name: "On-call", Type: "pagerduty", Links: alerts.ChannelLinks{PolicyIDs: []int{10}}},
+			{ID: 8, Name: "Other", Type: "email", Links: alerts.ChannelLinks{PolicyIDs: []int{11}}},
+		},
+	}
+
+	bundle, err := exportPolicyBundle(context.Background(), c, 1, 1, "10")
+	require.NoError(t, err)
+
+	assert.Equal(t, BundlePolicy{Name: "Production", IncidentPreference: "PER_CONDITION"}, bundle.Policy)
+	require.Len(t, bundle.Conditions, 1)
+	assert.Equal(t, "High error rate", bundle.Conditions[0].Name)
+	assert.Equal(t, "SINGLE_VALUE", bundle.Conditions[0].ValueFunction)
+	assert.Equal(t, 5.0, *bundle.Conditions[0].Terms[0].Threshold)
+	assert.Equal(t, []BundleChannel{{Name: "On-call", Type: "pagerduty"}}, bundle.Channels)
+
+	// Channels of another account are not visible to the API key.
+	bundle, err = exportPolicyBundle(context.Background(), c, 1, 2, "10")
+	require.NoError(t, err)
+	require.Len(t, bundle.Conditions, 1)
+	assert.Empty(t, bundle.Channels)
+}
+
+func TestApplyPolicyBundle_Creates(t *testing.T) {
+	c := &mockPolicyBundleClient{
+		channels: []*alerts.Channel{{ID: 7, Name: "On-call", Type: "pagerduty"}},
+	}
+
+	bundle := &PolicyBundle{
+		Policy:     BundlePolicy{Name: "Production", IncidentPreference: "PER_POLICY"},
+		Conditions: []BundleCondition{{Name: "High error rate", Type: "baseline", BaselineDirection: "UPPER_ONLY"}},
+		Channels:   []BundleChannel{{Name: "On-call", Type: "pagerduty"}, {Name: "Missing", Type: "email"}},
+	}
+
+	policy, err := applyPolicyBundle(context.Background(), c, 2, bundle)
+	require.NoError(t, err)
+
+	assert.Equal(t, "20", policy.ID)
+	assert.Equal(t, []string{"createPolicy", "createBaseline", "updatePolicyChannels"}, c.calls)
+	assert.Equal(t, []int{7}, c.linkedChannelIDs)
+}
+
+func TestApplyPolicyBundle_UpdatesExisting(t *testing.T) {
+	c := &mockPolicyBundleClient{
+		policies: []*alerts.AlertsPolicy{{ID: "20", Name: "Production"}},
+		conditions: []*alerts.NrqlAlertCondition{
+			{ID: "200", PolicyID: "20", NrqlConditionBase: alerts.NrqlConditionBase{Name: "High error rate"}},
+		},
+	}
+
+	bundle := &PolicyBundle{
+		Policy: BundlePolicy{Name: "Production", IncidentPreference: "PER_POLICY"},
+		Conditions: []BundleCondition{
+			{Name: "High error rate"},
+			{Name: "Low throughput", Type: "STATIC"},
+		},
+	}
+
+	_, err := applyPolicyBundle(context.Background(), c, 2, bundle)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"updatePolicy", "updateStatic", "createStatic"}, c.calls)
+}
+
+func TestCheckBundleChannelsAccount(t *testing.T) {
+	bundle := &PolicyBundle{
+		Policy:   BundlePolicy{Name: "Production", IncidentPreference: "PER_POLICY"},
+		Channels: []BundleChannel{{Name: "On-call", Type: "pagerduty"}},
+	}
+
+	require.NoError(t, checkBundleChannelsAccount(bundle, 2, 2))
+	require.NoError(t, checkBundleChannelsAccount(bundle, 2, 0))
+	require.NoError(t, checkBundleChannelsAccount(&PolicyBundle{Policy: bundle.Policy}, 2, 1))
+
+	err := checkBundleChannelsAccount(bundle, 2, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "account 1")
+}
+
+func TestApplyPolicyBundle_InvalidIncidentPreference(t *testing.T) {
+	c := &mockPolicyBundleClient{}
+
+	_, err := applyPolicyBundle(context.Background(), c, 2, &PolicyBundle{Policy: BundlePolicy{Name: "Production", IncidentPreference: "NOPE"}})
+	require.Error(t, err)
+	assert.Empty(t, c.calls)
+}
+
+func TestPolicyBundle_RoundTrip(t *testing.T) {
+	threshold := 1.5
+	bundle := &PolicyBundle{
+		Policy: BundlePolicy{Name: "Production", IncidentPreference: "PER_POLICY"},
+		Conditions: []BundleCondition{
+			{
+				Name:  "High error rate",
+				Type:  "STATIC",
+				Query: "SELECT count(*) FROM TransactionError",
+				Terms: []BundleConditionTerm{{Priority: "CRITICAL", Operator: "ABOVE", Threshold: &threshold, ThresholdDuration: 60, ThresholdOccurrences: "ALL"}},
+			},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "bundle")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"bundle.yaml", "bundle.json"} {
+		file := filepath.Join(dir, name)
+
+		data, marshalErr := marshalPolicyBundle(bundle, file)
+		require.NoError(t, marshalErr)
+		require.NoError(t, ioutil.WriteFile(file, data, 0644))
+
+		read, readErr := readPolicyBundle(file)
+		require.NoError(t, readErr)
+		assert.Equal(t, bundle, read, name)
+	}
+}
+
+func TestReadPolicyBundle_RequiresPolicyName(t *testing.T) {
+	f, err := ioutil.TempFile("", "bundle*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("conditions: []\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = readPolicyBundle(f.Name())
+	require.Error(t, err)
+}
+
+type mockPolicyBundleClient struct {
+	mockNrqlConditionMutator
+	policies         []*alerts.AlertsPolicy
+	conditions       []*alerts.NrqlAlertCondition
+	channels         []*alerts.Channel
+	linkedChannelIDs []int
+}
+
+func (m *mockPolicyBundleClient) QueryPolicyWithContext(ctx context.Context, accountID int, id string) (*alerts.AlertsPolicy, error) {
+	for _, p := range m.policies {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+
+	return nil, assert.AnError
+}
+
+func (m *mockPolicyBundleClient) QueryPolicySearchWithContext(context.Context, int, alerts.AlertsPoliciesSearchCriteriaInput) ([]*alerts.AlertsPolicy, error) {
+	return m.policies, nil
+}
+
+func (m *mockPolicyBundleClient) CreatePolicyMutationWithContext(ctx context.Context, accountID int, input alerts.AlertsPolicyInput) (*alerts.AlertsPolicy, error) {
+	m.calls = append(m.calls, "createPolicy")
+	return &alerts.AlertsPolicy{AccountID: accountID, ID: "20", Name: input.Name, IncidentPreference: input.IncidentPreference}, nil
+}
+
+func (m *mockPolicyBundleClient) UpdatePolicyMutationWithContext(ctx context.Context, accountID int, id string, input alerts.AlertsPolicyUpdateInput) (*alerts.AlertsPolicy, error) {
+	m.calls = append(m.calls, "updatePolicy")
+	return &alerts.AlertsPolicy{AccountID: accountID, ID: id, Name: input.Name, IncidentPreference: input.IncidentPreference}, nil
+}
+
+func (m *mockPolicyBundleClient) SearchNrqlConditionsQueryWithContext(ctx context.Context, accountID int, criteria alerts.NrqlConditionsSearchCriteria) ([]*alerts.NrqlAlertCondition, error) {
+	conditions := []*alerts.NrqlAlertCondition{}
+	for _, c := range m.conditions {
+		if c.PolicyID == criteria.PolicyID {
+			conditions = append(conditions, c)
+		}
+	}
+
+	return conditions, nil
+}
+
+func (m *mockPolicyBundleClient) ListChannelsWithContext(context.Context) ([]*alerts.Channel, error) {
+	return m.channels, nil
+}
+
+func (m *mockPolicyBundleClient) UpdatePolicyChannelsWithContext(ctx context.Context, policyID int, channelIDs []int) (*alerts.PolicyChannels, error) {
+	m.calls = append(m.calls, "updatePolicyChannels")
+	m.linkedChannelIDs = channelIDs
+	return &alerts.PolicyChannels{ID: policyID, ChannelIDs: channelIDs}, nil
+}
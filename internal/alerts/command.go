@@ -0,0 +1,16 @@
+package alerts
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	accountID int
+	policyID  int
+)
+
+// Command represents the alerts command.
+var Command = &cobra.Command{
+	Use:   "alerts",
+	Short: "Interact with New Relic alerts",
+}
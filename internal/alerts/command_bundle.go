@@ -0,0 +1,108 @@
+package alerts
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/credentials"
+	"github.com/newrelic/newrelic-cli/internal/output"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+)
+
+var bundleFile string
+
+var cmdExport = &cobra.Command{
+	Use:   "export",
+	Short: "Export an alert policy as a portable bundle.",
+	Long: `Export an alert policy as a portable bundle
+
+The export command writes an alert policy, its NRQL conditions and its
+notification channel associations to a YAML or JSON bundle.  Account and object
+IDs are removed, so the bundle can be applied to any account with the apply
+command.  Channels are recorded by name and type, and can only be exported from
+the account of the profile's API key.  The bundle is written as
+JSON if the output file has a .json extension, and as YAML otherwise.  If no
+output file is given, the bundle is printed as YAML.
+`,
+	Example: `newrelic alerts export --accountId 12345678 --policy 1234 --file bundle.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			bundle, err := exportPolicyBundle(utils.SignalCtx, &nrClient.Alerts, accountID, profileAccountID(), strconv.Itoa(policyID))
+			utils.LogIfFatal(err)
+
+			data, err := marshalPolicyBundle(bundle, bundleFile)
+			utils.LogIfFatal(err)
+
+			if bundleFile == "" {
+				fmt.Print(string(data))
+				return
+			}
+
+			utils.LogIfFatal(ioutil.WriteFile(bundleFile, data, 0644))
+			log.Infof("exported policy %s to %s", bundle.Policy.Name, bundleFile)
+		})
+	},
+}
+
+var cmdApply = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply an alert policy bundle to an account.",
+	Long: `Apply an alert policy bundle to an account
+
+The apply command creates or updates the alert policy, NRQL conditions and
+notification channel associations described by a bundle created with the
+export command.  Policies are matched by name in the target account, and
+conditions are matched by name within their policy, so applying the same bundle
+repeatedly is safe.  Notification channels are matched by name and type, and
+must already exist in the target account.  A bundle with channels can only be
+applied to the account of the profile's API key.
+`,
+	Example: `newrelic alerts apply --accountId 12345678 --file bundle.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			bundle, err := readPolicyBundle(bundleFile)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(checkBundleChannelsAccount(bundle, accountID, profileAccountID()))
+
+			policy, err := applyPolicyBundle(utils.SignalCtx, &nrClient.Alerts, accountID, bundle)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(policy))
+			log.Info("success")
+		})
+	},
+}
+
+// profileAccountID returns the account ID of the default profile, or 0 if
+// there is none.
+func profileAccountID() int {
+	if p := credentials.DefaultProfile(); p != nil {
+		return p.AccountID
+	}
+
+	return 0
+}
+
+func init() {
+	// Export
+	Command.AddCommand(cmdExport)
+	cmdExport.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the policy is located")
+	cmdExport.Flags().IntVarP(&policyID, "policy", "p", 0, "the ID of the alert policy to export")
+	cmdExport.Flags().StringVarP(&bundleFile, "file", "f", "", "the file to write the bundle to")
+	utils.LogIfError(cmdExport.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdExport.MarkFlagRequired("policy"))
+
+	// Apply
+	Command.AddCommand(cmdApply)
+	cmdApply.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID to apply the bundle to")
+	cmdApply.Flags().StringVarP(&bundleFile, "file", "f", "", "the bundle file to apply")
+	utils.LogIfError(cmdApply.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdApply.MarkFlagRequired("file"))
+}
@@ -0,0 +1,25 @@
+// +build unit
+
+package alerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestExport(t *testing.T) {
+	assert.Equal(t, "export", cmdExport.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdExport)
+	testcobra.CheckCobraRequiredFlags(t, cmdExport, []string{"accountId", "policy"})
+}
+
+func TestApply(t *testing.T) {
+	assert.Equal(t, "apply", cmdApply.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdApply)
+	testcobra.CheckCobraRequiredFlags(t, cmdApply, []string{"accountId", "file"})
+}
@@ -0,0 +1,69 @@
+package alerts
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/output"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+var cmdChannel = &cobra.Command{
+	Use:   "channel",
+	Short: "Interact with New Relic alert notification channels",
+	Long: `Interact with New Relic alert notification channels
+
+The channel command allows users to inspect the notification channels
+available to their alert policies. Use --help for more information.
+`,
+	Example: "newrelic alerts channel list",
+}
+
+var cmdChannelList = &cobra.Command{
+	Use:   "list",
+	Short: "List the alert notification channels.",
+	Long: `List the alert notification channels
+
+The list command retrieves the notification channels for the account
+associated with your API key, along with the IDs of the policies each channel
+is linked to.  The results can optionally be narrowed down to the channels
+linked to a single policy.
+`,
+	Example: `newrelic alerts channel list --policyId 1234`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			channels, err := nrClient.Alerts.ListChannelsWithContext(utils.SignalCtx)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(filterChannelsByPolicy(channels, policyID)))
+		})
+	},
+}
+
+func filterChannelsByPolicy(channels []*alerts.Channel, id int) []*alerts.Channel {
+	if id == 0 {
+		return channels
+	}
+
+	filtered := []*alerts.Channel{}
+	for _, c := range channels {
+		for _, p := range c.Links.PolicyIDs {
+			if p == id {
+				filtered = append(filtered, c)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+func init() {
+	Command.AddCommand(cmdChannel)
+
+	// List
+	cmdChannel.AddCommand(cmdChannelList)
+	cmdChannelList.Flags().IntVarP(&policyID, "policyId", "p", 0, "only list channels linked to this alert policy")
+}
@@ -0,0 +1,40 @@
+// +build unit
+
+package alerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+func TestChannel(t *testing.T) {
+	assert.Equal(t, "channel", cmdChannel.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdChannel)
+	testcobra.CheckCobraRequiredFlags(t, cmdChannel, []string{})
+}
+
+func TestChannelList(t *testing.T) {
+	assert.Equal(t, "list", cmdChannelList.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdChannelList)
+	testcobra.CheckCobraRequiredFlags(t, cmdChannelList, []string{})
+}
+
+func TestFilterChannelsByPolicy(t *testing.T) {
+	channels := []*alerts.Channel{
+		{ID: 1, Links: alerts.ChannelLinks{PolicyIDs: []int{10, 20}}},
+		{ID: 2, Links: alerts.ChannelLinks{PolicyIDs: []int{30}}},
+	}
+
+	assert.Len(t, filterChannelsByPolicy(channels, 0), 2)
+
+	filtered := filterChannelsByPolicy(channels, 20)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, 1, filtered[0].ID)
+}
@@ -0,0 +1,183 @@
+package alerts
+
+import (
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/output"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+var (
+	conditionID   int
+	conditionJSON string
+	conditionFile string
+)
+
+var cmdCondition = &cobra.Command{
+	Use:   "condition",
+	Short: "Manage New Relic NRQL alert conditions",
+	Long: `Manage New Relic NRQL alert conditions
+
+The condition command allows users to list, create, update and delete the NRQL
+alert conditions that belong to an alert policy. Use --help for more information.
+`,
+	Example: "newrelic alerts condition list --accountId 12345678 --policyId 1234",
+}
+
+var cmdConditionList = &cobra.Command{
+	Use:   "list",
+	Short: "List the NRQL conditions for an alert policy.",
+	Long: `List the NRQL conditions for an alert policy
+
+The list command retrieves the NRQL alert conditions for the given policy ID.
+`,
+	Example: `newrelic alerts condition list --accountId 12345678 --policyId 1234`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			criteria := alerts.NrqlConditionsSearchCriteria{
+				PolicyID: strconv.Itoa(policyID),
+			}
+
+			conditions, err := nrClient.Alerts.SearchNrqlConditionsQueryWithContext(utils.SignalCtx, accountID, criteria)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(conditions))
+		})
+	},
+}
+
+var cmdConditionGet = &cobra.Command{
+	Use:   "get",
+	Short: "Get a NRQL alert condition.",
+	Long: `Get a NRQL alert condition
+
+The get command retrieves a specific NRQL alert condition by its ID.
+`,
+	Example: `newrelic alerts condition get --accountId 12345678 --conditionId 98765`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			condition, err := nrClient.Alerts.GetNrqlConditionQueryWithContext(utils.SignalCtx, accountID, strconv.Itoa(conditionID))
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(condition))
+		})
+	},
+}
+
+var cmdConditionCreate = &cobra.Command{
+	Use:   "create",
+	Short: "Create a NRQL alert condition.",
+	Long: `Create a NRQL alert condition
+
+The create command adds a NRQL alert condition to the given policy.  The
+condition is provided as JSON, either inline with --condition or from a file
+with --file, using the field names of the NerdGraph NRQL condition input.  The
+"type" field selects a STATIC, BASELINE or OUTLIER condition, and defaults to
+STATIC.
+`,
+	Example: `newrelic alerts condition create --accountId 12345678 --policyId 1234 --file condition.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			input, err := readNrqlConditionInput(conditionJSON, conditionFile)
+			utils.LogIfFatal(err)
+
+			condition, err := createNrqlCondition(utils.SignalCtx, &nrClient.Alerts, accountID, strconv.Itoa(policyID), *input)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(condition))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdConditionUpdate = &cobra.Command{
+	Use:   "update",
+	Short: "Update a NRQL alert condition.",
+	Long: `Update a NRQL alert condition
+
+The update command targets an existing NRQL alert condition by its ID.  The
+updated condition is provided as JSON, either inline with --condition or from a
+file with --file.  The "type" field must match the type of the existing
+condition.
+`,
+	Example: `newrelic alerts condition update --accountId 12345678 --conditionId 98765 --file condition.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			input, err := readNrqlConditionInput(conditionJSON, conditionFile)
+			utils.LogIfFatal(err)
+
+			condition, err := updateNrqlCondition(utils.SignalCtx, &nrClient.Alerts, accountID, strconv.Itoa(conditionID), *input)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(condition))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdConditionDelete = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a NRQL alert condition.",
+	Long: `Delete a NRQL alert condition
+
+The delete command deletes a NRQL alert condition by its ID.
+`,
+	Example: `newrelic alerts condition delete --accountId 12345678 --conditionId 98765`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			_, err := nrClient.Alerts.DeleteNrqlConditionMutationWithContext(utils.SignalCtx, accountID, strconv.Itoa(conditionID))
+			utils.LogIfFatal(err)
+
+			log.Info("success")
+		})
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdCondition)
+
+	// List
+	cmdCondition.AddCommand(cmdConditionList)
+	cmdConditionList.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the policy is located")
+	cmdConditionList.Flags().IntVarP(&policyID, "policyId", "p", 0, "the ID of the alert policy")
+	utils.LogIfError(cmdConditionList.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdConditionList.MarkFlagRequired("policyId"))
+
+	// Get
+	cmdCondition.AddCommand(cmdConditionGet)
+	cmdConditionGet.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the condition is located")
+	cmdConditionGet.Flags().IntVarP(&conditionID, "conditionId", "c", 0, "the ID of the NRQL condition")
+	utils.LogIfError(cmdConditionGet.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdConditionGet.MarkFlagRequired("conditionId"))
+
+	// Create
+	cmdCondition.AddCommand(cmdConditionCreate)
+	cmdConditionCreate.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the policy is located")
+	cmdConditionCreate.Flags().IntVarP(&policyID, "policyId", "p", 0, "the ID of the alert policy to add the condition to")
+	cmdConditionCreate.Flags().StringVar(&conditionJSON, "condition", "", "the NRQL condition definition, as JSON")
+	cmdConditionCreate.Flags().StringVarP(&conditionFile, "file", "f", "", "a file containing the NRQL condition definition, as JSON")
+	utils.LogIfError(cmdConditionCreate.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdConditionCreate.MarkFlagRequired("policyId"))
+
+	// Update
+	cmdCondition.AddCommand(cmdConditionUpdate)
+	cmdConditionUpdate.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the condition is located")
+	cmdConditionUpdate.Flags().IntVarP(&conditionID, "conditionId", "c", 0, "the ID of the NRQL condition you want to update")
+	cmdConditionUpdate.Flags().StringVar(&conditionJSON, "condition", "", "the NRQL condition definition, as JSON")
+	cmdConditionUpdate.Flags().StringVarP(&conditionFile, "file", "f", "", "a file containing the NRQL condition definition, as JSON")
+	utils.LogIfError(cmdConditionUpdate.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdConditionUpdate.MarkFlagRequired("conditionId"))
+
+	// Delete
+	cmdCondition.AddCommand(cmdConditionDelete)
+	cmdConditionDelete.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the condition is located")
+	cmdConditionDelete.Flags().IntVarP(&conditionID, "conditionId", "c", 0, "the ID of the NRQL condition you want to delete")
+	utils.LogIfError(cmdConditionDelete.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdConditionDelete.MarkFlagRequired("conditionId"))
+}
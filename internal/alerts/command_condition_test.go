@@ -0,0 +1,53 @@
+// +build unit
+
+package alerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestCondition(t *testing.T) {
+	assert.Equal(t, "condition", cmdCondition.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdCondition)
+	testcobra.CheckCobraRequiredFlags(t, cmdCondition, []string{})
+}
+
+func TestConditionList(t *testing.T) {
+	assert.Equal(t, "list", cmdConditionList.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdConditionList)
+	testcobra.CheckCobraRequiredFlags(t, cmdConditionList, []string{"accountId", "policyId"})
+}
+
+func TestConditionGet(t *testing.T) {
+	assert.Equal(t, "get", cmdConditionGet.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdConditionGet)
+	testcobra.CheckCobraRequiredFlags(t, cmdConditionGet, []string{"accountId", "conditionId"})
+}
+
+func TestConditionCreate(t *testing.T) {
+	assert.Equal(t, "create", cmdConditionCreate.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdConditionCreate)
+	testcobra.CheckCobraRequiredFlags(t, cmdConditionCreate, []string{"accountId", "policyId"})
+}
+
+func TestConditionUpdate(t *testing.T) {
+	assert.Equal(t, "update", cmdConditionUpdate.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdConditionUpdate)
+	testcobra.CheckCobraRequiredFlags(t, cmdConditionUpdate, []string{"accountId", "conditionId"})
+}
+
+func TestConditionDelete(t *testing.T) {
+	assert.Equal(t, "delete", cmdConditionDelete.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdConditionDelete)
+	testcobra.CheckCobraRequiredFlags(t, cmdConditionDelete, []string{"accountId", "conditionId"})
+}
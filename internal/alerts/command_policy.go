@@ -0,0 +1,218 @@
+package alerts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/output"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+var (
+	policyName         string
+	incidentPreference string
+)
+
+var cmdPolicy = &cobra.Command{
+	Use:   "policy",
+	Short: "Manage New Relic alert policies",
+	Long: `Manage New Relic alert policies
+
+The policy command allows users to list, create, update and delete the alert
+policies for an account. Use --help for more information.
+`,
+	Example: "newrelic alerts policy list --accountId 12345678",
+}
+
+var cmdPolicyList = &cobra.Command{
+	Use:   "list",
+	Short: "List the alert policies for an account.",
+	Long: `List the alert policies for an account
+
+The list command retrieves the alert policies for the given account ID.  The
+results can optionally be narrowed down to the policies whose name contains
+the given value.
+`,
+	Example: `newrelic alerts policy list --accountId 12345678 --name "Production"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			policies, err := nrClient.Alerts.QueryPolicySearchWithContext(utils.SignalCtx, accountID, alerts.AlertsPoliciesSearchCriteriaInput{})
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(filterPoliciesByName(policies, policyName)))
+		})
+	},
+}
+
+var cmdPolicyGet = &cobra.Command{
+	Use:   "get",
+	Short: "Get an alert policy.",
+	Long: `Get an alert policy
+
+The get command retrieves a specific alert policy by its ID.
+`,
+	Example: `newrelic alerts policy get --accountId 12345678 --policyId 1234`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			policy, err := nrClient.Alerts.QueryPolicyWithContext(utils.SignalCtx, accountID, strconv.Itoa(policyID))
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(policy))
+		})
+	},
+}
+
+var cmdPolicyCreate = &cobra.Command{
+	Use:   "create",
+	Short: "Create an alert policy.",
+	Long: `Create an alert policy
+
+The create command creates a new alert policy in the given account.  The
+incident preference determines how incidents are created for critical
+violations of the conditions in the policy, and must be one of PER_POLICY,
+PER_CONDITION or PER_CONDITION_AND_TARGET.
+`,
+	Example: `newrelic alerts policy create --accountId 12345678 --name "Production" --incidentPreference PER_CONDITION`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			preference, err := parseIncidentPreference(incidentPreference)
+			utils.LogIfFatal(err)
+
+			createInput := alerts.AlertsPolicyInput{
+				Name:               policyName,
+				IncidentPreference: preference,
+			}
+
+			policy, err := nrClient.Alerts.CreatePolicyMutationWithContext(utils.SignalCtx, accountID, createInput)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(policy))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdPolicyUpdate = &cobra.Command{
+	Use:   "update",
+	Short: "Update an alert policy.",
+	Long: `Update an alert policy
+
+The update command targets an existing alert policy by its ID, and updates its
+name and/or incident preference.  Fields that are not provided are left
+unchanged.
+`,
+	Example: `newrelic alerts policy update --accountId 12345678 --policyId 1234 --name "Production (EU)"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			updateInput := alerts.AlertsPolicyUpdateInput{
+				Name: policyName,
+			}
+
+			if incidentPreference != "" {
+				preference, err := parseIncidentPreference(incidentPreference)
+				utils.LogIfFatal(err)
+
+				updateInput.IncidentPreference = preference
+			}
+
+			policy, err := nrClient.Alerts.UpdatePolicyMutationWithContext(utils.SignalCtx, accountID, strconv.Itoa(policyID), updateInput)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(policy))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdPolicyDelete = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an alert policy.",
+	Long: `Delete an alert policy
+
+The delete command deletes an alert policy by its ID, along with all of the
+conditions it contains.
+`,
+	Example: `newrelic alerts policy delete --accountId 12345678 --policyId 1234`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			_, err := nrClient.Alerts.DeletePolicyMutationWithContext(utils.SignalCtx, accountID, strconv.Itoa(policyID))
+			utils.LogIfFatal(err)
+
+			log.Info("success")
+		})
+	},
+}
+
+func filterPoliciesByName(policies []*alerts.AlertsPolicy, name string) []*alerts.AlertsPolicy {
+	if name == "" {
+		return policies
+	}
+
+	filtered := []*alerts.AlertsPolicy{}
+	for _, p := range policies {
+		if strings.Contains(strings.ToLower(p.Name), strings.ToLower(name)) {
+			filtered = append(filtered, p)
+		}
+	}
+
+	return filtered
+}
+
+func parseIncidentPreference(value string) (alerts.AlertsIncidentPreference, error) {
+	switch p := alerts.AlertsIncidentPreference(strings.ToUpper(value)); p {
+	case alerts.AlertsIncidentPreferenceTypes.PER_POLICY,
+		alerts.AlertsIncidentPreferenceTypes.PER_CONDITION,
+		alerts.AlertsIncidentPreferenceTypes.PER_CONDITION_AND_TARGET:
+		return p, nil
+	}
+
+	return "", fmt.Errorf("incident preference must be one of PER_POLICY, PER_CONDITION or PER_CONDITION_AND_TARGET, got %q", value)
+}
+
+func init() {
+	Command.AddCommand(cmdPolicy)
+
+	// List
+	cmdPolicy.AddCommand(cmdPolicyList)
+	cmdPolicyList.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID you want to list policies for")
+	cmdPolicyList.Flags().StringVarP(&policyName, "name", "n", "", "only list policies whose name contains this value")
+	utils.LogIfError(cmdPolicyList.MarkFlagRequired("accountId"))
+
+	// Get
+	cmdPolicy.AddCommand(cmdPolicyGet)
+	cmdPolicyGet.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the policy is located")
+	cmdPolicyGet.Flags().IntVarP(&policyID, "policyId", "p", 0, "the ID of the alert policy")
+	utils.LogIfError(cmdPolicyGet.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdPolicyGet.MarkFlagRequired("policyId"))
+
+	// Create
+	cmdPolicy.AddCommand(cmdPolicyCreate)
+	cmdPolicyCreate.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where you want to create the policy")
+	cmdPolicyCreate.Flags().StringVarP(&policyName, "name", "n", "", "the name of the alert policy")
+	cmdPolicyCreate.Flags().StringVarP(&incidentPreference, "incidentPreference", "i", "PER_POLICY", "how incidents are created for the policy: PER_POLICY, PER_CONDITION or PER_CONDITION_AND_TARGET")
+	utils.LogIfError(cmdPolicyCreate.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdPolicyCreate.MarkFlagRequired("name"))
+
+	// Update
+	cmdPolicy.AddCommand(cmdPolicyUpdate)
+	cmdPolicyUpdate.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the policy is located")
+	cmdPolicyUpdate.Flags().IntVarP(&policyID, "policyId", "p", 0, "the ID of the alert policy you want to update")
+	cmdPolicyUpdate.Flags().StringVarP(&policyName, "name", "n", "", "the new name of the alert policy")
+	cmdPolicyUpdate.Flags().StringVarP(&incidentPreference, "incidentPreference", "i", "", "how incidents are created for the policy: PER_POLICY, PER_CONDITION or PER_CONDITION_AND_TARGET")
+	utils.LogIfError(cmdPolicyUpdate.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdPolicyUpdate.MarkFlagRequired("policyId"))
+
+	// Delete
+	cmdPolicy.AddCommand(cmdPolicyDelete)
+	cmdPolicyDelete.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the policy is located")
+	cmdPolicyDelete.Flags().IntVarP(&policyID, "policyId", "p", 0, "the ID of the alert policy you want to delete")
+	utils.LogIfError(cmdPolicyDelete.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdPolicyDelete.MarkFlagRequired("policyId"))
+}
@@ -0,0 +1,77 @@
+// +build unit
+
+package alerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+func TestPolicy(t *testing.T) {
+	assert.Equal(t, "policy", cmdPolicy.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdPolicy)
+	testcobra.CheckCobraRequiredFlags(t, cmdPolicy, []string{})
+}
+
+func TestPolicyList(t *testing.T) {
+	assert.Equal(t, "list", cmdPolicyList.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdPolicyList)
+	testcobra.CheckCobraRequiredFlags(t, cmdPolicyList, []string{"accountId"})
+}
+
+func TestPolicyGet(t *testing.T) {
+	assert.Equal(t, "get", cmdPolicyGet.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdPolicyGet)
+	testcobra.CheckCobraRequiredFlags(t, cmdPolicyGet, []string{"accountId", "policyId"})
+}
+
+func TestPolicyCreate(t *testing.T) {
+	assert.Equal(t, "create", cmdPolicyCreate.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdPolicyCreate)
+	testcobra.CheckCobraRequiredFlags(t, cmdPolicyCreate, []string{"accountId", "name"})
+}
+
+func TestPolicyUpdate(t *testing.T) {
+	assert.Equal(t, "update", cmdPolicyUpdate.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdPolicyUpdate)
+	testcobra.CheckCobraRequiredFlags(t, cmdPolicyUpdate, []string{"accountId", "policyId"})
+}
+
+func TestPolicyDelete(t *testing.T) {
+	assert.Equal(t, "delete", cmdPolicyDelete.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdPolicyDelete)
+	testcobra.CheckCobraRequiredFlags(t, cmdPolicyDelete, []string{"accountId", "policyId"})
+}
+
+func TestFilterPoliciesByName(t *testing.T) {
+	policies := []*alerts.AlertsPolicy{
+		{ID: "1", Name: "Production APM"},
+		{ID: "2", Name: "Staging APM"},
+	}
+
+	assert.Len(t, filterPoliciesByName(policies, ""), 2)
+
+	filtered := filterPoliciesByName(policies, "production")
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "1", filtered[0].ID)
+}
+
+func TestParseIncidentPreference(t *testing.T) {
+	p, err := parseIncidentPreference("per_condition")
+	require.NoError(t, err)
+	assert.Equal(t, alerts.AlertsIncidentPreferenceTypes.PER_CONDITION, p)
+
+	_, err = parseIncidentPreference("PER_ENTITY")
+	require.Error(t, err)
+}
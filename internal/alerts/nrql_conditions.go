@@ -0,0 +1,85 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+// nrqlConditionMutator is the subset of the alerts client needed to create and
+// update NRQL conditions of any type.
+type nrqlConditionMutator interface {
+	CreateNrqlConditionStaticMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error)
+	CreateNrqlConditionBaselineMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error)
+	CreateNrqlConditionOutlierMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error)
+	UpdateNrqlConditionStaticMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error)
+	UpdateNrqlConditionBaselineMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error)
+	UpdateNrqlConditionOutlierMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error)
+}
+
+// readNrqlConditionInput parses a NRQL condition definition from either an
+// inline JSON string or a JSON file.
+func readNrqlConditionInput(inline string, file string) (*alerts.NrqlConditionInput, error) {
+	var data []byte
+
+	switch {
+	case inline != "" && file != "":
+		return nil, errors.New("only one of --condition or --file can be provided")
+	case inline != "":
+		data = []byte(inline)
+	case file != "":
+		var err error
+		if data, err = ioutil.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("could not read condition file %s: %s", file, err)
+		}
+	default:
+		return nil, errors.New("one of --condition or --file is required")
+	}
+
+	input := alerts.NrqlConditionInput{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("error parsing provided condition: %s", err)
+	}
+
+	input.Type = alerts.NrqlConditionType(strings.ToUpper(string(input.Type)))
+	if input.Type == "" {
+		input.Type = alerts.NrqlConditionTypes.Static
+	}
+
+	return &input, nil
+}
+
+// createNrqlCondition creates a NRQL condition in the given policy using the
+// mutation that matches the condition's type.
+func createNrqlCondition(ctx context.Context, client nrqlConditionMutator, accountID int, policyID string, input alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error) {
+	switch input.Type {
+	case alerts.NrqlConditionTypes.Static:
+		return client.CreateNrqlConditionStaticMutationWithContext(ctx, accountID, policyID, input)
+	case alerts.NrqlConditionTypes.Baseline:
+		return client.CreateNrqlConditionBaselineMutationWithContext(ctx, accountID, policyID, input)
+	case alerts.NrqlConditionTypes.Outlier:
+		return client.CreateNrqlConditionOutlierMutationWithContext(ctx, accountID, policyID, input)
+	}
+
+	return nil, fmt.Errorf("unsupported NRQL condition type %q", input.Type)
+}
+
+// updateNrqlCondition updates an existing NRQL condition using the mutation
+// that matches the condition's type.
+func updateNrqlCondition(ctx context.Context, client nrqlConditionMutator, accountID int, conditionID string, input alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error) {
+	switch input.Type {
+	case alerts.NrqlConditionTypes.Static:
+		return client.UpdateNrqlConditionStaticMutationWithContext(ctx, accountID, conditionID, input)
+	case alerts.NrqlConditionTypes.Baseline:
+		return client.UpdateNrqlConditionBaselineMutationWithContext(ctx, accountID, conditionID, input)
+	case alerts.NrqlConditionTypes.Outlier:
+		return client.UpdateNrqlConditionOutlierMutationWithContext(ctx, accountID, conditionID, input)
+	}
+
+	return nil, fmt.Errorf("unsupported NRQL condition type %q", input.Type)
+}
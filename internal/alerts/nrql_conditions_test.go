@@ -0,0 +1,115 @@
+// +build unit
+
+package alerts
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+func TestReadNrqlConditionInput_Inline(t *testing.T) {
+	input, err := readNrqlConditionInput(`{"name":"High error rate","type":"baseline","nrql":{"query":"SELECT count(*) FROM TransactionError"}}`, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "High error rate", input.Name)
+	assert.Equal(t, alerts.NrqlConditionTypes.Baseline, input.Type)
+	assert.Equal(t, "SELECT count(*) FROM TransactionError", input.Nrql.Query)
+}
+
+func TestReadNrqlConditionInput_DefaultsToStatic(t *testing.T) {
+	input, err := readNrqlConditionInput(`{"name":"High error rate"}`, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, alerts.NrqlConditionTypes.Static, input.Type)
+}
+
+func TestReadNrqlConditionInput_File(t *testing.T) {
+	f, err := ioutil.TempFile("", "condition*.json")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`{"name":"From file"}`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	input, err := readNrqlConditionInput("", f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "From file", input.Name)
+}
+
+func TestReadNrqlConditionInput_Errors(t *testing.T) {
+	_, err := readNrqlConditionInput("", "")
+	require.Error(t, err)
+
+	_, err = readNrqlConditionInput("{}", "condition.json")
+	require.Error(t, err)
+
+	_, err = readNrqlConditionInput("not json", "")
+	require.Error(t, err)
+}
+
+func TestCreateNrqlCondition_DispatchesOnType(t *testing.T) {
+	m := &mockNrqlConditionMutator{}
+
+	input := alerts.NrqlConditionInput{}
+	input.Type = alerts.NrqlConditionTypes.Outlier
+
+	_, err := createNrqlCondition(context.Background(), m, 1, "2", input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"createOutlier"}, m.calls)
+
+	input.Type = "UNKNOWN"
+	_, err = createNrqlCondition(context.Background(), m, 1, "2", input)
+	require.Error(t, err)
+}
+
+func TestUpdateNrqlCondition_DispatchesOnType(t *testing.T) {
+	m := &mockNrqlConditionMutator{}
+
+	input := alerts.NrqlConditionInput{}
+	input.Type = alerts.NrqlConditionTypes.Baseline
+
+	_, err := updateNrqlCondition(context.Background(), m, 1, "3", input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"updateBaseline"}, m.calls)
+}
+
+type mockNrqlConditionMutator struct {
+	calls []string
+}
+
+func (m *mockNrqlConditionMutator) record(call string) (*alerts.NrqlAlertCondition, error) {
+	m.calls = append(m.calls, call)
+	return &alerts.NrqlAlertCondition{}, nil
+}
+
+func (m *mockNrqlConditionMutator) CreateNrqlConditionStaticMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error) {
+	return m.record("createStatic")
+}
+
+func (m *mockNrqlConditionMutator) CreateNrqlConditionBaselineMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error) {
+	return m.record("createBaseline")
+}
+
+func (m *mockNrqlConditionMutator) CreateNrqlConditionOutlierMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error) {
+	return m.record("createOutlier")
+}
+
+func (m *mockNrqlConditionMutator) UpdateNrqlConditionStaticMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error) {
+	return m.record("updateStatic")
+}
+
+func (m *mockNrqlConditionMutator) UpdateNrqlConditionBaselineMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error) {
+	return m.record("updateBaseline")
+}
+
+func (m *mockNrqlConditionMutator) UpdateNrqlConditionOutlierMutationWithContext(context.Context, int, string, alerts.NrqlConditionInput) (*alerts.NrqlAlertCondition, error) {
+	return m.record("updateOutlier")
+}
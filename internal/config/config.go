@@ -38,10 +38,17 @@ var (
 
 // Config contains the main CLI configuration
 type Config struct {
-	LogLevel           string  `mapstructure:"logLevel"`           // LogLevel for verbose output
-	PluginDir          string  `mapstructure:"pluginDir"`          // PluginDir is the directory where plugins will be installed
-	SendUsageData      Ternary `mapstructure:"sendUsageData"`      // SendUsageData enables sending usage statistics to New Relic
-	PreReleaseFeatures Ternary `mapstructure:"preReleaseFeatures"` // PreReleaseFeatures enables display on features within the CLI that are announced but not generally available to customers
+	LogLevel                string  `mapstructure:"logLevel"`                // LogLevel for verbose output
+	PluginDir               string  `mapstructure:"pluginDir"`               // PluginDir is the directory where plugins will be installed
+	SendUsageData           Ternary `mapstructure:"sendUsageData"`           // SendUsageData enables sending usage statistics to New Relic
+	PreReleaseFeatures      Ternary `mapstructure:"preReleaseFeatures"`      // PreReleaseFeatures enables display on features within the CLI that are announced but not generally available to customers
+	RecipeSource            string  `mapstructure:"recipeSource"`            // RecipeSource is a git repository or tarball URL to load install recipes from instead of New Relic
+	RecipeSourceRef         string  `mapstructure:"recipeSourceRef"`         // RecipeSourceRef is the branch, tag or commit of a git recipe source to use
+	RecipeSourceChecksums   string  `mapstructure:"recipeSourceChecksums"`   // RecipeSourceChecksums is the path of a sha256 checksum manifest within the recipe source to verify recipes against
+	RecipeSourcePublicKey   string  `mapstructure:"recipeSourcePublicKey"`   // RecipeSourcePublicKey is a base64 ed25519 public key to verify the checksum manifest's detached signature with
+	DownloadMirrorHosts     string  `mapstructure:"downloadMirrorHosts"`     // DownloadMirrorHosts is a comma separated list of hosts trusted to mirror download.newrelic.com in NEW_RELIC_DOWNLOAD_URL
+	DownloadMirrorPublicKey string  `mapstructure:"downloadMirrorPublicKey"` // DownloadMirrorPublicKey is a base64 ed25519 public key; mirrors serving a signature of their URL made with it are trusted
+	BundleChecksums         Ternary `mapstructure:"bundleChecksums"`         // BundleChecksums requires artifacts downloaded into install bundles to match the sha256 checksum published alongside them; it does not apply to install
 
 	configDir string
 }
@@ -67,6 +74,7 @@ func init() {
 		LogLevel:           DefaultLogLevel,
 		SendUsageData:      TernaryValues.Unknown,
 		PreReleaseFeatures: TernaryValues.Unknown,
+		BundleChecksums:    TernaryValues.Unknown,
 	}
 
 	cfgDir, err := utils.GetDefaultConfigDirectory()
@@ -326,7 +334,7 @@ func (c *Config) validate() error {
 			if !stringInStringsIgnoreCase(v.Value.(string), validValues) {
 				return fmt.Errorf("\"%s\" is not a valid %s value; Please use one of: %s", v.Value, v.Name, validValues)
 			}
-		case "sendusagedata", "prereleasefeatures":
+		case "sendusagedata", "prereleasefeatures", "bundlechecksums":
 			err := (v.Value.(Ternary)).Valid()
 			if err != nil {
 				return fmt.Errorf("invalid value for '%s': %s", v.Name, err)
@@ -100,6 +100,36 @@ func TestConfigSetPreReleaseFeatures(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestConfigSetBundleChecksums(t *testing.T) {
+	f, err := ioutil.TempDir("/tmp", "newrelic")
+	assert.NoError(t, err)
+	defer os.RemoveAll(f)
+
+	// Initialize the new configuration directory
+	c, err := LoadConfig(f)
+	assert.NoError(t, err)
+	assert.Equal(t, c.configDir, f)
+
+	// Set the valid bundle checksum values
+	for _, l := range []Ternary{
+		TernaryValues.Allow,
+		TernaryValues.Disallow,
+		TernaryValues.Unknown,
+	} {
+		err = c.Set("bundleChecksums", l)
+		assert.NoError(t, err)
+		assert.Equal(t, l, c.BundleChecksums)
+	}
+
+	err = c.Set("bundleChecksums", "INVALID_VALUE")
+	assert.Error(t, err)
+
+	// The invalid value is not written to disk
+	c2, err := LoadConfig(f)
+	assert.NoError(t, err)
+	assert.Equal(t, TernaryValues.Unknown, c2.BundleChecksums)
+}
+
 func TestConfigSetPluginDir(t *testing.T) {
 	f, err := ioutil.TempDir("/tmp", "newrelic")
 	assert.NoError(t, err)
@@ -0,0 +1,11 @@
+package dashboard
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Command represents the dashboard command.
+var Command = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Interact with New Relic One dashboards",
+}
@@ -0,0 +1,192 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/output"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+var (
+	accountID int
+	guid      string
+	file      string
+)
+
+var cmdList = &cobra.Command{
+	Use:   "list",
+	Short: "List the New Relic One dashboards for an account.",
+	Long: `List the New Relic One dashboards for an account
+
+The list command retrieves the dashboards for the given account ID.
+`,
+	Example: `newrelic dashboard list --accountId 12345678`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			builder := entities.EntitySearchQueryBuilder{
+				Type: entities.EntitySearchQueryBuilderTypeTypes.DASHBOARD,
+				Tags: []entities.EntitySearchQueryBuilderTag{
+					{
+						Key:   "accountId",
+						Value: strconv.Itoa(accountID),
+					},
+				},
+			}
+
+			results, err := nrClient.Entities.GetEntitySearchWithContext(utils.SignalCtx, entities.EntitySearchOptions{}, "", builder, nil)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(results.Results.Entities))
+		})
+	},
+}
+
+var cmdGet = &cobra.Command{
+	Use:   "get",
+	Short: "Get a New Relic One dashboard.",
+	Long: `Get a New Relic One dashboard
+
+The get command retrieves a specific dashboard by its entity GUID.
+`,
+	Example: `newrelic dashboard get --guid MjUyMDUyOHxWSVp8REFTSEJPQVJEfDE2NDYzMDQ`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			dashboard, err := nrClient.Entities.GetEntityWithContext(utils.SignalCtx, entities.EntityGUID(guid))
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(dashboard))
+		})
+	},
+}
+
+var cmdExport = &cobra.Command{
+	Use:   "export",
+	Short: "Export a New Relic One dashboard as JSON.",
+	Long: `Export a New Relic One dashboard as JSON
+
+The export command retrieves a dashboard by its entity GUID and writes its
+definition as JSON.  The output uses the same shape as the JSON export in the
+New Relic One UI, so it can be used with the dashboard import command and with
+the utils terraform dashboard command.  Output is sent to STDOUT by default but
+can be redirected to a file with the --file option.
+`,
+	Example: `newrelic dashboard export --guid MjUyMDUyOHxWSVp8REFTSEJPQVJEfDE2NDYzMDQ --file dashboard.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			dashboard, err := FetchDashboardInput(utils.SignalCtx, &nrClient.NerdGraph, guid)
+			utils.LogIfFatal(err)
+
+			data, err := json.MarshalIndent(dashboard, "", "  ")
+			utils.LogIfFatal(err)
+
+			if file == "" {
+				fmt.Println(string(data))
+				return
+			}
+
+			utils.LogIfFatal(ioutil.WriteFile(file, data, 0644))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdImport = &cobra.Command{
+	Use:   "import",
+	Short: "Import a New Relic One dashboard from JSON.",
+	Long: `Import a New Relic One dashboard from JSON
+
+The import command creates a new dashboard in the given account from a JSON
+definition, as produced by the dashboard export command or the JSON export in
+the New Relic One UI.  Every account ID referenced by the dashboard's queries
+is rewritten to the target account ID.
+`,
+	Example: `newrelic dashboard import --accountId 12345678 --file dashboard.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			body, err := ioutil.ReadFile(file)
+			utils.LogIfFatal(err)
+
+			dashboard, err := TransformDashboardJSON(body, accountID)
+			utils.LogIfFatal(err)
+
+			result, err := nrClient.Dashboards.DashboardCreateWithContext(utils.SignalCtx, accountID, dashboard)
+			utils.LogIfFatal(err)
+
+			for _, e := range result.Errors {
+				log.Errorf("%s: %s", e.Type, e.Description)
+			}
+
+			if len(result.Errors) > 0 {
+				log.Fatal("dashboard import failed")
+			}
+
+			utils.LogIfFatal(output.Print(result.EntityResult))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdDelete = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a New Relic One dashboard.",
+	Long: `Delete a New Relic One dashboard
+
+The delete command accepts a dashboard's entity GUID.
+`,
+	Example: `newrelic dashboard delete --guid MjUyMDUyOHxWSVp8REFTSEJPQVJEfDE2NDYzMDQ`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			result, err := nrClient.Dashboards.DashboardDeleteWithContext(utils.SignalCtx, entities.EntityGUID(guid))
+			utils.LogIfFatal(err)
+
+			for _, e := range result.Errors {
+				log.Errorf("%s: %s", e.Type, e.Description)
+			}
+
+			if len(result.Errors) > 0 {
+				log.Fatal("dashboard delete failed")
+			}
+
+			log.Info("success")
+		})
+	},
+}
+
+func init() {
+	// List
+	Command.AddCommand(cmdList)
+	cmdList.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID you want to list dashboards for")
+	utils.LogIfError(cmdList.MarkFlagRequired("accountId"))
+
+	// Get
+	Command.AddCommand(cmdGet)
+	cmdGet.Flags().StringVarP(&guid, "guid", "g", "", "the GUID of the dashboard")
+	utils.LogIfError(cmdGet.MarkFlagRequired("guid"))
+
+	// Export
+	Command.AddCommand(cmdExport)
+	cmdExport.Flags().StringVarP(&guid, "guid", "g", "", "the GUID of the dashboard to export")
+	cmdExport.Flags().StringVarP(&file, "file", "f", "", "the file to write the dashboard JSON to")
+	utils.LogIfError(cmdExport.MarkFlagRequired("guid"))
+
+	// Import
+	Command.AddCommand(cmdImport)
+	cmdImport.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where you want to create the dashboard")
+	cmdImport.Flags().StringVarP(&file, "file", "f", "", "a file that contains exported dashboard JSON")
+	utils.LogIfError(cmdImport.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdImport.MarkFlagRequired("file"))
+
+	// Delete
+	Command.AddCommand(cmdDelete)
+	cmdDelete.Flags().StringVarP(&guid, "guid", "g", "", "the GUID of the dashboard to delete")
+	utils.LogIfError(cmdDelete.MarkFlagRequired("guid"))
+}
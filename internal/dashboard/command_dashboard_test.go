@@ -0,0 +1,46 @@
+// +build unit
+
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestList(t *testing.T) {
+	assert.Equal(t, "list", cmdList.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdList)
+	testcobra.CheckCobraRequiredFlags(t, cmdList, []string{"accountId"})
+}
+
+func TestGet(t *testing.T) {
+	assert.Equal(t, "get", cmdGet.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdGet)
+	testcobra.CheckCobraRequiredFlags(t, cmdGet, []string{"guid"})
+}
+
+func TestExport(t *testing.T) {
+	assert.Equal(t, "export", cmdExport.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdExport)
+	testcobra.CheckCobraRequiredFlags(t, cmdExport, []string{"guid"})
+}
+
+func TestImport(t *testing.T) {
+	assert.Equal(t, "import", cmdImport.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdImport)
+	testcobra.CheckCobraRequiredFlags(t, cmdImport, []string{"accountId", "file"})
+}
+
+func TestDelete(t *testing.T) {
+	assert.Equal(t, "delete", cmdDelete.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdDelete)
+	testcobra.CheckCobraRequiredFlags(t, cmdDelete, []string{"guid"})
+}
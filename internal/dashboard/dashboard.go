@@ -0,0 +1,214 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+var accountIDRegex = regexp.MustCompile(`"accountId"\s*:\s*\d+`)
+
+// NerdGraphClient is the subset of the NerdGraph client needed to fetch
+// dashboard definitions.
+type NerdGraphClient interface {
+	QueryWithResponseAndContext(context.Context, string, map[string]interface{}, interface{}) error
+}
+
+// FetchDashboardInput retrieves a dashboard through NerdGraph and returns it
+// in the same JSON shape as the dashboard JSON export, which is accepted by
+// the dashboard import and Terraform generation commands.
+func FetchDashboardInput(ctx context.Context, client NerdGraphClient, guid string) (*dashboards.DashboardInput, error) {
+	var resp dashboardQueryResult
+
+	vars := map[string]interface{}{
+		"guid": guid,
+	}
+
+	if err := client.QueryWithResponseAndContext(ctx, dashboardQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	e := resp.Actor.Entity
+	if e.Name == "" {
+		return nil, fmt.Errorf("no dashboard found with GUID %s", guid)
+	}
+
+	d := &dashboards.DashboardInput{
+		Name:        e.Name,
+		Description: e.Description,
+		Permissions: e.Permissions,
+	}
+
+	for _, p := range e.Pages {
+		page := dashboards.DashboardPageInput{
+			Name:        p.Name,
+			Description: p.Description,
+		}
+
+		for _, w := range p.Widgets {
+			widget := dashboards.DashboardWidgetInput{
+				Title:            w.Title,
+				Layout:           w.Layout,
+				Visualization:    w.Visualization,
+				RawConfiguration: w.RawConfiguration,
+			}
+
+			for _, l := range w.LinkedEntities {
+				widget.LinkedEntityGUIDs = append(widget.LinkedEntityGUIDs, l.GUID)
+			}
+
+			page.Widgets = append(page.Widgets, widget)
+		}
+
+		d.Pages = append(d.Pages, page)
+	}
+
+	return d, nil
+}
+
+// ListAccountDashboardGUIDs returns the GUIDs of the dashboards in an account,
+// following the entity search cursor until every page of results has been
+// read.  Dashboard pages, which are also dashboard entities, are left out.
+func ListAccountDashboardGUIDs(ctx context.Context, client NerdGraphClient, accountID int) ([]string, error) {
+	guids := []string{}
+
+	vars := map[string]interface{}{
+		"query": fmt.Sprintf("type = 'DASHBOARD' AND accountId = %d", accountID),
+	}
+
+	for {
+		var resp dashboardSearchResult
+
+		if err := client.QueryWithResponseAndContext(ctx, dashboardSearchQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		results := resp.Actor.EntitySearch.Results
+		for _, e := range results.Entities {
+			if e.DashboardParentGUID == "" {
+				guids = append(guids, string(e.GUID))
+			}
+		}
+
+		if results.NextCursor == "" {
+			return guids, nil
+		}
+
+		vars["cursor"] = results.NextCursor
+	}
+}
+
+// RewriteAccountIDs replaces every account ID referenced by the given
+// dashboard JSON with the provided account ID.
+func RewriteAccountIDs(body []byte, accountID int) []byte {
+	return accountIDRegex.ReplaceAll(body, []byte(fmt.Sprintf(`"accountId": %d`, accountID)))
+}
+
+// TransformDashboardJSON parses exported dashboard JSON, pointing all of its
+// queries at the provided account ID.
+func TransformDashboardJSON(body []byte, accountID int) (dashboards.DashboardInput, error) {
+	dashboard := dashboards.DashboardInput{}
+
+	if err := json.Unmarshal(RewriteAccountIDs(body, accountID), &dashboard); err != nil {
+		return dashboard, err
+	}
+
+	return dashboard, nil
+}
+
+type dashboardQueryResult struct {
+	Actor struct {
+		Entity dashboardEntity `json:"entity"`
+	} `json:"actor"`
+}
+
+type dashboardEntity struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description"`
+	Permissions entities.DashboardPermissions `json:"permissions"`
+	Pages       []dashboardPage               `json:"pages"`
+}
+
+type dashboardPage struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Widgets     []dashboardWidget `json:"widgets"`
+}
+
+type dashboardWidget struct {
+	Title            string                                       `json:"title"`
+	Layout           dashboards.DashboardWidgetLayoutInput        `json:"layout"`
+	Visualization    dashboards.DashboardWidgetVisualizationInput `json:"visualization"`
+	RawConfiguration entities.DashboardWidgetRawConfiguration     `json:"rawConfiguration"`
+	LinkedEntities   []struct {
+		GUID entities.EntityGUID `json:"guid"`
+	} `json:"linkedEntities"`
+}
+
+type dashboardSearchResult struct {
+	Actor struct {
+		EntitySearch struct {
+			Results struct {
+				NextCursor string `json:"nextCursor"`
+				Entities   []struct {
+					GUID                entities.EntityGUID `json:"guid"`
+					DashboardParentGUID entities.EntityGUID `json:"dashboardParentGuid"`
+				} `json:"entities"`
+			} `json:"results"`
+		} `json:"entitySearch"`
+	} `json:"actor"`
+}
+
+const dashboardSearchQuery = `
+query($query: String!, $cursor: String) {
+	actor {
+		entitySearch(query: $query) {
+			results(cursor: $cursor) {
+				nextCursor
+				entities {
+					guid
+					... on DashboardEntityOutline {
+						dashboardParentGuid
+					}
+				}
+			}
+		}
+	}
+}`
+
+const dashboardQuery = `
+query($guid: EntityGuid!) {
+	actor {
+		entity(guid: $guid) {
+			... on DashboardEntity {
+				name
+				description
+				permissions
+				pages {
+					name
+					description
+					widgets {
+						title
+						layout {
+							column
+							row
+							height
+							width
+						}
+						visualization {
+							id
+						}
+						rawConfiguration
+						linkedEntities {
+							guid
+						}
+					}
+				}
+			}
+		}
+	}
+}`
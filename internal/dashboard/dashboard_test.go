@@ -0,0 +1,116 @@
+// +build unit
+
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+func TestFetchDashboardInput(t *testing.T) {
+	c := &mockNerdGraphClient{resp: `{
+		"actor": {
+			"entity": {
+				"name": "Apache",
+				"description": "Apache overview",
+				"permissions": "PUBLIC_READ_ONLY",
+				"pages": [{
+					"name": "Overview",
+					"widgets": [{
+						"title": "Servers Reporting",
+						"layout": {"column": 1, "row": 1, "height": 3, "width": 4},
+						"visualization": {"id": "viz.billboard"},
+						"rawConfiguration": {"nrqlQueries": [{"accountId": 1, "query": "SELECT count(*) FROM ApacheSample"}]},
+						"linkedEntities": [{"guid": "MTIzNA"}]
+					}]
+				}]
+			}
+		}
+	}`}
+
+	d, err := FetchDashboardInput(context.Background(), c, "MjUy")
+	require.NoError(t, err)
+
+	assert.Equal(t, "MjUy", c.vars["guid"])
+	assert.Equal(t, "Apache", d.Name)
+	assert.Equal(t, entities.DashboardPermissionsTypes.PUBLIC_READ_ONLY, d.Permissions)
+	require.Len(t, d.Pages, 1)
+	require.Len(t, d.Pages[0].Widgets, 1)
+
+	w := d.Pages[0].Widgets[0]
+	assert.Equal(t, "viz.billboard", w.Visualization.ID)
+	assert.Equal(t, 4, w.Layout.Width)
+	assert.Equal(t, []entities.EntityGUID{"MTIzNA"}, w.LinkedEntityGUIDs)
+	assert.Contains(t, string(w.RawConfiguration), "SELECT count(*) FROM ApacheSample")
+}
+
+func TestFetchDashboardInput_NotFound(t *testing.T) {
+	c := &mockNerdGraphClient{resp: `{"actor": {"entity": null}}`}
+
+	_, err := FetchDashboardInput(context.Background(), c, "MjUy")
+	require.Error(t, err)
+}
+
+func TestListAccountDashboardGUIDs(t *testing.T) {
+	c := &mockNerdGraphClient{pages: []string{
+		`{"actor": {"entitySearch": {"results": {"nextCursor": "page2", "entities": [
+			{"guid": "MQ"},
+			{"guid": "MQ-page", "dashboardParentGuid": "MQ"}
+		]}}}}`,
+		`{"actor": {"entitySearch": {"results": {"nextCursor": null, "entities": [{"guid": "Mg"}]}}}}`,
+	}}
+
+	guids, err := ListAccountDashboardGUIDs(context.Background(), c, 12345)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MQ", "Mg"}, guids)
+	assert.Equal(t, []interface{}{nil, "page2"}, c.cursors)
+	assert.Equal(t, "type = 'DASHBOARD' AND accountId = 12345", c.vars["query"])
+}
+
+func TestRewriteAccountIDs(t *testing.T) {
+	body := []byte(`{"nrqlQueries":[{"accountId": 0,"query":"a"},{"accountId":12345,"query":"b"}]}`)
+
+	assert.Equal(t,
+		`{"nrqlQueries":[{"accountId": 999,"query":"a"},{"accountId": 999,"query":"b"}]}`,
+		string(RewriteAccountIDs(body, 999)),
+	)
+}
+
+func TestTransformDashboardJSON(t *testing.T) {
+	body := []byte(`{"name":"Apache","permissions":"PRIVATE","pages":[{"name":"Apache","widgets":[{"visualization":{"id":"viz.billboard"},"title":"Servers","rawConfiguration":{"nrqlQueries":[{"accountId":1,"query":"SELECT 1"}]}}]}]}`)
+
+	d, err := TransformDashboardJSON(body, 12345)
+	require.NoError(t, err)
+
+	transformed, err := json.Marshal(d)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(transformed), `"accountId":12345`)
+	assert.Equal(t, entities.DashboardPermissionsTypes.PRIVATE, d.Permissions)
+}
+
+type mockNerdGraphClient struct {
+	resp string
+	// pages, when set, are returned in turn instead of resp.
+	pages   []string
+	vars    map[string]interface{}
+	cursors []interface{}
+}
+
+func (c *mockNerdGraphClient) QueryWithResponseAndContext(ctx context.Context, query string, vars map[string]interface{}, respBody interface{}) error {
+	c.vars = vars
+	c.cursors = append(c.cursors, vars["cursor"])
+
+	resp := c.resp
+	if len(c.pages) > 0 {
+		resp, c.pages = c.pages[0], c.pages[1:]
+	}
+
+	return json.Unmarshal([]byte(resp), respBody)
+}
@@ -0,0 +1,11 @@
+package incidents
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Command represents the incidents command.
+var Command = &cobra.Command{
+	Use:   "incidents",
+	Short: "Interact with New Relic alert incidents and violations",
+}
@@ -0,0 +1,157 @@
+package incidents
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/output"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+)
+
+var (
+	accountID     int
+	incidentID    int
+	policy        string
+	priority      string
+	entity        string
+	since         string
+	includeClosed bool
+	interval      time.Duration
+)
+
+var cmdList = &cobra.Command{
+	Use:   "list",
+	Short: "List the open alert violations for an account.",
+	Long: `List the open alert violations for an account
+
+The list command retrieves the alert violations for the given account ID from
+the NrAiIncident event type, and shows the ones that are still open.  The
+results can be narrowed down by policy ID or name, by priority, and by entity
+GUID or name.  Use --all to include violations that have already closed.
+
+The incidentId shown for each violation is the NrAiIncident violation ID.  It
+is not the alert incident ID that the ack and close commands expect.
+`,
+	Example: `newrelic incidents list --accountId 12345678 --priority critical --policy "Production"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		f := Filter{Policy: policy, Priority: priority, Entity: entity}
+		utils.LogIfFatal(f.Validate())
+
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			events, err := FetchEvents(utils.SignalCtx, &nrClient.Nrdb, accountID, f, since)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(Summarize(events, includeClosed)))
+		})
+	},
+}
+
+var cmdWatch = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch for alert violations as they open and close.",
+	Long: `Watch for alert violations as they open and close
+
+The watch command polls the given account for alert violation events, and
+prints each violation as it opens or closes.  Only events that occur after the
+command starts are shown.  The same policy, priority and entity filters as the
+list command are supported.  Press Ctrl+C to stop watching.
+`,
+	Example: `newrelic incidents watch --accountId 12345678 --priority critical --interval 30s`,
+	Run: func(cmd *cobra.Command, args []string) {
+		f := Filter{Policy: policy, Priority: priority, Entity: entity}
+		utils.LogIfFatal(f.Validate())
+
+		if interval <= 0 {
+			log.Fatalf("interval must be greater than 0, got %s", interval)
+		}
+
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			w := NewWatcher(&nrClient.Nrdb, accountID, f, interval)
+
+			log.Infof("watching for alert violations in account %d", accountID)
+			err := w.Watch(utils.SignalCtx, func(e Event) {
+				utils.LogIfError(output.Print(e))
+			})
+			utils.LogIfFatal(err)
+		})
+	},
+}
+
+var cmdAck = &cobra.Command{
+	Use:   "ack",
+	Short: "Acknowledge an alert incident.",
+	Long: `Acknowledge an alert incident
+
+The ack command acknowledges an open alert incident by its alert incident ID,
+as shown on the incident's page in New Relic Alerts and returned by the REST
+API's alerts_incidents endpoint.  The violation IDs shown by the list and watch
+commands are a different ID, and cannot be used here.
+`,
+	Example: `newrelic incidents ack --incidentId 12345  # the alert incident ID, not a violation ID from incidents list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			incident, err := nrClient.Alerts.AcknowledgeIncidentWithContext(utils.SignalCtx, incidentID)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(incident))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdClose = &cobra.Command{
+	Use:   "close",
+	Short: "Close an alert incident.",
+	Long: `Close an alert incident
+
+The close command manually closes an open alert incident by its alert incident
+ID, as shown on the incident's page in New Relic Alerts and returned by the
+REST API's alerts_incidents endpoint.  The violation IDs shown by the list and
+watch commands are a different ID, and cannot be used here.
+`,
+	Example: `newrelic incidents close --incidentId 12345  # the alert incident ID, not a violation ID from incidents list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			incident, err := nrClient.Alerts.CloseIncidentWithContext(utils.SignalCtx, incidentID)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(incident))
+			log.Info("success")
+		})
+	},
+}
+
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID to show violations for")
+	cmd.Flags().StringVarP(&policy, "policy", "p", "", "only show violations for this policy ID or name")
+	cmd.Flags().StringVar(&priority, "priority", "", "only show violations with this priority: critical or warning")
+	cmd.Flags().StringVarP(&entity, "entity", "e", "", "only show violations for this entity GUID or name")
+	utils.LogIfError(cmd.MarkFlagRequired("accountId"))
+}
+
+func init() {
+	// List
+	Command.AddCommand(cmdList)
+	addFilterFlags(cmdList)
+	cmdList.Flags().StringVarP(&since, "since", "s", "1 day ago", "how far back to look for violations, as a NRQL SINCE clause")
+	cmdList.Flags().BoolVar(&includeClosed, "all", false, "include violations that have closed")
+
+	// Watch
+	Command.AddCommand(cmdWatch)
+	addFilterFlags(cmdWatch)
+	cmdWatch.Flags().DurationVarP(&interval, "interval", "i", 30*time.Second, "how often to poll for new events")
+
+	// Ack
+	Command.AddCommand(cmdAck)
+	cmdAck.Flags().IntVarP(&incidentID, "incidentId", "i", 0, "the alert incident ID to acknowledge, not a violation ID from incidents list")
+	utils.LogIfError(cmdAck.MarkFlagRequired("incidentId"))
+
+	// Close
+	Command.AddCommand(cmdClose)
+	cmdClose.Flags().IntVarP(&incidentID, "incidentId", "i", 0, "the alert incident ID to close, not a violation ID from incidents list")
+	utils.LogIfError(cmdClose.MarkFlagRequired("incidentId"))
+}
@@ -0,0 +1,39 @@
+// +build unit
+
+package incidents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestList(t *testing.T) {
+	assert.Equal(t, "list", cmdList.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdList)
+	testcobra.CheckCobraRequiredFlags(t, cmdList, []string{"accountId"})
+}
+
+func TestWatch(t *testing.T) {
+	assert.Equal(t, "watch", cmdWatch.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdWatch)
+	testcobra.CheckCobraRequiredFlags(t, cmdWatch, []string{"accountId"})
+}
+
+func TestAck(t *testing.T) {
+	assert.Equal(t, "ack", cmdAck.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdAck)
+	testcobra.CheckCobraRequiredFlags(t, cmdAck, []string{"incidentId"})
+}
+
+func TestClose(t *testing.T) {
+	assert.Equal(t, "close", cmdClose.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdClose)
+	testcobra.CheckCobraRequiredFlags(t, cmdClose, []string{"incidentId"})
+}
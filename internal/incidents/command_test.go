@@ -0,0 +1,18 @@
+// +build unit
+
+package incidents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestIncidentsCommand(t *testing.T) {
+	assert.Equal(t, "incidents", Command.Name())
+
+	testcobra.CheckCobraMetadata(t, Command)
+	testcobra.CheckCobraRequiredFlags(t, Command, []string{})
+}
@@ -0,0 +1,273 @@
+package incidents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
+)
+
+const (
+	eventOpen  = "open"
+	eventClose = "close"
+
+	// watchLookback is how far behind the last seen event each watch poll
+	// looks, to pick up events that were ingested late.
+	watchLookback = 5 * time.Minute
+)
+
+// Event represents a single open or close event of an alert violation, as
+// recorded in the NrAiIncident event type.
+type Event struct {
+	IncidentID    int64  `json:"incidentId"`
+	Event         string `json:"event"`
+	Priority      string `json:"priority"`
+	Title         string `json:"title,omitempty"`
+	PolicyID      int64  `json:"policyId,omitempty"`
+	PolicyName    string `json:"policyName,omitempty"`
+	ConditionID   int64  `json:"conditionId,omitempty"`
+	ConditionName string `json:"conditionName,omitempty"`
+	EntityGUID    string `json:"entity.guid,omitempty"`
+	EntityName    string `json:"entity.name,omitempty"`
+	OpenTime      int64  `json:"openTime,omitempty"`
+	CloseTime     int64  `json:"closeTime,omitempty"`
+	Timestamp     int64  `json:"timestamp"`
+}
+
+func (e Event) key() string {
+	return fmt.Sprintf("%d:%s", e.IncidentID, e.Event)
+}
+
+// Filter narrows down the violations returned for an account.
+type Filter struct {
+	// Policy matches either a policy ID or a policy name.
+	Policy string
+	// Priority matches the violation priority, either critical or warning.
+	Priority string
+	// Entity matches either an entity GUID or an entity name.
+	Entity string
+}
+
+// Validate checks that the filter values are supported.
+func (f Filter) Validate() error {
+	switch strings.ToLower(f.Priority) {
+	case "", "critical", "warning":
+		return nil
+	}
+
+	return fmt.Errorf("priority must be one of critical or warning, got %q", f.Priority)
+}
+
+func (f Filter) whereClause() string {
+	clauses := []string{}
+
+	if f.Policy != "" {
+		if id, err := strconv.Atoi(f.Policy); err == nil {
+			clauses = append(clauses, fmt.Sprintf("policyId = %d", id))
+		} else {
+			clauses = append(clauses, fmt.Sprintf("policyName = '%s'", utils.EscapeNRQLString(f.Policy)))
+		}
+	}
+
+	if f.Priority != "" {
+		clauses = append(clauses, fmt.Sprintf("priority = '%s'", utils.EscapeNRQLString(strings.ToLower(f.Priority))))
+	}
+
+	if f.Entity != "" {
+		e := utils.EscapeNRQLString(f.Entity)
+		clauses = append(clauses, fmt.Sprintf("(entity.guid = '%s' OR entity.name = '%s')", e, e))
+	}
+
+	if len(clauses) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(clauses, " AND ")
+}
+
+func buildQuery(f Filter, since string) string {
+	return fmt.Sprintf("SELECT * FROM NrAiIncident%s SINCE %s LIMIT MAX", f.whereClause(), since)
+}
+
+// FetchEvents returns the violation events matching the filter that occurred
+// since the given NRQL time expression, oldest first.
+func FetchEvents(ctx context.Context, client utils.NRDBClient, accountID int, f Filter, since string) ([]Event, error) {
+	result, err := client.QueryWithContext(ctx, accountID, nrdb.NRQL(buildQuery(f, since)))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(result.Results))
+	for _, r := range result.Results {
+		e, parseErr := toEvent(r)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		events = append(events, e)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+
+	return events, nil
+}
+
+func toEvent(r nrdb.NRDBResult) (Event, error) {
+	e := Event{}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return e, err
+	}
+
+	if err = json.Unmarshal(data, &e); err != nil {
+		return e, fmt.Errorf("could not parse NrAiIncident event: %s", err)
+	}
+
+	return e, nil
+}
+
+// Summarize folds open and close events into one entry per violation, most
+// recently opened first.  Closed violations are only included when
+// includeClosed is set.
+func Summarize(events []Event, includeClosed bool) []Event {
+	byID := map[int64]*Event{}
+	ids := []int64{}
+
+	for _, e := range events {
+		existing, ok := byID[e.IncidentID]
+		if !ok {
+			copied := e
+			byID[e.IncidentID] = &copied
+			ids = append(ids, e.IncidentID)
+			continue
+		}
+
+		if e.Event == eventClose {
+			existing.Event = eventClose
+			existing.CloseTime = e.CloseTime
+			existing.Timestamp = e.Timestamp
+		}
+	}
+
+	summary := []Event{}
+	for _, id := range ids {
+		e := byID[id]
+		if e.Event == eventClose && !includeClosed {
+			continue
+		}
+
+		summary = append(summary, *e)
+	}
+
+	sort.SliceStable(summary, func(i, j int) bool {
+		return summary[i].OpenTime > summary[j].OpenTime
+	})
+
+	return summary
+}
+
+// Watcher polls NRDB for violation events that occur after it starts.
+type Watcher struct {
+	Interval  time.Duration
+	client    utils.NRDBClient
+	accountID int
+	filter    Filter
+	start     time.Time
+	lastPoll  time.Time
+	seen      map[string]time.Time
+}
+
+// NewWatcher returns a new instance of Watcher, which reports events that
+// occur from now on.
+func NewWatcher(client utils.NRDBClient, accountID int, f Filter, interval time.Duration) *Watcher {
+	now := time.Now()
+
+	return &Watcher{
+		Interval:  interval,
+		client:    client,
+		accountID: accountID,
+		filter:    f,
+		start:     now,
+		lastPoll:  now,
+		seen:      map[string]time.Time{},
+	}
+}
+
+// Poll returns the events that have occurred since the previous poll.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	pollTime := time.Now()
+
+	from := w.lastPoll.Add(-watchLookback)
+	if from.Before(w.start) {
+		from = w.start
+	}
+
+	events, err := FetchEvents(ctx, w.client, w.accountID, w.filter, strconv.FormatInt(from.UnixNano()/int64(time.Millisecond), 10))
+	if err != nil {
+		return nil, err
+	}
+
+	newEvents := []Event{}
+	for _, e := range events {
+		ts := time.Unix(0, e.Timestamp*int64(time.Millisecond))
+		if ts.Before(w.start) {
+			continue
+		}
+
+		if _, ok := w.seen[e.key()]; ok {
+			continue
+		}
+
+		w.seen[e.key()] = ts
+		newEvents = append(newEvents, e)
+	}
+
+	w.lastPoll = pollTime
+	w.forget(from)
+
+	return newEvents, nil
+}
+
+// forget drops seen events that fall outside of the lookback window, since
+// they can no longer be returned by a poll.
+func (w *Watcher) forget(before time.Time) {
+	for k, ts := range w.seen {
+		if ts.Before(before) {
+			delete(w.seen, k)
+		}
+	}
+}
+
+// Watch polls until the context is cancelled, calling f for every new event.
+func (w *Watcher) Watch(ctx context.Context, f func(Event)) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := w.Poll(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			f(e)
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// +build unit
+
+package incidents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
+)
+
+func TestBuildQuery(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM NrAiIncident SINCE 1 day ago LIMIT MAX", buildQuery(Filter{}, "1 day ago"))
+
+	q := buildQuery(Filter{Policy: "1234", Priority: "CRITICAL"}, "1 hour ago")
+	assert.Equal(t, "SELECT * FROM NrAiIncident WHERE policyId = 1234 AND priority = 'critical' SINCE 1 hour ago LIMIT MAX", q)
+
+	q = buildQuery(Filter{Policy: "Bob's policy", Entity: "web-01"}, "1 hour ago")
+	assert.Equal(t, `SELECT * FROM NrAiIncident WHERE policyName = 'Bob\'s policy' AND (entity.guid = 'web-01' OR entity.name = 'web-01') SINCE 1 hour ago LIMIT MAX`, q)
+
+	q = buildQuery(Filter{Policy: `web\' OR 1=1`}, "1 hour ago")
+	assert.Equal(t, `SELECT * FROM NrAiIncident WHERE policyName = 'web\\\' OR 1=1' SINCE 1 hour ago LIMIT MAX`, q)
+}
+
+func TestFilterValidate(t *testing.T) {
+	require.NoError(t, Filter{}.Validate())
+	require.NoError(t, Filter{Priority: "Warning"}.Validate())
+	require.Error(t, Filter{Priority: "high"}.Validate())
+}
+
+func TestFetchEvents(t *testing.T) {
+	c := &mockNRDBClient{results: [][]nrdb.NRDBResult{{
+		{"incidentId": float64(2), "event": "open", "priority": "warning", "timestamp": float64(2000), "entity.name": "web-02"},
+		{"incidentId": float64(1), "event": "open", "priority": "critical", "timestamp": float64(1000), "entity.name": "web-01"},
+	}}}
+
+	events, err := FetchEvents(context.Background(), c, 1, Filter{}, "1 day ago")
+	require.NoError(t, err)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(1), events[0].IncidentID)
+	assert.Equal(t, "web-01", events[0].EntityName)
+	assert.Equal(t, "critical", events[0].Priority)
+}
+
+func TestSummarize(t *testing.T) {
+	events := []Event{
+		{IncidentID: 1, Event: "open", OpenTime: 1000, Timestamp: 1000},
+		{IncidentID: 2, Event: "open", OpenTime: 2000, Timestamp: 2000},
+		{IncidentID: 1, Event: "close", OpenTime: 1000, CloseTime: 3000, Timestamp: 3000},
+	}
+
+	open := Summarize(events, false)
+	require.Len(t, open, 1)
+	assert.Equal(t, int64(2), open[0].IncidentID)
+
+	all := Summarize(events, true)
+	require.Len(t, all, 2)
+	assert.Equal(t, int64(2), all[0].IncidentID)
+	assert.Equal(t, "close", all[1].Event)
+	assert.Equal(t, int64(3000), all[1].CloseTime)
+}
+
+func TestWatcherPoll(t *testing.T) {
+	now := time.Now()
+	ms := func(t time.Time) float64 {
+		return float64(t.UnixNano() / int64(time.Millisecond))
+	}
+
+	c := &mockNRDBClient{results: [][]nrdb.NRDBResult{
+		{
+			{"incidentId": float64(1), "event": "open", "timestamp": ms(now.Add(-time.Hour))},
+			{"incidentId": float64(2), "event": "open", "timestamp": ms(now.Add(time.Second))},
+		},
+		{
+			{"incidentId": float64(2), "event": "open", "timestamp": ms(now.Add(time.Second))},
+			{"incidentId": float64(2), "event": "close", "timestamp": ms(now.Add(2 * time.Second))},
+		},
+	}}
+
+	w := NewWatcher(c, 1, Filter{}, time.Second)
+	w.start = now
+	w.lastPoll = now
+
+	events, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(2), events[0].IncidentID)
+	assert.Equal(t, "open", events[0].Event)
+
+	events, err = w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "close", events[0].Event)
+}
+
+func TestWatcherWatch_StopsOnCancel(t *testing.T) {
+	c := &mockNRDBClient{}
+	w := NewWatcher(c, 1, Filter{}, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.NoError(t, w.Watch(ctx, func(Event) {}))
+	assert.Equal(t, 1, c.queries)
+}
+
+type mockNRDBClient struct {
+	results [][]nrdb.NRDBResult
+	queries int
+}
+
+func (c *mockNRDBClient) QueryWithContext(ctx context.Context, accountID int, nrql nrdb.NRQL) (*nrdb.NRDBResultContainer, error) {
+	c.queries++
+
+	if c.queries > len(c.results) {
+		return &nrdb.NRDBResultContainer{}, nil
+	}
+
+	return &nrdb.NRDBResultContainer{Results: c.results[c.queries-1]}, nil
+}
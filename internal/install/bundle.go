@@ -0,0 +1,572 @@
+package install
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+const (
+	bundleManifestFile = "bundle.json"
+	bundleRecipesDir   = "recipes"
+	bundleArtifactsDir = "artifacts"
+)
+
+// downloadURLReferenceRE finds the paths recipes download from under
+// NEW_RELIC_DOWNLOAD_URL.  Paths may contain further template actions.
+var downloadURLReferenceRE = regexp.MustCompile(`\{\{\s*\.NEW_RELIC_DOWNLOAD_URL\s*\}\}((?:\{\{[^}]*\}\}|[^\s"'` + "`" + `<>|;{}()\\])*)`)
+
+// BundleManifest describes the contents of an install bundle.
+type BundleManifest struct {
+	CreatedAt       int64  `json:"createdAt"`
+	OS              string `json:"os"`
+	Platform        string `json:"platform,omitempty"`
+	PlatformVersion string `json:"platformVersion,omitempty"`
+	KernelArch      string `json:"kernelArch,omitempty"`
+	// Recipes are the recipes the bundle was created for.  Their dependencies
+	// are included in the bundle as well.
+	Recipes   []string         `json:"recipes"`
+	Artifacts []BundleArtifact `json:"artifacts"`
+	// Unresolved are download references that could not be bundled, because
+	// they depend on values only known on the target host or refer to a
+	// directory rather than a file.
+	Unresolved []BundleReference `json:"unresolved,omitempty"`
+}
+
+// BundleArtifact is a file downloaded into the bundle, at its path relative
+// to the download URL.
+type BundleArtifact struct {
+	Recipe string `json:"recipe"`
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// BundleReference is a reference to the download URL in a recipe.
+type BundleReference struct {
+	Recipe    string `json:"recipe"`
+	Reference string `json:"reference"`
+}
+
+// BundleOptions are the recipes and target host a bundle is created for.
+type BundleOptions struct {
+	RecipeNames     []string
+	OS              string
+	Platform        string
+	PlatformVersion string
+	KernelArch      string
+}
+
+// BundleCreator creates install bundles: archives holding the recipes for a
+// target host, their dependencies and the artifacts they download, for
+// installing on hosts without access to the internet.
+type BundleCreator struct {
+	// DownloadURL, when set, replaces the URL artifacts are downloaded from.
+	DownloadURL string
+	// MirrorPolicy decides whether NEW_RELIC_DOWNLOAD_URL is trusted.
+	MirrorPolicy *execution.DownloadMirrorPolicy
+	// VerifyChecksums requires each artifact to match the sha256 checksum
+	// published next to it, in a file with .sha256 appended to its name.
+	VerifyChecksums bool
+	recipeFetcher   recipes.RecipeFetcher
+	client          *http.Client
+}
+
+func NewBundleCreator(rf recipes.RecipeFetcher) *BundleCreator {
+	return &BundleCreator{
+		MirrorPolicy:  execution.NewDownloadMirrorPolicy(types.DownloadMirror{}),
+		recipeFetcher: rf,
+		client: &http.Client{
+			Timeout: 10 * time.Minute,
+		},
+	}
+}
+
+// Create writes a bundle for the given recipes and target host to out.
+func (c *BundleCreator) Create(ctx context.Context, opts BundleOptions, out string) (*BundleManifest, error) {
+	m := types.DiscoveryManifest{
+		OS:              opts.OS,
+		Platform:        opts.Platform,
+		PlatformVersion: opts.PlatformVersion,
+		KernelArch:      opts.KernelArch,
+	}
+
+	selected, err := c.resolveRecipes(ctx, m, opts.RecipeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "newrelic-bundle")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := BundleManifest{
+		CreatedAt:       utils.GetTimestamp(),
+		OS:              opts.OS,
+		Platform:        opts.Platform,
+		PlatformVersion: opts.PlatformVersion,
+		KernelArch:      opts.KernelArch,
+		Recipes:         opts.RecipeNames,
+		Artifacts:       []BundleArtifact{},
+	}
+
+	for _, r := range selected {
+		if err = c.addRecipe(ctx, dir, m, r, &manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = writeJSONFile(filepath.Join(dir, bundleManifestFile), manifest); err != nil {
+		return nil, err
+	}
+
+	if err = writeTarball(dir, out); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// resolveRecipes returns the named recipes for the target host along with
+// their dependencies.
+func (c *BundleCreator) resolveRecipes(ctx context.Context, m types.DiscoveryManifest, names []string) ([]types.OpenInstallationRecipe, error) {
+	repo := recipes.NewRecipeRepository(func() ([]types.OpenInstallationRecipe, error) {
+		return c.recipeFetcher.FetchRecipes(ctx)
+	})
+
+	available, err := repo.FindAll(m)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]types.OpenInstallationRecipe{}
+	for _, r := range available {
+		byName[r.Name] = r
+	}
+
+	selected := []types.OpenInstallationRecipe{}
+	for _, n := range names {
+		r, ok := byName[n]
+		if !ok {
+			return nil, fmt.Errorf("no recipe named %s was found for %s", n, describeTarget(m))
+		}
+
+		selected = append(selected, r)
+	}
+
+	return recipes.NewDependencyGraph(available).Resolve(selected)
+}
+
+func (c *BundleCreator) addRecipe(ctx context.Context, dir string, m types.DiscoveryManifest, r types.OpenInstallationRecipe, manifest *BundleManifest) error {
+	b, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	name := r.Name + ".yml"
+	if !validBundlePath(name) {
+		return fmt.Errorf("invalid recipe name %s", r.Name)
+	}
+
+	if err = writeBundleFile(dir, path.Join(bundleRecipesDir, name), bytes.NewReader(b)); err != nil {
+		return err
+	}
+
+	// Input variables are left out, since artifact paths depend on the target
+	// host and not on the answers given during install.
+	target := r
+	target.InputVars = nil
+
+	vars, err := execution.SimulatedRecipeVars(m, target, nil, c.MirrorPolicy)
+	if err != nil {
+		return err
+	}
+
+	downloadURL := vars["NEW_RELIC_DOWNLOAD_URL"]
+	if c.DownloadURL != "" {
+		downloadURL = c.DownloadURL
+	}
+
+	resolved, unresolved := artifactReferences(r, vars)
+
+	for _, ref := range unresolved {
+		manifest.Unresolved = append(manifest.Unresolved, BundleReference{Recipe: r.Name, Reference: ref})
+	}
+
+	for _, p := range resolved {
+		if bundled(manifest, p) {
+			continue
+		}
+
+		a, downloadErr := c.download(ctx, dir, downloadURL, p)
+		if downloadErr != nil {
+			return fmt.Errorf("could not download %s for %s: %s", p, r.Name, downloadErr)
+		}
+
+		a.Recipe = r.Name
+		manifest.Artifacts = append(manifest.Artifacts, *a)
+	}
+
+	return nil
+}
+
+func (c *BundleCreator) download(ctx context.Context, dir string, downloadURL string, p string) (*BundleArtifact, error) {
+	log.WithFields(log.Fields{
+		"path": p,
+	}).Debug("downloading artifact")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL+p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	h := sha256.New()
+	if err = writeBundleFile(dir, path.Join(bundleArtifactsDir, p), io.TeeReader(resp.Body, h)); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(filepath.Join(dir, bundleArtifactsDir, filepath.FromSlash(p)))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	if c.VerifyChecksums {
+		if err = c.verifyChecksum(ctx, downloadURL+p+".sha256", sum); err != nil {
+			return nil, err
+		}
+	}
+
+	return &BundleArtifact{
+		Path:   p,
+		SHA256: sum,
+		Size:   info.Size(),
+	}, nil
+}
+
+// verifyChecksum compares a sum to the published checksum, in the format
+// written by sha256sum.
+func (c *BundleCreator) verifyChecksum(ctx context.Context, checksumURL string, sum string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checksumURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not fetch checksum %s: %s", checksumURL, resp.Status)
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 || !strings.EqualFold(fields[0], sum) {
+		return fmt.Errorf("checksum does not match %s", checksumURL)
+	}
+
+	return nil
+}
+
+// artifactReferences returns the paths a recipe downloads from under
+// NEW_RELIC_DOWNLOAD_URL, with template actions rendered from vars.  References
+// that cannot be rendered, or that are not a single file, are returned as
+// unresolved.
+func artifactReferences(r types.OpenInstallationRecipe, vars types.RecipeVars) ([]string, []string) {
+	resolved := []string{}
+	unresolved := []string{}
+	seen := map[string]bool{}
+
+	for _, match := range downloadURLReferenceRE.FindAllStringSubmatch(r.Install, -1) {
+		p, err := renderReference(match[1], vars)
+		if err != nil || p == "" || strings.HasSuffix(p, "/") || !validBundlePath(p) {
+			p = match[0]
+			if !seen[p] {
+				unresolved = append(unresolved, p)
+			}
+		} else if !seen[p] {
+			resolved = append(resolved, p)
+		}
+
+		seen[p] = true
+	}
+
+	return resolved, unresolved
+}
+
+func renderReference(ref string, vars types.RecipeVars) (string, error) {
+	if !strings.Contains(ref, "{{") {
+		return ref, nil
+	}
+
+	tmpl, err := template.New("reference").Option("missingkey=error").Parse(ref)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err = tmpl.Execute(&b, map[string]string(vars)); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func validBundlePath(p string) bool {
+	clean := path.Clean(p)
+	return clean == p && !path.IsAbs(clean) && clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
+func bundled(manifest *BundleManifest, p string) bool {
+	for _, a := range manifest.Artifacts {
+		if a.Path == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+func describeTarget(m types.DiscoveryManifest) string {
+	parts := []string{}
+	for _, p := range []string{m.OS, m.Platform, m.PlatformVersion, m.KernelArch} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// Bundle is an install bundle extracted to a temporary directory.
+type Bundle struct {
+	Dir      string
+	Manifest BundleManifest
+	server   *http.Server
+}
+
+// OpenBundle extracts the bundle at the given path and verifies the
+// checksums of its artifacts.
+func OpenBundle(bundlePath string) (*Bundle, error) {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dir, err := ioutil.TempDir("", "newrelic-bundle")
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{Dir: dir}
+
+	if err = b.open(f); err != nil {
+		b.Close()
+		return nil, fmt.Errorf("could not open bundle %s: %s", bundlePath, err)
+	}
+
+	return b, nil
+}
+
+func (b *Bundle) open(r io.Reader) error {
+	if err := recipes.ExtractTarball(r, b.Dir); err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(b.Dir, bundleManifestFile))
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(data, &b.Manifest); err != nil {
+		return err
+	}
+
+	for _, a := range b.Manifest.Artifacts {
+		if err = b.verifyArtifact(a); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bundle) verifyArtifact(a BundleArtifact) error {
+	if !validBundlePath(a.Path) {
+		return fmt.Errorf("artifact %s is outside the bundle", a.Path)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(b.Dir, bundleArtifactsDir, filepath.FromSlash(a.Path)))
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != a.SHA256 {
+		return fmt.Errorf("artifact %s does not match its checksum", a.Path)
+	}
+
+	return nil
+}
+
+// RecipesDir returns the directory holding the bundle's recipes.
+func (b *Bundle) RecipesDir() string {
+	return filepath.Join(b.Dir, bundleRecipesDir)
+}
+
+// Serve starts a file server for the bundle's artifacts on the loopback
+// interface, and returns the download URL for recipes to use.
+func (b *Bundle) Serve() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	b.server = &http.Server{
+		Handler: http.FileServer(http.Dir(filepath.Join(b.Dir, bundleArtifactsDir))),
+	}
+
+	go func() {
+		if serveErr := b.server.Serve(listener); serveErr != nil && serveErr != http.ErrServerClosed {
+			log.Error(serveErr)
+		}
+	}()
+
+	return fmt.Sprintf("http://%s/", listener.Addr().String()), nil
+}
+
+// Close stops the file server and removes the extracted bundle.
+func (b *Bundle) Close() {
+	if b.server != nil {
+		utils.LogIfError(b.server.Close())
+		b.server = nil
+	}
+
+	os.RemoveAll(b.Dir)
+}
+
+func writeBundleFile(dir string, name string, r io.Reader) error {
+	p := filepath.Join(dir, filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func writeJSONFile(p string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(p, b, 0640)
+}
+
+// writeTarball writes the files under dir to a gzipped tarball at out.
+func writeTarball(dir string, out string) error {
+	f, err := os.OpenFile(out, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil || !info.Mode().IsRegular() {
+			return walkErr
+		}
+
+		return addTarballFile(tw, dir, p, info)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+func addTarballFile(tw *tar.Writer, dir string, p string, info os.FileInfo) error {
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return err
+	}
+
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(rel)
+
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
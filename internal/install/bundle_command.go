@@ -0,0 +1,106 @@
+package install
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+)
+
+var (
+	bundleRecipeNames     []string
+	bundleOS              string
+	bundlePlatform        string
+	bundlePlatformVersion string
+	bundleKernelArch      string
+	bundleOutput          string
+)
+
+var cmdBundle = &cobra.Command{
+	Use:   "bundle",
+	Short: "Manage install bundles for hosts without internet access.",
+	Long: `Manage install bundles for hosts without internet access
+
+An install bundle is an archive of recipes, their dependencies and the
+artifacts they download, created on a host with internet access.  Install
+from a bundle with the --bundle flag of the install command.
+`,
+	Example: "newrelic install bundle create --recipe infrastructure-agent-installer --os linux --platform ubuntu",
+}
+
+var cmdBundleCreate = &cobra.Command{
+	Use:   "create",
+	Short: "Create an install bundle.",
+	Long: `Create an install bundle
+
+The create command finds the named recipes for the given operating system and
+platform, along with the recipes they depend on, and downloads the files they
+reference under NEW_RELIC_DOWNLOAD_URL into a single archive.  References that
+depend on values only known on the target host, or that refer to a directory
+such as a package repository, cannot be bundled and are listed in the
+bundle's manifest.
+
+When the bundleChecksums configuration value is true, each downloaded file
+must match the sha256 checksum published alongside it, in a file of the same
+name with a .sha256 suffix.
+`,
+	Example: "newrelic install bundle create --recipe infrastructure-agent-installer --os linux --platform ubuntu --output bundle.tar.gz",
+	Run: func(cmd *cobra.Command, args []string) {
+		ic := types.InstallerContext{
+			LocalRecipes: localRecipes,
+		}
+
+		var verifyChecksums bool
+		config.WithConfig(func(cfg *config.Config) {
+			withConfig(&ic, cfg)
+			verifyChecksums = cfg.BundleChecksums.Bool()
+		})
+
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			c := NewBundleCreator(newRecipeFetcher(ic, nrClient))
+			c.MirrorPolicy = execution.NewDownloadMirrorPolicy(ic.DownloadMirror)
+			c.VerifyChecksums = verifyChecksums
+
+			opts := BundleOptions{
+				RecipeNames:     bundleRecipeNames,
+				OS:              bundleOS,
+				Platform:        bundlePlatform,
+				PlatformVersion: bundlePlatformVersion,
+				KernelArch:      bundleKernelArch,
+			}
+
+			m, err := c.Create(utils.SignalCtx, opts, bundleOutput)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			for _, u := range m.Unresolved {
+				log.Warnf("Could not bundle %s for %s, the target host will need access to it", u.Reference, u.Recipe)
+			}
+
+			fmt.Printf("  Bundle written to %s with %d artifacts.\n", bundleOutput, len(m.Artifacts))
+		})
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdBundle)
+	cmdBundle.AddCommand(cmdBundleCreate)
+
+	cmdBundleCreate.Flags().StringSliceVarP(&bundleRecipeNames, "recipe", "n", []string{}, "the name of a recipe to bundle")
+	cmdBundleCreate.Flags().StringVar(&bundleOS, "os", "", "the operating system of the target host")
+	cmdBundleCreate.Flags().StringVar(&bundlePlatform, "platform", "", "the platform of the target host, such as ubuntu or centos")
+	cmdBundleCreate.Flags().StringVar(&bundlePlatformVersion, "platformVersion", "", "the platform version of the target host")
+	cmdBundleCreate.Flags().StringVar(&bundleKernelArch, "kernelArch", "", "the kernel architecture of the target host")
+	cmdBundleCreate.Flags().StringVarP(&bundleOutput, "output", "o", "newrelic-install-bundle.tar.gz", "the path to write the bundle to")
+	cmdBundleCreate.Flags().StringVar(&localRecipes, "localRecipes", "", "a path to local recipes to bundle instead of fetching them")
+	utils.LogIfError(cmdBundleCreate.MarkFlagRequired("recipe"))
+	utils.LogIfError(cmdBundleCreate.MarkFlagRequired("os"))
+}
@@ -0,0 +1,212 @@
+// +build unit
+
+package install
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+var bundleTestRecipes = map[string]string{
+	"infra.yml": `name: infrastructure-agent-installer
+installTargets:
+  - type: host
+    os: linux
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - curl -s {{.NEW_RELIC_DOWNLOAD_URL}}infrastructure_agent/linux/newrelic-infra.tar.gz -o /tmp/infra.tar.gz
+        - curl -s "{{.NEW_RELIC_DOWNLOAD_URL}}infrastructure_agent/{{.OS}}/VERSION"
+        - echo "deb {{.NEW_RELIC_DOWNLOAD_URL}}infrastructure_agent/linux/apt/ focal main"
+`,
+	"mysql.yml": `name: mysql-open-source-integration
+dependencies:
+  - infrastructure-agent-installer
+installTargets:
+  - type: host
+    os: linux
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - curl -s {{.NEW_RELIC_DOWNLOAD_URL}}infrastructure_agent/binaries/linux/{{.MYSQL_ARCH}}/nri-mysql.tar.gz
+`,
+	"windows.yml": `name: infrastructure-agent-windows
+installTargets:
+  - type: host
+    os: windows
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo windows
+`,
+}
+
+func TestArtifactReferences(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test",
+		Install: `
+cmds:
+  - curl {{.NEW_RELIC_DOWNLOAD_URL}}a/{{.OS}}/b.tar.gz
+  - curl '{{ .NEW_RELIC_DOWNLOAD_URL }}c.deb'; echo done
+  - curl {{.NEW_RELIC_DOWNLOAD_URL}}a/{{.UNKNOWN}}/b.tar.gz
+  - curl {{.NEW_RELIC_DOWNLOAD_URL}}../etc/passwd
+  - echo {{.NEW_RELIC_DOWNLOAD_URL}}apt/
+  - curl {{.NEW_RELIC_DOWNLOAD_URL}}c.deb
+`,
+	}
+
+	resolved, unresolved := artifactReferences(r, types.RecipeVars{"OS": "linux"})
+	require.Equal(t, []string{"a/linux/b.tar.gz", "c.deb"}, resolved)
+	require.Len(t, unresolved, 3)
+}
+
+func TestBundle_CreateAndOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic-bundle-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	recipesDir := filepath.Join(dir, "recipes")
+	require.NoError(t, os.MkdirAll(recipesDir, 0750))
+	for name, content := range bundleTestRecipes {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(recipesDir, name), []byte(content), 0600))
+	}
+
+	downloads := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("artifact " + r.URL.Path))
+	}))
+	defer downloads.Close()
+
+	c := NewBundleCreator(&recipes.LocalRecipeFetcher{Path: recipesDir})
+	c.DownloadURL = downloads.URL + "/"
+
+	out := filepath.Join(dir, "bundle.tar.gz")
+	opts := BundleOptions{
+		RecipeNames: []string{"mysql-open-source-integration"},
+		OS:          "linux",
+		Platform:    "ubuntu",
+	}
+
+	m, err := c.Create(context.Background(), opts, out)
+	require.NoError(t, err)
+	require.Len(t, m.Artifacts, 2)
+	require.Equal(t, "infrastructure_agent/linux/newrelic-infra.tar.gz", m.Artifacts[0].Path)
+	require.Equal(t, "infrastructure_agent/linux/VERSION", m.Artifacts[1].Path)
+	require.Len(t, m.Unresolved, 2)
+
+	b, err := OpenBundle(out)
+	require.NoError(t, err)
+	defer b.Close()
+
+	require.Equal(t, []string{"mysql-open-source-integration"}, b.Manifest.Recipes)
+
+	bundled, err := (&recipes.LocalRecipeFetcher{Path: b.RecipesDir()}).FetchRecipes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, bundled, 2)
+
+	downloadURL, err := b.Serve()
+	require.NoError(t, err)
+
+	resp, err := http.Get(downloadURL + "infrastructure_agent/linux/VERSION")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "artifact /infrastructure_agent/linux/VERSION", string(body))
+}
+
+func TestBundle_CreateUnknownRecipe(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic-bundle-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, content := range bundleTestRecipes {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	c := NewBundleCreator(&recipes.LocalRecipeFetcher{Path: dir})
+	opts := BundleOptions{
+		RecipeNames: []string{"infrastructure-agent-windows"},
+		OS:          "linux",
+	}
+
+	_, err = c.Create(context.Background(), opts, filepath.Join(dir, "bundle.tar.gz"))
+	require.Error(t, err)
+}
+
+func TestBundle_CreateRejectsRecipeNamesOutsideBundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic-bundle-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	escaped := strings.Replace(bundleTestRecipes["windows.yml"], "name: infrastructure-agent-windows", "name: ../../escaped", 1)
+	escaped = strings.Replace(escaped, "os: windows", "os: linux", 1)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "escaped.yml"), []byte(escaped), 0600))
+
+	c := NewBundleCreator(&recipes.LocalRecipeFetcher{Path: dir})
+	opts := BundleOptions{
+		RecipeNames: []string{"../../escaped"},
+		OS:          "linux",
+	}
+
+	_, err = c.Create(context.Background(), opts, filepath.Join(dir, "bundle.tar.gz"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid recipe name")
+}
+
+func TestBundle_VerifyChecksums(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic-bundle-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "infra.yml"), []byte(bundleTestRecipes["infra.yml"]), 0600))
+
+	checksum := ""
+	downloads := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			_, _ = w.Write([]byte(checksum))
+			return
+		}
+
+		_, _ = w.Write([]byte("artifact"))
+	}))
+	defer downloads.Close()
+
+	c := NewBundleCreator(&recipes.LocalRecipeFetcher{Path: dir})
+	c.DownloadURL = downloads.URL + "/"
+	c.VerifyChecksums = true
+
+	opts := BundleOptions{
+		RecipeNames: []string{"infrastructure-agent-installer"},
+		OS:          "linux",
+	}
+	out := filepath.Join(dir, "bundle.tar.gz")
+
+	checksum = "0000000000000000000000000000000000000000000000000000000000000000  artifact"
+	_, err = c.Create(context.Background(), opts, out)
+	require.Error(t, err)
+
+	sum := sha256.Sum256([]byte("artifact"))
+	checksum = hex.EncodeToString(sum[:]) + "  artifact"
+	_, err = c.Create(context.Background(), opts, out)
+	require.NoError(t, err)
+}
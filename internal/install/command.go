@@ -2,6 +2,7 @@ package install
 
 import (
 	"errors"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -9,6 +10,7 @@ import (
 	"github.com/newrelic/newrelic-cli/internal/client"
 	"github.com/newrelic/newrelic-cli/internal/config"
 	"github.com/newrelic/newrelic-cli/internal/credentials"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 	"github.com/newrelic/newrelic-client-go/newrelic"
 )
@@ -23,6 +25,16 @@ var (
 	skipApm            bool
 	skipInfra          bool
 	testMode           bool
+	dryRun             bool
+	answersFile        string
+	explainDeps        bool
+	explainMatch       bool
+	concurrency        int
+	uninstallOnFailure bool
+	reportFile         string
+	statusSinks        []string
+	bundlePath         string
+	manifestPath       string
 	debug              bool
 	trace              bool
 )
@@ -41,6 +53,49 @@ var Command = &cobra.Command{
 			SkipLoggingInstall: skipLoggingInstall,
 			SkipApm:            skipApm,
 			SkipInfraInstall:   skipInfra,
+			DryRun:             dryRun,
+			ExplainDeps:        explainDeps,
+			ExplainMatch:       explainMatch,
+			Concurrency:        concurrency,
+			UninstallOnFailure: uninstallOnFailure,
+			ReportFile:         reportFile,
+			StatusSinks:        statusSinks,
+			DiscoveryManifest:  manifestPath,
+		}
+
+		if answersFile != "" {
+			answers, err := types.LoadAnswers(answersFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			// Questions that are not answered fall back to their defaults rather
+			// than prompting, since there may be no terminal to prompt on.
+			ic.Answers = answers
+			ic.AssumeYes = true
+		}
+
+		config.WithConfig(func(cfg *config.Config) {
+			withConfig(&ic, cfg)
+		})
+
+		var bundle *Bundle
+		if bundlePath != "" {
+			var err error
+			if bundle, err = openBundleForInstall(&ic, bundlePath); err != nil {
+				log.Fatal(err)
+			}
+			defer bundle.Close()
+
+			// log.Fatal exits without running deferred calls, so the bundle's
+			// temporary directory is also removed on the way out.
+			log.RegisterExitHandler(bundle.Close)
+		}
+
+		for _, spec := range statusSinks {
+			if _, err := execution.NewStatusSink(spec); err != nil {
+				log.Fatal(err)
+			}
 		}
 
 		config.InitFileLogger()
@@ -73,6 +128,52 @@ var Command = &cobra.Command{
 	},
 }
 
+// withConfig sets the parts of the installer context that come from the CLI
+// configuration rather than from flags.
+func withConfig(ic *types.InstallerContext, cfg *config.Config) {
+	ic.RecipeSource = types.RecipeSource{
+		URL:       cfg.RecipeSource,
+		Ref:       cfg.RecipeSourceRef,
+		Checksums: cfg.RecipeSourceChecksums,
+		PublicKey: cfg.RecipeSourcePublicKey,
+	}
+
+	ic.DownloadMirror = types.DownloadMirror{
+		PublicKey: cfg.DownloadMirrorPublicKey,
+	}
+
+	for _, h := range strings.Split(cfg.DownloadMirrorHosts, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			ic.DownloadMirror.Hosts = append(ic.DownloadMirror.Hosts, h)
+		}
+	}
+}
+
+// openBundleForInstall extracts the bundle and serves its artifacts, and
+// points the install at the bundle's recipes and file server.  Unless recipes
+// are named, the recipes the bundle was created for are installed.
+func openBundleForInstall(ic *types.InstallerContext, path string) (*Bundle, error) {
+	b, err := OpenBundle(path)
+	if err != nil {
+		return nil, err
+	}
+
+	downloadURL, err := b.Serve()
+	if err != nil {
+		b.Close()
+		return nil, err
+	}
+
+	ic.LocalRecipes = b.RecipesDir()
+	ic.DownloadURL = downloadURL
+
+	if !ic.RecipesProvided() {
+		ic.RecipeNames = b.Manifest.Recipes
+	}
+
+	return b, nil
+}
+
 func assertProfileIsValid(profile *credentials.Profile) error {
 	if profile == nil {
 		return errors.New("default profile has not been set")
@@ -88,8 +189,18 @@ func init() {
 	Command.Flags().BoolVarP(&skipApm, "skipApm", "a", false, "skips installation for APM")
 	Command.Flags().BoolVarP(&skipInfra, "skipInfra", "i", false, "skips installation for infrastructure agent (only for targeted install)")
 	Command.Flags().BoolVarP(&testMode, "testMode", "t", false, "fakes operations for UX testing")
+	Command.Flags().BoolVar(&dryRun, "dryRun", false, "print the install plan without executing any recipes")
+	Command.Flags().BoolVar(&explainDeps, "explainDeps", false, "print the recipe dependency graph and install order without installing")
+	Command.Flags().BoolVar(&explainMatch, "explainMatch", false, "print which processes, packages and ports match each recipe for this host without installing")
+	Command.Flags().IntVar(&concurrency, "concurrency", 1, "the maximum number of recipes to install at the same time, for recipes that do not depend on each other")
+	Command.Flags().BoolVar(&uninstallOnFailure, "uninstallOnFailure", false, "run a recipe's uninstall task when its installation cannot be validated")
+	Command.Flags().StringVar(&reportFile, "reportFile", "", "the path to write a report of the install outcome to, as JUnit XML if the path ends in .xml and JSON otherwise")
+	Command.Flags().StringSliceVar(&statusSinks, "statusSink", []string{}, "a file to append install status events to as JSON lines, or an http(s) URL to post them to")
+	Command.Flags().StringVar(&answersFile, "answers", "", "the path to a YAML file answering the questions asked during install, for non-interactive installs")
 	Command.Flags().BoolVar(&debug, "debug", false, "debug level logging")
 	Command.Flags().BoolVar(&trace, "trace", false, "trace level logging")
 	Command.Flags().BoolVarP(&assumeYes, "assumeYes", "y", false, "use \"yes\" for all questions during install")
+	Command.Flags().StringVar(&manifestPath, "manifest", "", "the path to a discovery manifest saved with the discover command, to use instead of discovering this host")
+	Command.Flags().StringVar(&bundlePath, "bundle", "", "the path to an install bundle to install from, for hosts without internet access")
 	Command.Flags().StringVarP(&localRecipes, "localRecipes", "", "", "a path to local recipes to load instead of service other fetching")
 }
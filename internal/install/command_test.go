@@ -16,3 +16,38 @@ func TestInstallCommand(t *testing.T) {
 	testcobra.CheckCobraMetadata(t, Command)
 	testcobra.CheckCobraRequiredFlags(t, Command, []string{})
 }
+
+func TestStatusCommand(t *testing.T) {
+	assert.Equal(t, "status", cmdStatus.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdStatus)
+	testcobra.CheckCobraRequiredFlags(t, cmdStatus, []string{})
+}
+
+func TestUninstallCommand(t *testing.T) {
+	assert.Equal(t, "uninstall", UninstallCommand.Name())
+
+	testcobra.CheckCobraMetadata(t, UninstallCommand)
+	testcobra.CheckCobraRequiredFlags(t, UninstallCommand, []string{"recipe"})
+}
+
+func TestBundleCommand(t *testing.T) {
+	assert.Equal(t, "bundle", cmdBundle.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdBundle)
+	testcobra.CheckCobraRequiredFlags(t, cmdBundle, []string{})
+}
+
+func TestBundleCreateCommand(t *testing.T) {
+	assert.Equal(t, "create", cmdBundleCreate.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdBundleCreate)
+	testcobra.CheckCobraRequiredFlags(t, cmdBundleCreate, []string{"recipe", "os"})
+}
+
+func TestDiscoverCommand(t *testing.T) {
+	assert.Equal(t, "discover", cmdDiscover.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdDiscover)
+	testcobra.CheckCobraRequiredFlags(t, cmdDiscover, []string{})
+}
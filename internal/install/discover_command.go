@@ -0,0 +1,70 @@
+package install
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/discovery"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var discoverOutput string
+
+var cmdDiscover = &cobra.Command{
+	Use:   "discover",
+	Short: "Save what the installer discovers about this host.",
+	Long: `Save what the installer discovers about this host
+
+The discover command writes the host information, running processes, installed
+packages and listening ports the installer uses to choose recipes to a JSON
+manifest.  Pass the manifest to the --manifest flag of the install command to
+choose recipes as if on this host.  The manifest includes the command lines of
+running processes, so review it before sharing it.
+`,
+	Example: "newrelic install discover --output manifest.json",
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := discovery.NewPSUtilDiscoverer().Discover(utils.SignalCtx)
+		if err != nil {
+			log.Fatalf("there was an error discovering system info: %s", err)
+		}
+
+		if err = writeDiscoveryManifest(m, discoverOutput, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+
+		if discoverOutput != "" {
+			fmt.Printf("  Discovery manifest written to %s.\n", discoverOutput)
+		}
+	},
+}
+
+// writeDiscoveryManifest writes the manifest to the named file, or to stdout
+// when no file is named.
+func writeDiscoveryManifest(m *types.DiscoveryManifest, path string, stdout io.Writer) error {
+	if path == "" {
+		return types.WriteDiscoveryManifest(stdout, m)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err = types.WriteDiscoveryManifest(f, m); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func init() {
+	Command.AddCommand(cmdDiscover)
+
+	cmdDiscover.Flags().StringVarP(&discoverOutput, "output", "o", "", "the path to write the manifest to, instead of stdout")
+}
@@ -0,0 +1,73 @@
+package discovery
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// Cloud providers reported in the discovery manifest, for use in recipe
+// install targets.
+const (
+	CloudProviderAWS          = "aws"
+	CloudProviderAzure        = "azure"
+	CloudProviderGCP          = "gcp"
+	CloudProviderDigitalOcean = "digitalocean"
+	CloudProviderAlibaba      = "alibaba"
+	CloudProviderOracle       = "oracle"
+)
+
+// azureChassisAssetTag is the asset tag set on every Azure virtual machine.
+const azureChassisAssetTag = "7783-7084-3265-9085-8269-3286-77"
+
+// detectCloud reads the cloud provider and instance metadata from the DMI
+// values exposed in sysfs, so that no metadata service needs to be called.
+// Values that are only readable by root are left empty otherwise.
+func detectCloud(rootDir string) types.CloudMetadata {
+	dmi := func(name string) string {
+		b, err := ioutil.ReadFile(filepath.Join(rootDir, "sys/class/dmi/id", name))
+		if err != nil {
+			return ""
+		}
+
+		return strings.TrimSpace(string(b))
+	}
+
+	vendor := strings.ToLower(dmi("sys_vendor"))
+	product := dmi("product_name")
+
+	switch {
+	case strings.Contains(vendor, "amazon") || strings.HasPrefix(strings.ToLower(dmi("product_version")), "amazon"):
+		c := types.CloudMetadata{Provider: CloudProviderAWS}
+		if tag := dmi("board_asset_tag"); strings.HasPrefix(tag, "i-") {
+			c.InstanceID = tag
+		}
+		if !strings.EqualFold(product, "HVM domU") {
+			c.InstanceType = product
+		}
+		return c
+	case strings.Contains(vendor, "google"):
+		return types.CloudMetadata{Provider: CloudProviderGCP}
+	case strings.Contains(vendor, "microsoft") && dmi("chassis_asset_tag") == azureChassisAssetTag:
+		return types.CloudMetadata{
+			Provider:   CloudProviderAzure,
+			InstanceID: strings.ToLower(dmi("product_uuid")),
+		}
+	case strings.Contains(vendor, "digitalocean"):
+		return types.CloudMetadata{
+			Provider:   CloudProviderDigitalOcean,
+			InstanceID: dmi("product_serial"),
+		}
+	case strings.Contains(vendor, "alibaba"):
+		return types.CloudMetadata{
+			Provider:   CloudProviderAlibaba,
+			InstanceID: dmi("product_serial"),
+		}
+	case strings.EqualFold(dmi("chassis_asset_tag"), "OracleCloud.com"):
+		return types.CloudMetadata{Provider: CloudProviderOracle}
+	}
+
+	return types.CloudMetadata{}
+}
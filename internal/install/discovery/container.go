@@ -0,0 +1,69 @@
+package discovery
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cgroupRuntimes maps a marker found in a process' cgroups to the container
+// runtime that created it.
+var cgroupRuntimes = []struct {
+	marker  string
+	runtime string
+}{
+	{"docker", "docker"},
+	{"containerd", "containerd"},
+	{"crio", "cri-o"},
+	{"libpod", "podman"},
+	{"lxc", "lxc"},
+}
+
+// detectContainer returns the container runtime the CLI runs under, if any,
+// and whether it runs in a Kubernetes pod.
+func detectContainer(rootDir string, getenv func(string) string) (string, bool) {
+	kubernetes := getenv("KUBERNETES_SERVICE_HOST") != "" ||
+		fileExists(filepath.Join(rootDir, "var/run/secrets/kubernetes.io/serviceaccount"))
+
+	runtime := ""
+	switch {
+	case fileExists(filepath.Join(rootDir, ".dockerenv")):
+		runtime = "docker"
+	case fileExists(filepath.Join(rootDir, "run/.containerenv")):
+		runtime = "podman"
+	default:
+		runtime = cgroupRuntime(rootDir)
+	}
+
+	if runtime == "" && kubernetes {
+		runtime = "containerd"
+	}
+
+	return runtime, kubernetes
+}
+
+func cgroupRuntime(rootDir string) string {
+	b, err := ioutil.ReadFile(filepath.Join(rootDir, "proc/1/cgroup"))
+	if err != nil {
+		return ""
+	}
+
+	cgroups := string(b)
+	for _, r := range cgroupRuntimes {
+		if strings.Contains(cgroups, r.marker) {
+			return r.runtime
+		}
+	}
+
+	if strings.Contains(cgroups, "kubepods") {
+		return "containerd"
+	}
+
+	return ""
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
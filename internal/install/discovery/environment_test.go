@@ -0,0 +1,144 @@
+// +build unit
+
+package discovery
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shirou/gopsutil/net"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func givenRootDir(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "newrelic-discovery-test")
+	require.NoError(t, err)
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+
+	return dir
+}
+
+func TestDetectContainer(t *testing.T) {
+	noEnv := func(string) string { return "" }
+
+	dir := givenRootDir(t, map[string]string{})
+	defer os.RemoveAll(dir)
+	runtime, kubernetes := detectContainer(dir, noEnv)
+	require.Empty(t, runtime)
+	require.False(t, kubernetes)
+
+	dir = givenRootDir(t, map[string]string{".dockerenv": ""})
+	defer os.RemoveAll(dir)
+	runtime, kubernetes = detectContainer(dir, noEnv)
+	require.Equal(t, "docker", runtime)
+	require.False(t, kubernetes)
+
+	dir = givenRootDir(t, map[string]string{
+		"proc/1/cgroup": "0::/kubepods/besteffort/pod1234/cri-containerd-abcd.scope\n",
+	})
+	defer os.RemoveAll(dir)
+	runtime, kubernetes = detectContainer(dir, func(k string) string {
+		if k == "KUBERNETES_SERVICE_HOST" {
+			return "10.0.0.1"
+		}
+		return ""
+	})
+	require.Equal(t, "containerd", runtime)
+	require.True(t, kubernetes)
+}
+
+func TestDetectCloud(t *testing.T) {
+	tests := []struct {
+		files    map[string]string
+		expected types.CloudMetadata
+	}{
+		{
+			files: map[string]string{
+				"sys/class/dmi/id/sys_vendor":      "Amazon EC2\n",
+				"sys/class/dmi/id/product_name":    "m5.large\n",
+				"sys/class/dmi/id/board_asset_tag": "i-0123456789abcdef0\n",
+			},
+			expected: types.CloudMetadata{Provider: CloudProviderAWS, InstanceID: "i-0123456789abcdef0", InstanceType: "m5.large"},
+		},
+		{
+			files: map[string]string{
+				"sys/class/dmi/id/sys_vendor":   "Google\n",
+				"sys/class/dmi/id/product_name": "Google Compute Engine\n",
+			},
+			expected: types.CloudMetadata{Provider: CloudProviderGCP},
+		},
+		{
+			files: map[string]string{
+				"sys/class/dmi/id/sys_vendor":        "Microsoft Corporation\n",
+				"sys/class/dmi/id/chassis_asset_tag": azureChassisAssetTag + "\n",
+			},
+			expected: types.CloudMetadata{Provider: CloudProviderAzure},
+		},
+		{
+			files: map[string]string{
+				"sys/class/dmi/id/sys_vendor": "Microsoft Corporation\n",
+			},
+			expected: types.CloudMetadata{},
+		},
+		{
+			files:    map[string]string{},
+			expected: types.CloudMetadata{},
+		},
+	}
+
+	for _, test := range tests {
+		dir := givenRootDir(t, test.files)
+		require.Equal(t, test.expected, detectCloud(dir))
+		os.RemoveAll(dir)
+	}
+}
+
+func TestParseDpkgStatus(t *testing.T) {
+	status := `Package: mysql-server-8.0
+Status: install ok installed
+Version: 8.0.27-0ubuntu0.20.04.1
+Description: MySQL database server binaries
+ and system database setup
+
+Package: removed
+Status: deinstall ok config-files
+Version: 1.0
+
+Package: nginx
+Status: install ok installed
+Version: 1.18.0-0ubuntu1.2`
+
+	packages, err := parseDpkgStatus(strings.NewReader(status))
+	require.NoError(t, err)
+	require.Equal(t, []types.InstalledPackage{
+		{Name: "mysql-server-8.0", Version: "8.0.27-0ubuntu0.20.04.1", Manager: "dpkg"},
+		{Name: "nginx", Version: "1.18.0-0ubuntu1.2", Manager: "dpkg"},
+	}, packages)
+}
+
+func TestParseRpmOutput(t *testing.T) {
+	packages := parseRpmOutput("postgresql-server\t13.4-1.el8\ngpg-pubkey\t1-1\n\n")
+	require.Equal(t, []types.InstalledPackage{
+		{Name: "postgresql-server", Version: "13.4-1.el8", Manager: "rpm"},
+	}, packages)
+}
+
+func TestListeningPorts(t *testing.T) {
+	conns := []net.ConnectionStat{
+		{Laddr: net.Addr{IP: "0.0.0.0", Port: 3306}, Status: "LISTEN", Pid: 42},
+		{Laddr: net.Addr{IP: "0.0.0.0", Port: 3306}, Status: "LISTEN", Pid: 42},
+		{Laddr: net.Addr{IP: "10.0.0.2", Port: 51234}, Status: "ESTABLISHED", Pid: 7},
+	}
+
+	require.Equal(t, []types.ListeningPort{{Port: 3306, Address: "0.0.0.0", PID: 42}}, listeningPorts(conns))
+}
@@ -0,0 +1,30 @@
+package discovery
+
+import (
+	"context"
+	"os"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// ManifestFileDiscoverer replays a discovery manifest saved by the install
+// discover command instead of discovering the host it runs on.
+type ManifestFileDiscoverer struct {
+	Path string
+}
+
+func NewManifestFileDiscoverer(path string) *ManifestFileDiscoverer {
+	return &ManifestFileDiscoverer{
+		Path: path,
+	}
+}
+
+func (d *ManifestFileDiscoverer) Discover(context.Context) (*types.DiscoveryManifest, error) {
+	f, err := os.Open(d.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return types.ReadDiscoveryManifest(f)
+}
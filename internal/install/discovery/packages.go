@@ -0,0 +1,111 @@
+package discovery
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// detectPackages returns the packages installed by dpkg and rpm.  rpm's
+// database can only be read by rpm itself, so it is queried only when rpm is
+// on the PATH.
+func detectPackages(ctx context.Context, rootDir string) ([]types.InstalledPackage, error) {
+	packages := []types.InstalledPackage{}
+
+	f, err := os.Open(filepath.Join(rootDir, "var/lib/dpkg/status"))
+	if err == nil {
+		defer f.Close()
+
+		var dpkg []types.InstalledPackage
+		if dpkg, err = parseDpkgStatus(f); err != nil {
+			return nil, fmt.Errorf("could not read dpkg packages: %s", err)
+		}
+
+		packages = append(packages, dpkg...)
+	}
+
+	if _, err = exec.LookPath("rpm"); err == nil {
+		cmd := exec.CommandContext(ctx, "rpm", "--root", rootDir, "-qa", "--qf", `%{NAME}\t%{VERSION}-%{RELEASE}\n`)
+
+		var out []byte
+		if out, err = cmd.Output(); err != nil {
+			return nil, fmt.Errorf("could not list rpm packages: %s", err)
+		}
+
+		packages = append(packages, parseRpmOutput(string(out))...)
+	}
+
+	return packages, nil
+}
+
+// parseDpkgStatus reads the installed packages from dpkg's status file, a
+// list of blank line separated stanzas.
+func parseDpkgStatus(r io.Reader) ([]types.InstalledPackage, error) {
+	packages := []types.InstalledPackage{}
+
+	var name, version, status string
+	flush := func() {
+		if name != "" && strings.HasSuffix(status, " installed") {
+			packages = append(packages, types.InstalledPackage{
+				Name:    name,
+				Version: version,
+				Manager: "dpkg",
+			})
+		}
+
+		name, version, status = "", "", ""
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "Package:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "Package:"))
+		case strings.HasPrefix(line, "Version:"):
+			version = strings.TrimSpace(strings.TrimPrefix(line, "Version:"))
+		case strings.HasPrefix(line, "Status:"):
+			status = strings.TrimSpace(strings.TrimPrefix(line, "Status:"))
+		}
+	}
+
+	flush()
+
+	return packages, scanner.Err()
+}
+
+func parseRpmOutput(out string) []types.InstalledPackage {
+	packages := []types.InstalledPackage{}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), "\t", 2)
+		if fields[0] == "" || fields[0] == "gpg-pubkey" {
+			continue
+		}
+
+		p := types.InstalledPackage{
+			Name:    fields[0],
+			Manager: "rpm",
+		}
+
+		if len(fields) > 1 {
+			p.Version = fields[1]
+		}
+
+		packages = append(packages, p)
+	}
+
+	return packages
+}
@@ -0,0 +1,45 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/shirou/gopsutil/net"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// detectListeningPorts returns the TCP ports processes on the host are
+// listening on.  Owning PIDs are only known for processes the CLI is allowed
+// to inspect.
+func detectListeningPorts(ctx context.Context) ([]types.ListeningPort, error) {
+	conns, err := net.ConnectionsWithContext(ctx, "tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	return listeningPorts(conns), nil
+}
+
+func listeningPorts(conns []net.ConnectionStat) []types.ListeningPort {
+	ports := []types.ListeningPort{}
+	seen := map[types.ListeningPort]bool{}
+
+	for _, c := range conns {
+		if c.Status != "LISTEN" {
+			continue
+		}
+
+		p := types.ListeningPort{
+			Port:    c.Laddr.Port,
+			Address: c.Laddr.IP,
+			PID:     c.Pid,
+		}
+
+		if !seen[p] {
+			seen[p] = true
+			ports = append(ports, p)
+		}
+	}
+
+	return ports
+}
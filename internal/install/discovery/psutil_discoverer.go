@@ -3,6 +3,7 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 
@@ -13,10 +14,15 @@ import (
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 )
 
-type PSUtilDiscoverer struct{}
+type PSUtilDiscoverer struct {
+	// rootDir is where container, cloud and package information is read from.
+	rootDir string
+}
 
 func NewPSUtilDiscoverer() *PSUtilDiscoverer {
-	return &PSUtilDiscoverer{}
+	return &PSUtilDiscoverer{
+		rootDir: "/",
+	}
 }
 
 func (p *PSUtilDiscoverer) Discover(ctx context.Context) (*types.DiscoveryManifest, error) {
@@ -58,9 +64,34 @@ func (p *PSUtilDiscoverer) Discover(ctx context.Context) (*types.DiscoveryManife
 
 	m.DiscoveredProcesses = processes
 
+	p.discoverEnvironment(ctx, &m)
+
 	return &m, nil
 }
 
+// discoverEnvironment adds what is known about the host's container, cloud,
+// packages and listening ports to the manifest.  Recipes do not require this
+// information, so failures to read it are not fatal.
+func (p *PSUtilDiscoverer) discoverEnvironment(ctx context.Context, m *types.DiscoveryManifest) {
+	m.ContainerRuntime, m.Kubernetes = detectContainer(p.rootDir, os.Getenv)
+	m.Cloud = detectCloud(p.rootDir)
+
+	packages, err := detectPackages(ctx, p.rootDir)
+	if err != nil {
+		log.Debugf("cannot retrieve installed packages: %s", err)
+	}
+	m.InstalledPackages = packages
+
+	ports, err := detectListeningPorts(ctx)
+	if err != nil {
+		log.Debugf("cannot retrieve listening ports: %s", err)
+	}
+	m.ListeningPorts = ports
+
+	log.Debugf("discovered container %q, cloud %+v, %d packages and %d listening ports",
+		m.ContainerType(), m.Cloud, len(m.InstalledPackages), len(m.ListeningPorts))
+}
+
 func filterValues(m types.DiscoveryManifest) types.DiscoveryManifest {
 	if !isValidOpenInstallationPlatform(m.Platform) {
 		m.Platform = ""
@@ -1,6 +1,10 @@
 package discovery
 
 import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
 	"github.com/shirou/gopsutil/process"
 )
 
@@ -29,3 +33,36 @@ func (p PSUtilProcess) Cmd() (string, error) {
 func (p PSUtilProcess) PID() int32 {
 	return process.Process(p).Pid
 }
+
+func (p PSUtilProcess) Exe() (string, error) {
+	pp := process.Process(p)
+	return pp.Exe()
+}
+
+func (p PSUtilProcess) Username() (string, error) {
+	pp := process.Process(p)
+	return pp.Username()
+}
+
+func (p PSUtilProcess) PPID() (int32, error) {
+	pp := process.Process(p)
+	return pp.Ppid()
+}
+
+// EnvNames reads the process' environment from procfs, so it is only
+// available on Linux, and for processes the CLI is allowed to inspect.
+func (p PSUtilProcess) EnvNames() ([]string, error) {
+	b, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/environ", p.PID()))
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, e := range strings.Split(string(b), "\x00") {
+		if name := strings.SplitN(e, "=", 2)[0]; name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
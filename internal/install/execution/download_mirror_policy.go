@@ -0,0 +1,157 @@
+package execution
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const (
+	// DefaultDownloadURL is where recipes download artifacts from unless a
+	// trusted mirror is set in NEW_RELIC_DOWNLOAD_URL.
+	DefaultDownloadURL = "https://download.newrelic.com/"
+
+	// DownloadMirrorSignatureFile is the file a mirror serves, relative to its
+	// URL, holding the base64 encoded ed25519 signature of that URL.
+	DownloadMirrorSignatureFile = "newrelic-mirror.sig"
+)
+
+// DownloadMirrorPolicy decides whether NEW_RELIC_DOWNLOAD_URL may be used in
+// place of the default download URL.  New Relic's own hosts are always
+// trusted.  Other mirrors are trusted when their host is listed, or when they
+// serve a signature of their URL made with the configured key.  Mirrors must
+// use https.
+type DownloadMirrorPolicy struct {
+	// Hosts are trusted mirror hosts.  A leading "*." matches any subdomain.
+	Hosts []string
+	// PublicKey is the base64 encoded ed25519 key mirror signatures are made with.
+	PublicKey string
+	client    *http.Client
+	mutex     sync.Mutex
+	decisions map[string]error
+}
+
+// NewDownloadMirrorPolicy returns a policy trusting the configured mirrors.
+func NewDownloadMirrorPolicy(m types.DownloadMirror) *DownloadMirrorPolicy {
+	return &DownloadMirrorPolicy{
+		Hosts:     m.Hosts,
+		PublicKey: m.PublicKey,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		decisions: map[string]error{},
+	}
+}
+
+// DownloadURL returns the mirror URL if it is trusted, and the default
+// download URL otherwise.  Each rejected mirror is logged once.
+func (p *DownloadMirrorPolicy) DownloadURL(mirrorURL string) string {
+	if mirrorURL == "" {
+		return DefaultDownloadURL
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	err, decided := p.decisions[mirrorURL]
+	if !decided {
+		err = p.check(mirrorURL)
+		p.decisions[mirrorURL] = err
+
+		if err != nil {
+			log.Warnf("Ignoring NEW_RELIC_DOWNLOAD_URL %s and downloading from %s instead: %s", mirrorURL, DefaultDownloadURL, err)
+		}
+	}
+
+	if err != nil {
+		return DefaultDownloadURL
+	}
+
+	return mirrorURL
+}
+
+func (p *DownloadMirrorPolicy) check(mirrorURL string) error {
+	u, err := url.Parse(mirrorURL)
+	if err != nil {
+		return fmt.Errorf("could not parse the URL: %s", err)
+	}
+
+	if u.Scheme != "https" {
+		return fmt.Errorf("mirrors must use https")
+	}
+
+	if p.hostTrusted(u.Hostname()) {
+		return nil
+	}
+
+	if p.PublicKey == "" {
+		return fmt.Errorf("%s is not a trusted mirror, add it to the downloadMirrorHosts config value to trust it", u.Hostname())
+	}
+
+	return p.verifySignature(mirrorURL)
+}
+
+func (p *DownloadMirrorPolicy) hostTrusted(host string) bool {
+	for _, regexString := range downloadURLAccessListRegex {
+		if regexp.MustCompile(regexString).MatchString(host) {
+			return true
+		}
+	}
+
+	for _, h := range p.Hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+
+		if strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:]) {
+			return true
+		}
+
+		if h != "" && strings.EqualFold(h, host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifySignature trusts a mirror that serves a signature of its own URL.
+func (p *DownloadMirrorPolicy) verifySignature(mirrorURL string) error {
+	key, err := base64.StdEncoding.DecodeString(p.PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("downloadMirrorPublicKey must be a base64 encoded ed25519 public key")
+	}
+
+	signatureURL := strings.TrimSuffix(mirrorURL, "/") + "/" + DownloadMirrorSignatureFile
+
+	resp, err := p.client.Get(signatureURL)
+	if err != nil {
+		return fmt.Errorf("could not fetch the mirror signature: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not fetch the mirror signature from %s: %s", signatureURL, resp.Status)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil || !ed25519.Verify(ed25519.PublicKey(key), []byte(mirrorURL), sig) {
+		return fmt.Errorf("the mirror signature is not valid")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// +build unit
+
+package execution
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestDownloadMirrorPolicy_Hosts(t *testing.T) {
+	p := NewDownloadMirrorPolicy(types.DownloadMirror{
+		Hosts: []string{"artifactory.example.com", "*.mirror.example.com"},
+	})
+
+	tests := map[string]string{
+		"":                                         DefaultDownloadURL,
+		"https://download.newrelic.com/":           "https://download.newrelic.com/",
+		"https://artifactory.example.com/nr/":      "https://artifactory.example.com/nr/",
+		"https://ARTIFACTORY.example.com:8443/nr/": "https://ARTIFACTORY.example.com:8443/nr/",
+		"https://eu.mirror.example.com/":           "https://eu.mirror.example.com/",
+		"https://mirror.example.com.evil.com/":     DefaultDownloadURL,
+		"http://artifactory.example.com/nr/":       DefaultDownloadURL,
+		"https://other.example.com/":               DefaultDownloadURL,
+		"://not a url":                             DefaultDownloadURL,
+	}
+
+	for mirrorURL, expected := range tests {
+		require.Equal(t, expected, p.DownloadURL(mirrorURL), mirrorURL)
+	}
+}
+
+func TestDownloadMirrorPolicy_Signature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var signature string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/nr/"+DownloadMirrorSignatureFile {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(signature))
+	}))
+	defer server.Close()
+
+	mirrorURL := server.URL + "/nr/"
+
+	newPolicy := func() *DownloadMirrorPolicy {
+		p := NewDownloadMirrorPolicy(types.DownloadMirror{
+			PublicKey: base64.StdEncoding.EncodeToString(pub),
+		})
+		p.client = server.Client()
+		return p
+	}
+
+	signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(mirrorURL)))
+	require.Equal(t, mirrorURL, newPolicy().DownloadURL(mirrorURL))
+
+	signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte("https://other.example.com/")))
+	require.Equal(t, DefaultDownloadURL, newPolicy().DownloadURL(mirrorURL))
+
+	require.Equal(t, DefaultDownloadURL, newPolicy().DownloadURL(server.URL+"/missing/"))
+}
@@ -0,0 +1,40 @@
+package execution
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileStatusSink appends status events to a file as JSON lines, so that the
+// file can be followed while the install runs.
+type FileStatusSink struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileStatusSink returns a sink that appends to the file at the given path.
+func NewFileStatusSink(path string) *FileStatusSink {
+	return &FileStatusSink{
+		path: path,
+	}
+}
+
+func (s *FileStatusSink) Send(event StatusSinkEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
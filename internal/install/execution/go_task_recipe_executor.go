@@ -56,8 +56,18 @@ func (re *GoTaskRecipeExecutor) Execute(ctx context.Context, r types.OpenInstall
 		return err
 	}
 
-	stdoutCapture := NewLineCaptureBuffer(re.Stdout)
-	stderrCapture := NewLineCaptureBuffer(re.Stderr)
+	stdout, stderr := re.Stdout, re.Stderr
+	if prefix := outputPrefix(ctx); prefix != "" {
+		stdoutPrefixer := NewPrefixWriter(re.Stdout, prefix)
+		stderrPrefixer := NewPrefixWriter(re.Stderr, prefix)
+		defer stdoutPrefixer.Flush()
+		defer stderrPrefixer.Flush()
+
+		stdout, stderr = stdoutPrefixer, stderrPrefixer
+	}
+
+	stdoutCapture := NewLineCaptureBuffer(stdout)
+	stderrCapture := NewLineCaptureBuffer(stderr)
 
 	e := task.Executor{
 		Entrypoint: file.Name(),
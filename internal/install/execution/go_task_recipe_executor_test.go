@@ -35,3 +35,25 @@ tasks:
 	require.NoError(t, err)
 	require.Equal(t, "testValue\n", b.String())
 }
+
+func TestExecute_OutputPrefix(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - |
+        echo line1
+        printf line2
+`,
+	}
+
+	e := NewGoTaskRecipeExecutor()
+	b := bytes.NewBufferString("")
+	e.Stdout = b
+	err := e.Execute(WithOutputPrefix(context.Background(), "[test-recipe] "), r, types.RecipeVars{})
+	require.NoError(t, err)
+	require.Equal(t, "[test-recipe] line1\n[test-recipe] line2\n", b.String())
+}
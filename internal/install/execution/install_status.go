@@ -3,6 +3,7 @@ package execution
 import (
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -46,6 +47,16 @@ type InstallStatus struct {
 	statusSubscriber          []StatusSubscriber
 	successLinkConfig         types.OpenInstallationSuccessLinkConfig
 	PlatformLinkGenerator     LinkGenerator
+	mutex                     *sync.Mutex
+	notifier                  *statusNotifier
+	published                 uint64
+}
+
+// statusNotifier delivers notifications to subscribers one at a time, in the
+// order the changes they describe were applied to the status.
+type statusNotifier struct {
+	cond     *sync.Cond
+	notified uint64
 }
 
 type RecipeStatus struct {
@@ -69,6 +80,7 @@ var RecipeStatusTypes = struct {
 	SKIPPED     RecipeStatusType
 	RECOMMENDED RecipeStatusType
 	UNSUPPORTED RecipeStatusType
+	UNINSTALLED RecipeStatusType
 }{
 	AVAILABLE:   "AVAILABLE",
 	CANCELED:    "CANCELED",
@@ -78,6 +90,7 @@ var RecipeStatusTypes = struct {
 	SKIPPED:     "SKIPPED",
 	RECOMMENDED: "RECOMMENDED",
 	UNSUPPORTED: "UNSUPPORTED",
+	UNINSTALLED: "UNINSTALLED",
 }
 
 type ObservabilityPackStatus struct {
@@ -120,107 +133,109 @@ func NewInstallStatus(reporters []StatusSubscriber, PlatformLinkGenerator LinkGe
 		statusSubscriber:      reporters,
 		PlatformLinkGenerator: PlatformLinkGenerator,
 		HTTPSProxy:            httpproxy.FromEnvironment().HTTPSProxy,
+		mutex:                 &sync.Mutex{},
+		notifier:              &statusNotifier{cond: sync.NewCond(&sync.Mutex{})},
 	}
 
 	return &s
 }
 
 func (s *InstallStatus) DiscoveryComplete(dm types.DiscoveryManifest) {
-	s.withDiscoveryInfo(dm)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.DiscoveryComplete(s, dm); err != nil {
+	s.publish(func() {
+		s.withDiscoveryInfo(dm)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.DiscoveryComplete(status, dm); err != nil {
 			log.Errorf("Could not report discovery info: %s", err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) RecipeAvailable(recipe types.OpenInstallationRecipe) {
-	s.withAvailableRecipe(recipe)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeAvailable(s, recipe); err != nil {
+	s.publish(func() {
+		s.withAvailableRecipe(recipe)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.RecipeAvailable(status, recipe); err != nil {
 			log.Errorf("Could not report recipe execution status: %s", err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) RecipesSelected(recipes []types.OpenInstallationRecipe) {
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipesSelected(s, recipes); err != nil {
+	s.publish(func() {}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.RecipesSelected(status, recipes); err != nil {
 			log.Errorf("Could not report recipe execution status: %s", err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) ObservabilityPackFetchPending(event ObservabilityPackStatusEvent) {
-	s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.FetchPending)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.ObservabilityPackFetchPending(s); err != nil {
+	s.publish(func() {
+		s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.FetchPending)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.ObservabilityPackFetchPending(status); err != nil {
 			log.Errorf("Error writing observabilityPack status for pack %s: %s", event.ObservabilityPack.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) ObservabilityPackFetchSuccess(event ObservabilityPackStatusEvent) {
-	s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.FetchSuccess)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.ObservabilityPackFetchSuccess(s); err != nil {
+	s.publish(func() {
+		s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.FetchSuccess)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.ObservabilityPackFetchSuccess(status); err != nil {
 			log.Errorf("Error writing observabilityPack status for pack %s: %s", event.ObservabilityPack.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) ObservabilityPackFetchFailed(event ObservabilityPackStatusEvent) {
-	s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.FetchFailed)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.ObservabilityPackFetchFailed(s); err != nil {
+	s.publish(func() {
+		s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.FetchFailed)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.ObservabilityPackFetchFailed(status); err != nil {
 			log.Errorf("Error writing observabilityPack status for pack %s: %s", event.ObservabilityPack.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) ObservabilityPackInstallPending(event ObservabilityPackStatusEvent) {
-	s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.InstallPending)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.ObservabilityPackInstallPending(s); err != nil {
+	s.publish(func() {
+		s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.InstallPending)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.ObservabilityPackInstallPending(status); err != nil {
 			log.Errorf("Error writing observabilityPack status for pack %s: %s", event.ObservabilityPack.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) ObservabilityPackInstallSuccess(event ObservabilityPackStatusEvent) {
-	s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.InstallSuccess)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.ObservabilityPackInstallSuccess(s); err != nil {
+	s.publish(func() {
+		s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.InstallSuccess)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.ObservabilityPackInstallSuccess(status); err != nil {
 			log.Errorf("Error writing observabilityPack status for pack %s: %s", event.ObservabilityPack.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) ObservabilityPackInstallFailed(event ObservabilityPackStatusEvent) {
-	s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.InstallFailed)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.ObservabilityPackInstallFailed(s); err != nil {
+	s.publish(func() {
+		s.withObservabilityPackEvent(event, ObservabilityPackStatusTypes.InstallFailed)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.ObservabilityPackInstallFailed(status); err != nil {
 			log.Errorf("Error writing observabilityPack status for pack %s: %s", event.ObservabilityPack.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) RecipeInstalled(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.INSTALLED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeInstalled(s, event); err != nil {
+	s.publish(func() {
+		s.withRecipeEvent(event, RecipeStatusTypes.INSTALLED)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.RecipeInstalled(status, event); err != nil {
 			log.Errorf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
 		}
-	}
+	})
 }
 
 // RecipeRecommended is responsible for setting the nerstorage scopes
@@ -229,75 +244,164 @@ func (s *InstallStatus) RecipeInstalled(event RecipeStatusEvent) {
 // should consider integrating, but not something that the recipe framework
 // will currently assist with.
 func (s *InstallStatus) RecipeRecommended(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.RECOMMENDED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeRecommended(s, event); err != nil {
+	s.publish(func() {
+		s.withRecipeEvent(event, RecipeStatusTypes.RECOMMENDED)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.RecipeRecommended(status, event); err != nil {
 			log.Errorf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) RecipeInstalling(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.INSTALLING)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeInstalling(s, event); err != nil {
+	s.publish(func() {
+		s.withRecipeEvent(event, RecipeStatusTypes.INSTALLING)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.RecipeInstalling(status, event); err != nil {
 			log.Errorf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) RecipeFailed(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.FAILED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeFailed(s, event); err != nil {
+	s.publish(func() {
+		s.withRecipeEvent(event, RecipeStatusTypes.FAILED)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.RecipeFailed(status, event); err != nil {
 			log.Errorf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) RecipeSkipped(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.SKIPPED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeSkipped(s, event); err != nil {
+	s.publish(func() {
+		s.withRecipeEvent(event, RecipeStatusTypes.SKIPPED)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.RecipeSkipped(status, event); err != nil {
 			log.Errorf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) RecipeUnsupported(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.UNSUPPORTED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeUnsupported(s, event); err != nil {
+	s.publish(func() {
+		s.withRecipeEvent(event, RecipeStatusTypes.UNSUPPORTED)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.RecipeUnsupported(status, event); err != nil {
 			log.Errorf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) InstallComplete(err error) {
-	s.completed(err)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.InstallComplete(s); err != nil {
+	s.publish(func() {
+		s.completed(err)
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.InstallComplete(status); err != nil {
 			log.Errorf("Error writing execution status: %s", err)
 		}
-	}
+	})
 }
 
 func (s *InstallStatus) InstallCanceled() {
-	s.canceled()
-
-	for _, r := range s.statusSubscriber {
-		if err := r.InstallCanceled(s); err != nil {
+	s.publish(func() {
+		s.canceled()
+	}, func(r StatusSubscriber, status *InstallStatus) {
+		if err := r.InstallCanceled(status); err != nil {
 			log.Errorf("Error writing execution status: %s", err)
 		}
+	})
+}
+
+// publish applies a change to the status and notifies the subscribers of it.
+// The change is applied under the status lock, but subscribers are called
+// outside it with a snapshot of the result, so a slow subscriber does not hold
+// up recipes installing concurrently.
+func (s *InstallStatus) publish(change func(), notify func(StatusSubscriber, *InstallStatus)) {
+	unlock := s.lock()
+	change()
+	snapshot := s.snapshot()
+	seq := s.published
+	s.published++
+	unlock()
+
+	if n := s.notifier; n != nil {
+		n.cond.L.Lock()
+		defer n.cond.L.Unlock()
+
+		for n.notified != seq {
+			n.cond.Wait()
+		}
+
+		defer func() {
+			n.notified++
+			n.cond.Broadcast()
+		}()
+	}
+
+	for _, r := range s.statusSubscriber {
+		notify(r, snapshot)
 	}
 }
 
+// snapshot returns a copy of the status that later changes do not affect.
+func (s *InstallStatus) snapshot() *InstallStatus {
+	c := *s
+	c.mutex = nil
+	c.notifier = nil
+	c.EntityGUIDs = append([]string(nil), s.EntityGUIDs...)
+	c.Statuses = copyRecipeStatuses(s.Statuses)
+	c.Skipped = copyRecipeStatuses(s.Skipped)
+	c.Canceled = copyRecipeStatuses(s.Canceled)
+	c.Failed = copyRecipeStatuses(s.Failed)
+	c.Installed = copyRecipeStatuses(s.Installed)
+	c.ObservabilityPackStatuses = copyObservabilityPackStatuses(s.ObservabilityPackStatuses)
+	c.CanceledPacks = copyObservabilityPackStatuses(s.CanceledPacks)
+	c.FailedPacks = copyObservabilityPackStatuses(s.FailedPacks)
+	c.InstalledPacks = copyObservabilityPackStatuses(s.InstalledPacks)
+
+	return &c
+}
+
+func copyRecipeStatuses(statuses []*RecipeStatus) []*RecipeStatus {
+	if statuses == nil {
+		return nil
+	}
+
+	c := make([]*RecipeStatus, len(statuses))
+	for i, st := range statuses {
+		copied := *st
+		c[i] = &copied
+	}
+
+	return c
+}
+
+func copyObservabilityPackStatuses(statuses []*ObservabilityPackStatus) []*ObservabilityPackStatus {
+	if statuses == nil {
+		return nil
+	}
+
+	c := make([]*ObservabilityPackStatus, len(statuses))
+	for i, st := range statuses {
+		copied := *st
+		c[i] = &copied
+	}
+
+	return c
+}
+
+// lock serializes status updates, since recipes may be installed concurrently.
+// It returns the function that releases the lock.
+func (s *InstallStatus) lock() func() {
+	if s.mutex == nil {
+		return func() {}
+	}
+
+	s.mutex.Lock()
+	return s.mutex.Unlock
+}
+
 func (s *InstallStatus) WasSuccessful() bool {
 	return s.hasAnyRecipeStatus(RecipeStatusTypes.INSTALLED)
 }
@@ -5,6 +5,7 @@ package execution
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -282,3 +283,59 @@ func TestStatus_HTTPSProxy(t *testing.T) {
 
 	require.Equal(t, "localhost:8888", s.HTTPSProxy)
 }
+
+// blockingStatusReporter blocks in RecipeInstalled until released, and
+// records the recipes it is notified of, in order.
+type blockingStatusReporter struct {
+	*MockStatusReporter
+	entered  chan struct{}
+	release  chan struct{}
+	notified []string
+}
+
+func (r *blockingStatusReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	close(r.entered)
+	<-r.release
+	r.notified = append(r.notified, event.Recipe.Name)
+	return nil
+}
+
+func (r *blockingStatusReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	r.notified = append(r.notified, event.Recipe.Name)
+	return nil
+}
+
+func TestInstallStatus_subscribersRunOutsideLock(t *testing.T) {
+	r := &blockingStatusReporter{
+		MockStatusReporter: NewMockStatusReporter(),
+		entered:            make(chan struct{}),
+		release:            make(chan struct{}),
+	}
+	s := NewInstallStatus([]StatusSubscriber{r}, NewPlatformLinkGenerator())
+
+	installed := make(chan struct{})
+	go func() {
+		s.RecipeInstalled(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "installed"}})
+		close(installed)
+	}()
+	<-r.entered
+
+	// The blocked subscriber does not hold up changes to the status.
+	failed := make(chan struct{})
+	go func() {
+		s.RecipeFailed(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "failed"}})
+		close(failed)
+	}()
+
+	require.Eventually(t, func() bool {
+		defer s.lock()()
+		return len(s.Statuses) == 2
+	}, time.Second, time.Millisecond)
+
+	close(r.release)
+	<-installed
+	<-failed
+
+	// Subscribers are notified in the order the changes were applied.
+	require.Equal(t, []string{"installed", "failed"}, r.notified)
+}
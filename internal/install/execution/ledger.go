@@ -0,0 +1,182 @@
+package execution
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// DefaultLedgerFile is the name of the install ledger within the config directory.
+var DefaultLedgerFile = "install-ledger.jsonl"
+
+// LedgerEntry records what happened to a recipe on this host.
+type LedgerEntry struct {
+	Recipe      string           `json:"recipe"`
+	DisplayName string           `json:"displayName,omitempty"`
+	Status      RecipeStatusType `json:"status"`
+	Timestamp   int64            `json:"timestamp"`
+	// RecipeHash identifies the version of the recipe's install steps that ran.
+	RecipeHash string `json:"recipeHash,omitempty"`
+	EntityGUID string `json:"entityGuid,omitempty"`
+	// Message describes why the recipe failed, was skipped or is unsupported.
+	Message    string `json:"message,omitempty"`
+	CLIVersion string `json:"cliVersion,omitempty"`
+	// Vars are the variables the recipe ran with, with secrets redacted.
+	Vars types.RecipeVars `json:"vars,omitempty"`
+	// Uninstall is the recipe's uninstall task at the time it was installed.
+	Uninstall string `json:"uninstall,omitempty"`
+}
+
+// RecipeHash returns a hash of the recipe's install steps.  Recipes are not
+// versioned, so this is what tells two runs of a changed recipe apart.
+func RecipeHash(r types.OpenInstallationRecipe) string {
+	if r.Install == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(r.Install))
+	return hex.EncodeToString(sum[:])
+}
+
+// Ledger is a local, append-only record of the recipes run on this host,
+// stored as one JSON document per line.
+type Ledger struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewLedger returns a ledger stored at the given path.
+func NewLedger(path string) *Ledger {
+	return &Ledger{
+		path: path,
+	}
+}
+
+// DefaultLedgerPath returns the path of the ledger in the config directory.
+func DefaultLedgerPath() string {
+	return filepath.Join(config.DefaultConfigDirectory, DefaultLedgerFile)
+}
+
+// Append adds an entry to the ledger.
+func (l *Ledger) Append(e LedgerEntry) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(l.path), 0750); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// Entries returns all entries in the ledger, oldest first.  A ledger that has
+// not been written to yet has no entries.
+func (l *Ledger) Entries() ([]LedgerEntry, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entries := []LedgerEntry{}
+
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e LedgerEntry
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("could not read line %d of %s: %s", line, l.path, err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, scanner.Err()
+}
+
+// Latest returns the most recent entry for the named recipe, or nil if the
+// recipe has never been run on this host.  When statuses are given, only
+// entries with one of those statuses are considered.
+func (l *Ledger) Latest(recipeName string, statuses ...RecipeStatusType) (*LedgerEntry, error) {
+	entries, err := l.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Recipe == recipeName && hasStatus(entries[i], statuses) {
+			return &entries[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// LatestByRecipe returns the most recent entry for each recipe in the ledger,
+// ordered by when each recipe was first run.
+func (l *Ledger) LatestByRecipe() ([]LedgerEntry, error) {
+	entries, err := l.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	index := map[string]int{}
+	latest := []LedgerEntry{}
+
+	for _, e := range entries {
+		if i, ok := index[e.Recipe]; ok {
+			latest[i] = e
+			continue
+		}
+
+		index[e.Recipe] = len(latest)
+		latest = append(latest, e)
+	}
+
+	return latest, nil
+}
+
+func hasStatus(e LedgerEntry, statuses []RecipeStatusType) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+
+	for _, s := range statuses {
+		if e.Status == s {
+			return true
+		}
+	}
+
+	return false
+}
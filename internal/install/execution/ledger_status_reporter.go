@@ -0,0 +1,123 @@
+package execution
+
+import (
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// LedgerStatusReporter is an implementation of the StatusSubscriber interface
+// that records the outcome of each recipe in the local install ledger.
+type LedgerStatusReporter struct {
+	ledger *Ledger
+}
+
+// NewLedgerStatusReporter returns a status reporter that writes to the given ledger.
+func NewLedgerStatusReporter(ledger *Ledger) *LedgerStatusReporter {
+	r := LedgerStatusReporter{
+		ledger: ledger,
+	}
+
+	return &r
+}
+
+func (r LedgerStatusReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.ledger.Append(r.entry(status, event, RecipeStatusTypes.INSTALLED))
+}
+
+func (r LedgerStatusReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.ledger.Append(r.entry(status, event, RecipeStatusTypes.FAILED))
+}
+
+func (r LedgerStatusReporter) RecipeSkipped(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.ledger.Append(r.entry(status, event, RecipeStatusTypes.SKIPPED))
+}
+
+func (r LedgerStatusReporter) RecipeUnsupported(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.ledger.Append(r.entry(status, event, RecipeStatusTypes.UNSUPPORTED))
+}
+
+// InstallCanceled records the recipes that had not finished when the install
+// was canceled.
+func (r LedgerStatusReporter) InstallCanceled(status *InstallStatus) error {
+	for _, s := range status.Statuses {
+		if s.Status != RecipeStatusTypes.CANCELED {
+			continue
+		}
+
+		err := r.ledger.Append(LedgerEntry{
+			Recipe:      s.Name,
+			DisplayName: s.DisplayName,
+			Status:      RecipeStatusTypes.CANCELED,
+			Timestamp:   utils.GetTimestamp(),
+			CLIVersion:  status.CLIVersion,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r LedgerStatusReporter) entry(status *InstallStatus, event RecipeStatusEvent, rs RecipeStatusType) LedgerEntry {
+	return LedgerEntry{
+		Recipe:      event.Recipe.Name,
+		DisplayName: event.Recipe.DisplayName,
+		Status:      rs,
+		Timestamp:   utils.GetTimestamp(),
+		RecipeHash:  RecipeHash(event.Recipe),
+		EntityGUID:  event.EntityGUID,
+		Message:     event.Msg,
+		CLIVersion:  status.CLIVersion,
+		Vars:        RedactSecretVars(event.Vars, event.Recipe.InputVars),
+		Uninstall:   event.Recipe.Uninstall,
+	}
+}
+
+func (r LedgerStatusReporter) InstallComplete(status *InstallStatus) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) DiscoveryComplete(status *InstallStatus, dm types.DiscoveryManifest) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) RecipeAvailable(status *InstallStatus, recipe types.OpenInstallationRecipe) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) RecipeInstalling(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) RecipeRecommended(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) RecipesSelected(status *InstallStatus, recipes []types.OpenInstallationRecipe) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) ObservabilityPackFetchPending(status *InstallStatus) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) ObservabilityPackFetchSuccess(status *InstallStatus) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) ObservabilityPackFetchFailed(status *InstallStatus) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) ObservabilityPackInstallPending(status *InstallStatus) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) ObservabilityPackInstallSuccess(status *InstallStatus) error {
+	return nil
+}
+
+func (r LedgerStatusReporter) ObservabilityPackInstallFailed(status *InstallStatus) error {
+	return nil
+}
@@ -0,0 +1,152 @@
+// +build unit
+
+package execution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestLedger_Empty(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewLedger(filepath.Join(dir, "ledger.jsonl"))
+
+	entries, err := l.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	e, err := l.Latest("test-recipe")
+	require.NoError(t, err)
+	require.Nil(t, e)
+}
+
+func TestLedger_AppendAndLatest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewLedger(filepath.Join(dir, "nested", "ledger.jsonl"))
+
+	require.NoError(t, l.Append(LedgerEntry{Recipe: "test-recipe", Status: RecipeStatusTypes.FAILED, Timestamp: 1}))
+	require.NoError(t, l.Append(LedgerEntry{Recipe: "another-recipe", Status: RecipeStatusTypes.INSTALLED, Timestamp: 2}))
+	require.NoError(t, l.Append(LedgerEntry{Recipe: "test-recipe", Status: RecipeStatusTypes.INSTALLED, Timestamp: 3}))
+
+	entries, err := l.Entries()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+
+	e, err := l.Latest("test-recipe")
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	require.Equal(t, RecipeStatusTypes.INSTALLED, e.Status)
+	require.Equal(t, int64(3), e.Timestamp)
+
+	e, err = l.Latest("test-recipe", RecipeStatusTypes.FAILED)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), e.Timestamp)
+
+	e, err = l.Latest("test-recipe", RecipeStatusTypes.SKIPPED)
+	require.NoError(t, err)
+	require.Nil(t, e)
+
+	latest, err := l.LatestByRecipe()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(latest))
+	require.Equal(t, "test-recipe", latest[0].Recipe)
+	require.Equal(t, int64(3), latest[0].Timestamp)
+	require.Equal(t, "another-recipe", latest[1].Recipe)
+}
+
+func TestRecipeHash(t *testing.T) {
+	require.Empty(t, RecipeHash(types.OpenInstallationRecipe{}))
+
+	h := RecipeHash(types.OpenInstallationRecipe{Install: "install"})
+	require.Len(t, h, 64)
+	require.Equal(t, h, RecipeHash(types.OpenInstallationRecipe{Name: "other", Install: "install"}))
+	require.NotEqual(t, h, RecipeHash(types.OpenInstallationRecipe{Install: "changed"}))
+}
+
+func TestLedgerStatusReporter_RecipeInstalled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewLedger(filepath.Join(dir, "ledger.jsonl"))
+	var r StatusSubscriber = NewLedgerStatusReporter(l)
+
+	err = r.RecipeInstalled(&InstallStatus{CLIVersion: "0.0.1"}, RecipeStatusEvent{
+		EntityGUID: "testGUID",
+		Recipe: types.OpenInstallationRecipe{
+			Name:      "test-recipe",
+			Install:   "install",
+			Uninstall: "uninstall",
+			InputVars: []types.OpenInstallationRecipeInputVariable{
+				{Name: "DB_PASS", Secret: true},
+			},
+		},
+		Vars: types.RecipeVars{
+			"DB_PASS":               "hunter2",
+			"NEW_RELIC_LICENSE_KEY": "abc123",
+			"DB_PORT":               "5432",
+			"API_TOKEN":             "xyz",
+			"SSL_KEY_PATH":          "/etc/ssl/key.pem",
+			"KEYSTORE_DIR":          "/etc/keystore",
+		},
+	})
+	require.NoError(t, err)
+
+	e, err := l.Latest("test-recipe")
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	require.Equal(t, RecipeStatusTypes.INSTALLED, e.Status)
+	require.Equal(t, "uninstall", e.Uninstall)
+	require.Equal(t, "testGUID", e.EntityGUID)
+	require.Equal(t, "0.0.1", e.CLIVersion)
+	require.Equal(t, RecipeHash(types.OpenInstallationRecipe{Install: "install"}), e.RecipeHash)
+	require.Equal(t, RedactedValue, e.Vars["DB_PASS"])
+	require.Equal(t, RedactedValue, e.Vars["NEW_RELIC_LICENSE_KEY"])
+	require.Equal(t, "5432", e.Vars["DB_PORT"])
+	require.Equal(t, RedactedValue, e.Vars["API_TOKEN"])
+	require.Equal(t, "/etc/ssl/key.pem", e.Vars["SSL_KEY_PATH"])
+	require.Equal(t, "/etc/keystore", e.Vars["KEYSTORE_DIR"])
+}
+
+func TestLedgerStatusReporter_Outcomes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewLedger(filepath.Join(dir, "ledger.jsonl"))
+	r := NewLedgerStatusReporter(l)
+	status := &InstallStatus{
+		Statuses: []*RecipeStatus{
+			{Name: "canceled-recipe", Status: RecipeStatusTypes.CANCELED},
+			{Name: "failed-recipe", Status: RecipeStatusTypes.FAILED},
+		},
+	}
+
+	require.NoError(t, r.RecipeFailed(status, RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "failed-recipe"}, Msg: "failed"}))
+	require.NoError(t, r.RecipeSkipped(status, RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "skipped-recipe"}}))
+	require.NoError(t, r.RecipeUnsupported(status, RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "unsupported-recipe"}}))
+	require.NoError(t, r.RecipeInstalling(status, RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "installing-recipe"}}))
+	require.NoError(t, r.InstallCanceled(status))
+
+	entries, err := l.Entries()
+	require.NoError(t, err)
+	require.Equal(t, 4, len(entries))
+	require.Equal(t, RecipeStatusTypes.FAILED, entries[0].Status)
+	require.Equal(t, "failed", entries[0].Message)
+	require.Equal(t, RecipeStatusTypes.SKIPPED, entries[1].Status)
+	require.Equal(t, RecipeStatusTypes.UNSUPPORTED, entries[2].Status)
+	require.Equal(t, RecipeStatusTypes.CANCELED, entries[3].Status)
+	require.Equal(t, "canceled-recipe", entries[3].Recipe)
+}
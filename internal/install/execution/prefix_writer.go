@@ -0,0 +1,84 @@
+package execution
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+type outputPrefixKey struct{}
+
+// outputMutex serializes writes from all prefix writers, so that lines from
+// recipes running concurrently are not interleaved.
+var outputMutex sync.Mutex
+
+// WithOutputPrefix returns a context that causes recipe output to be prefixed,
+// so that the output of recipes running concurrently can be told apart.
+func WithOutputPrefix(ctx context.Context, prefix string) context.Context {
+	return context.WithValue(ctx, outputPrefixKey{}, prefix)
+}
+
+func outputPrefix(ctx context.Context) string {
+	prefix, _ := ctx.Value(outputPrefixKey{}).(string)
+	return prefix
+}
+
+// PrefixWriter prefixes each line written to it.  Only whole lines are written
+// to the underlying writer; a trailing partial line is held until it is
+// completed or the writer is flushed.
+type PrefixWriter struct {
+	prefix  string
+	writer  io.Writer
+	current []byte
+}
+
+func NewPrefixWriter(w io.Writer, prefix string) *PrefixWriter {
+	return &PrefixWriter{
+		prefix: prefix,
+		writer: w,
+	}
+}
+
+func (p *PrefixWriter) Write(b []byte) (int, error) {
+	var lines []byte
+
+	for _, c := range b {
+		p.current = append(p.current, c)
+
+		if c == '\n' {
+			lines = append(lines, p.prefix...)
+			lines = append(lines, p.current...)
+			p.current = p.current[:0]
+		}
+	}
+
+	if err := p.write(lines); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
+// Flush writes any partial line that has not yet been terminated.
+func (p *PrefixWriter) Flush() error {
+	if len(p.current) == 0 {
+		return nil
+	}
+
+	line := append([]byte(p.prefix), p.current...)
+	p.current = p.current[:0]
+
+	return p.write(append(line, '\n'))
+}
+
+func (p *PrefixWriter) write(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	_, err := p.writer.Write(b)
+	return err
+}
@@ -0,0 +1,39 @@
+// +build unit
+
+package execution
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	w := bytes.NewBufferString("")
+	p := NewPrefixWriter(w, "[test] ")
+
+	n, err := p.Write([]byte("abc\n123"))
+	require.NoError(t, err)
+	require.Equal(t, 7, n)
+	require.Equal(t, "[test] abc\n", w.String())
+
+	_, err = p.Write([]byte("456\ndef"))
+	require.NoError(t, err)
+	require.Equal(t, "[test] abc\n[test] 123456\n", w.String())
+
+	require.NoError(t, p.Flush())
+	require.Equal(t, "[test] abc\n[test] 123456\n[test] def\n", w.String())
+
+	require.NoError(t, p.Flush())
+	require.Equal(t, "[test] abc\n[test] 123456\n[test] def\n", w.String())
+}
+
+func TestOutputPrefix(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, "", outputPrefix(ctx))
+
+	ctx = WithOutputPrefix(ctx, "[test] ")
+	require.Equal(t, "[test] ", outputPrefix(ctx))
+}
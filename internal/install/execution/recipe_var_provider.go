@@ -3,7 +3,6 @@ package execution
 import (
 	"errors"
 	"fmt"
-	"net/url"
 	"os"
 	"regexp"
 	"strconv"
@@ -21,9 +20,29 @@ var (
 		`(.)?download\.newrelic\.com$`,
 		`nr-downloads-ohai-(staging|testing)\.s3-website-us-east-1\.amazonaws\.com$`,
 	}
+
+	// defaultDownloadMirrorPolicy trusts only New Relic's own download hosts.
+	defaultDownloadMirrorPolicy = NewDownloadMirrorPolicy(types.DownloadMirror{})
+
+	// RedactedValue replaces the values of secret variables.
+	RedactedValue = "********"
+	// secretVarNameRE matches variable names whose last underscore-separated
+	// word names a secret, such as NEW_RELIC_LICENSE_KEY, but not names like
+	// SSL_KEY_PATH or KEYSTORE_DIR that only refer to one.
+	secretVarNameRE = regexp.MustCompile(`(?i)(^|_)(KEY|PASSWORD|SECRET|TOKEN|CREDENTIALS?)$`)
 )
 
-type RecipeVarProvider struct{}
+type RecipeVarProvider struct {
+	// Answers, when set, supply input variable values ahead of prompting.
+	Answers *types.Answers
+	// MirrorPolicy decides whether NEW_RELIC_DOWNLOAD_URL is trusted.  When
+	// not set, only New Relic's own download hosts are.
+	MirrorPolicy *DownloadMirrorPolicy
+	// DownloadURL, when set, replaces NEW_RELIC_DOWNLOAD_URL.  It is not checked
+	// against the access list, so it must only be set by the CLI itself, such as
+	// for the file server of an install bundle.
+	DownloadURL string
+}
 
 func NewRecipeVarProvider() *RecipeVarProvider {
 	return &RecipeVarProvider{}
@@ -45,12 +64,12 @@ func (re *RecipeVarProvider) Prepare(m types.DiscoveryManifest, r types.OpenInst
 		return types.RecipeVars{}, err
 	}
 
-	inputVarsResult, err := varsFromInput(r.InputVars, assumeYes)
+	inputVarsResult, err := varsFromInput(r, re.Answers, assumeYes)
 	if err != nil {
 		return types.RecipeVars{}, err
 	}
 
-	envVarsResult := varFromEnv()
+	envVarsResult := varFromEnv(re.MirrorPolicy)
 
 	results = append(results, systemInfoResult)
 	results = append(results, profileResult)
@@ -64,6 +83,37 @@ func (re *RecipeVarProvider) Prepare(m types.DiscoveryManifest, r types.OpenInst
 		}
 	}
 
+	if re.DownloadURL != "" {
+		vars["NEW_RELIC_DOWNLOAD_URL"] = re.DownloadURL
+	}
+
+	return vars, nil
+}
+
+// SimulatedRecipeVars returns the variables a recipe would be executed with on
+// the given host, with placeholder credentials, for testing recipes without an
+// account.  Input variables are taken from the environment, the answers or
+// their defaults, without prompting.  The download URL is decided by the given
+// mirror policy, or the default one when it is nil.
+func SimulatedRecipeVars(m types.DiscoveryManifest, r types.OpenInstallationRecipe, answers *types.Answers, policy *DownloadMirrorPolicy) (types.RecipeVars, error) {
+	vars := types.RecipeVars{
+		"NEW_RELIC_LICENSE_KEY": "simulatedLicenseKey",
+		"NEW_RELIC_ACCOUNT_ID":  "0",
+		"NEW_RELIC_API_KEY":     "simulatedAPIKey",
+		"NEW_RELIC_REGION":      "US",
+	}
+
+	inputVarsResult, err := varsFromInput(r, answers, true)
+	if err != nil {
+		return types.RecipeVars{}, err
+	}
+
+	for _, result := range []types.RecipeVars{varsFromSystemInfo(m), inputVarsResult, varFromEnv(policy)} {
+		for k, v := range result {
+			vars[k] = v
+		}
+	}
+
 	return vars, nil
 }
 
@@ -98,12 +148,12 @@ func varsFromSystemInfo(m types.DiscoveryManifest) types.RecipeVars {
 	return vars
 }
 
-func varsFromInput(inputVars []types.OpenInstallationRecipeInputVariable, assumeYes bool) (types.RecipeVars, error) {
+func varsFromInput(r types.OpenInstallationRecipe, answers *types.Answers, assumeYes bool) (types.RecipeVars, error) {
 	vars := make(types.RecipeVars)
 
 	vars["NEW_RELIC_ASSUME_YES"] = fmt.Sprintf("%t", assumeYes)
 
-	for _, envConfig := range inputVars {
+	for _, envConfig := range r.InputVars {
 		var err error
 		envValue := os.Getenv(envConfig.Name)
 
@@ -112,6 +162,15 @@ func varsFromInput(inputVars []types.OpenInstallationRecipeInputVariable, assume
 			continue
 		}
 
+		if answer, ok := answers.Variable(r.Name, envConfig.Name); ok {
+			log.WithFields(log.Fields{
+				"name": envConfig.Name,
+			}).Debug("using value from answers file")
+
+			vars[envConfig.Name] = answer
+			continue
+		}
+
 		if assumeYes {
 			if envConfig.Default == "" {
 				return types.RecipeVars{}, fmt.Errorf("no default value for environment variable %s and none provided", envConfig.Name)
@@ -180,28 +239,33 @@ func varFromPrompt(envConfig types.OpenInstallationRecipeInputVariable) (string,
 
 }
 
-func varFromEnv() types.RecipeVars {
+func varFromEnv(policy *DownloadMirrorPolicy) types.RecipeVars {
 	vars := make(types.RecipeVars)
 
-	downloadURL := "https://download.newrelic.com/"
-	envDownloadURL := os.Getenv("NEW_RELIC_DOWNLOAD_URL")
-	if envDownloadURL != "" {
-		URL, err := url.Parse(envDownloadURL)
-		if err == nil {
-			if URL.Scheme == "https" {
-				for _, regexString := range downloadURLAccessListRegex {
-					var regex = regexp.MustCompile(regexString)
-					if regex.MatchString(URL.Host) {
-						downloadURL = envDownloadURL
-						break
-					}
-				}
-			}
-		} else {
-			log.Warnf("Could not parse download URL: %s, detail: %s", envDownloadURL, err.Error())
-		}
+	if policy == nil {
+		policy = defaultDownloadMirrorPolicy
 	}
-	vars["NEW_RELIC_DOWNLOAD_URL"] = downloadURL
+
+	vars["NEW_RELIC_DOWNLOAD_URL"] = policy.DownloadURL(os.Getenv("NEW_RELIC_DOWNLOAD_URL"))
 
 	return vars
 }
+
+// RedactSecretVars returns a copy of vars with the values of secret input
+// variables, and of variables whose names suggest a secret, redacted.
+func RedactSecretVars(vars types.RecipeVars, inputVars []types.OpenInstallationRecipeInputVariable) types.RecipeVars {
+	secret := map[string]bool{}
+	for _, v := range inputVars {
+		secret[v.Name] = v.Secret
+	}
+
+	redacted := types.RecipeVars{}
+	for k, v := range vars {
+		if v != "" && (secret[k] || secretVarNameRE.MatchString(k)) {
+			v = RedactedValue
+		}
+		redacted[k] = v
+	}
+
+	return redacted
+}
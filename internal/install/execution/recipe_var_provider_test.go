@@ -510,3 +510,42 @@ func TestRecipeVarProvider_OverrideDownloadURL_RefusedNotNewRelic(t *testing.T)
 	require.NoError(t, err)
 	require.Contains(t, "https://download.newrelic.com/", v["NEW_RELIC_DOWNLOAD_URL"])
 }
+
+func TestVarsFromInput_Answers(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		InputVars: []types.OpenInstallationRecipeInputVariable{
+			{Name: "TEST_ANSWERED"},
+			{Name: "TEST_DEFAULTED", Default: "default"},
+		},
+	}
+
+	answers := &types.Answers{
+		Variables: map[string]map[string]string{
+			"test-recipe": {"TEST_ANSWERED": "answer"},
+		},
+	}
+
+	v, err := varsFromInput(r, answers, true)
+	require.NoError(t, err)
+	require.Equal(t, "answer", v["TEST_ANSWERED"])
+	require.Equal(t, "default", v["TEST_DEFAULTED"])
+
+	os.Setenv("TEST_ANSWERED", "fromEnv")
+	defer os.Unsetenv("TEST_ANSWERED")
+
+	v, err = varsFromInput(r, answers, true)
+	require.NoError(t, err)
+	require.Equal(t, "fromEnv", v["TEST_ANSWERED"])
+}
+
+func TestRecipeVarProvider_BundleDownloadURL(t *testing.T) {
+	e := NewRecipeVarProvider()
+	e.DownloadURL = "http://127.0.0.1:8080/"
+
+	credentials.SetDefaultProfile(credentials.Profile{})
+
+	v, err := e.Prepare(types.DiscoveryManifest{}, types.OpenInstallationRecipe{}, false, "testLicenseKey")
+	require.NoError(t, err)
+	require.Equal(t, "http://127.0.0.1:8080/", v["NEW_RELIC_DOWNLOAD_URL"])
+}
@@ -0,0 +1,308 @@
+package execution
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// ReportFormat is the format of an install report file.
+type ReportFormat string
+
+var ReportFormats = struct {
+	JSON  ReportFormat
+	JUnit ReportFormat
+}{
+	JSON:  "json",
+	JUnit: "junit",
+}
+
+// InstallReport is the machine-readable summary of an install run.
+type InstallReport struct {
+	Complete    bool                       `json:"complete"`
+	Canceled    bool                       `json:"canceled"`
+	Success     bool                       `json:"success"`
+	Timestamp   int64                      `json:"timestamp"`
+	CLIVersion  string                     `json:"cliVersion"`
+	Error       *StatusError               `json:"error,omitempty"`
+	Recipes     []*RecipeStatus            `json:"recipes"`
+	Packs       []*ObservabilityPackStatus `json:"packs"`
+	EntityGUIDs []string                   `json:"entityGuids"`
+	RedirectURL string                     `json:"redirectUrl,omitempty"`
+	LogFilePath string                     `json:"logFilePath"`
+}
+
+// ReportFileStatusReporter is an implementation of the StatusSubscriber
+// interface that writes a report file at the end of an install, for tools
+// that need to act on the outcome.
+type ReportFileStatusReporter struct {
+	path   string
+	format ReportFormat
+}
+
+// NewReportFileStatusReporter returns a status reporter that writes its report
+// to the given path.  Paths ending in .xml get a JUnit report, and all other
+// paths a JSON report.
+func NewReportFileStatusReporter(path string) *ReportFileStatusReporter {
+	r := ReportFileStatusReporter{
+		path:   path,
+		format: ReportFormats.JSON,
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		r.format = ReportFormats.JUnit
+	}
+
+	return &r
+}
+
+func (r ReportFileStatusReporter) InstallComplete(status *InstallStatus) error {
+	return r.writeReport(NewInstallReport(status, false))
+}
+
+func (r ReportFileStatusReporter) InstallCanceled(status *InstallStatus) error {
+	return r.writeReport(NewInstallReport(status, true))
+}
+
+// NewInstallReport summarizes the install status.
+func NewInstallReport(status *InstallStatus, canceled bool) InstallReport {
+	report := InstallReport{
+		Complete:    status.Complete,
+		Canceled:    canceled,
+		Success:     !canceled && status.WasSuccessful() && !status.hasAnyRecipeStatus(RecipeStatusTypes.FAILED),
+		Timestamp:   status.Timestamp,
+		CLIVersion:  status.CLIVersion,
+		Recipes:     status.Statuses,
+		Packs:       status.ObservabilityPackStatuses,
+		EntityGUIDs: status.EntityGUIDs,
+		RedirectURL: status.RedirectURL,
+		LogFilePath: status.LogFilePath,
+	}
+
+	if status.Error.Message != "" {
+		report.Error = &status.Error
+	}
+
+	if report.Recipes == nil {
+		report.Recipes = []*RecipeStatus{}
+	}
+
+	if report.Packs == nil {
+		report.Packs = []*ObservabilityPackStatus{}
+	}
+
+	if report.EntityGUIDs == nil {
+		report.EntityGUIDs = []string{}
+	}
+
+	return report
+}
+
+func (r ReportFileStatusReporter) writeReport(report InstallReport) error {
+	var b []byte
+	var err error
+
+	switch r.format {
+	case ReportFormats.JUnit:
+		b, err = xml.MarshalIndent(newJUnitTestSuites(report), "", "  ")
+		b = append([]byte(xml.Header), b...)
+	default:
+		b, err = json.MarshalIndent(report, "", "  ")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(r.path, append(b, '\n'), 0600); err != nil {
+		return fmt.Errorf("could not write install report: %s", err)
+	}
+
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func newJUnitTestSuites(report InstallReport) junitTestSuites {
+	recipes := junitTestSuite{Name: "recipes"}
+	for _, s := range report.Recipes {
+		recipes.add(recipeTestCase(s))
+	}
+
+	packs := junitTestSuite{Name: "observabilityPacks"}
+	for _, s := range report.Packs {
+		packs.add(packTestCase(s))
+	}
+
+	return junitTestSuites{
+		Suites: []junitTestSuite{recipes, packs},
+	}
+}
+
+func (s *junitTestSuite) add(c junitTestCase) {
+	s.Tests++
+
+	if c.Failure != nil {
+		s.Failures++
+	}
+
+	if c.Skipped != nil {
+		s.Skipped++
+	}
+
+	s.Cases = append(s.Cases, c)
+}
+
+func recipeTestCase(s *RecipeStatus) junitTestCase {
+	c := junitTestCase{
+		Name:      s.Name,
+		ClassName: "recipe",
+		Time:      fmt.Sprintf("%.3f", float64(s.ValidationDurationMilliseconds)/1000),
+	}
+
+	if s.EntityGUID != "" {
+		c.SystemOut = fmt.Sprintf("entityGuid: %s", s.EntityGUID)
+	}
+
+	switch s.Status {
+	case RecipeStatusTypes.INSTALLED:
+	case RecipeStatusTypes.FAILED:
+		c.Failure = &junitMessage{
+			Message: s.Error.Message,
+			Body:    taskPathDetails(s.Error),
+		}
+	default:
+		msg := strings.ToLower(string(s.Status))
+		if s.Error.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, s.Error.Message)
+		}
+
+		c.Skipped = &junitMessage{
+			Message: msg,
+		}
+	}
+
+	return c
+}
+
+func packTestCase(s *ObservabilityPackStatus) junitTestCase {
+	c := junitTestCase{
+		Name:      s.Name,
+		ClassName: "observabilityPack",
+		Time:      "0.000",
+	}
+
+	switch s.Status {
+	case ObservabilityPackStatusTypes.InstallSuccess, ObservabilityPackStatusTypes.FetchSuccess:
+	case ObservabilityPackStatusTypes.InstallFailed, ObservabilityPackStatusTypes.FetchFailed:
+		c.Failure = &junitMessage{
+			Message: s.Error.Message,
+			Body:    s.Error.Details,
+		}
+	default:
+		c.Skipped = &junitMessage{
+			Message: strings.ToLower(string(s.Status)),
+		}
+	}
+
+	return c
+}
+
+func taskPathDetails(e StatusError) string {
+	if len(e.TaskPath) == 0 {
+		return e.Details
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("task path: %s\n%s", strings.Join(e.TaskPath, " > "), e.Details))
+}
+
+func (r ReportFileStatusReporter) DiscoveryComplete(status *InstallStatus, dm types.DiscoveryManifest) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) RecipeAvailable(status *InstallStatus, recipe types.OpenInstallationRecipe) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) RecipeInstalling(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) RecipeRecommended(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) RecipeSkipped(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) RecipeUnsupported(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) RecipesSelected(status *InstallStatus, recipes []types.OpenInstallationRecipe) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) ObservabilityPackFetchPending(status *InstallStatus) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) ObservabilityPackFetchSuccess(status *InstallStatus) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) ObservabilityPackFetchFailed(status *InstallStatus) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) ObservabilityPackInstallPending(status *InstallStatus) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) ObservabilityPackInstallSuccess(status *InstallStatus) error {
+	return nil
+}
+
+func (r ReportFileStatusReporter) ObservabilityPackInstallFailed(status *InstallStatus) error {
+	return nil
+}
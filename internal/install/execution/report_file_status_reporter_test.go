@@ -0,0 +1,119 @@
+// +build unit
+
+package execution
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestReportFileStatusReporter_interface(t *testing.T) {
+	var r StatusSubscriber = NewReportFileStatusReporter("report.json")
+	require.NotNil(t, r)
+}
+
+func testReportStatus() *InstallStatus {
+	s := NewInstallStatus([]StatusSubscriber{}, NewMockPlatformLinkGenerator())
+	s.RecipeInstalled(RecipeStatusEvent{
+		Recipe:                         types.OpenInstallationRecipe{Name: "installed-recipe"},
+		EntityGUID:                     "testGUID",
+		ValidationDurationMilliseconds: 1500,
+	})
+	s.RecipeFailed(RecipeStatusEvent{
+		Recipe:   types.OpenInstallationRecipe{Name: "failed-recipe"},
+		Msg:      "execution failed",
+		TaskPath: []string{"default", "install"},
+	})
+	s.RecipeSkipped(RecipeStatusEvent{
+		Recipe: types.OpenInstallationRecipe{Name: "skipped-recipe"},
+	})
+	s.ObservabilityPackStatuses = []*ObservabilityPackStatus{
+		{Name: "installed-pack", Status: ObservabilityPackStatusTypes.InstallSuccess},
+		{Name: "failed-pack", Status: ObservabilityPackStatusTypes.InstallFailed, Error: StatusError{Message: "pack failed"}},
+	}
+	s.RedirectURL = "https://example.com/redirect"
+
+	return s
+}
+
+func TestReportFileStatusReporter_JSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "report.json")
+	r := NewReportFileStatusReporter(path)
+
+	s := testReportStatus()
+	require.NoError(t, r.InstallComplete(s))
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var report InstallReport
+	require.NoError(t, json.Unmarshal(b, &report))
+	require.False(t, report.Success)
+	require.False(t, report.Canceled)
+	require.Equal(t, "https://example.com/redirect", report.RedirectURL)
+	require.Equal(t, []string{"testGUID"}, report.EntityGUIDs)
+	require.Equal(t, 3, len(report.Recipes))
+	require.Equal(t, RecipeStatusTypes.INSTALLED, report.Recipes[0].Status)
+	require.Equal(t, int64(1500), report.Recipes[0].ValidationDurationMilliseconds)
+	require.Equal(t, RecipeStatusTypes.FAILED, report.Recipes[1].Status)
+	require.Equal(t, "execution failed", report.Recipes[1].Error.Message)
+	require.Equal(t, []string{"default", "install"}, report.Recipes[1].Error.TaskPath)
+	require.Equal(t, 2, len(report.Packs))
+}
+
+func TestReportFileStatusReporter_JUnit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "report.xml")
+	r := NewReportFileStatusReporter(path)
+
+	require.NoError(t, r.InstallCanceled(testReportStatus()))
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(b, &suites))
+	require.Equal(t, 2, len(suites.Suites))
+
+	recipes := suites.Suites[0]
+	require.Equal(t, 3, recipes.Tests)
+	require.Equal(t, 1, recipes.Failures)
+	require.Equal(t, 1, recipes.Skipped)
+	require.Equal(t, "1.500", recipes.Cases[0].Time)
+	require.Nil(t, recipes.Cases[0].Failure)
+	require.Equal(t, "execution failed", recipes.Cases[1].Failure.Message)
+	require.Contains(t, recipes.Cases[1].Failure.Body, "default > install")
+	require.Equal(t, "skipped", recipes.Cases[2].Skipped.Message)
+
+	packs := suites.Suites[1]
+	require.Equal(t, 2, packs.Tests)
+	require.Equal(t, 1, packs.Failures)
+	require.Equal(t, "pack failed", packs.Cases[1].Failure.Message)
+}
+
+func TestNewInstallReport_Success(t *testing.T) {
+	s := NewInstallStatus([]StatusSubscriber{}, NewMockPlatformLinkGenerator())
+	s.RecipeInstalled(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "installed-recipe"}})
+
+	report := NewInstallReport(s, false)
+	require.True(t, report.Success)
+	require.Nil(t, report.Error)
+
+	report = NewInstallReport(s, true)
+	require.False(t, report.Success)
+}
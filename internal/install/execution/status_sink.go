@@ -0,0 +1,218 @@
+package execution
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// StatusSink receives install status events as they happen.
+type StatusSink interface {
+	Send(event StatusSinkEvent) error
+}
+
+// flushingStatusSink is a StatusSink that sends events in the background, and
+// can wait for them to be sent.
+type flushingStatusSink interface {
+	Flush(timeout time.Duration) error
+}
+
+// statusSinkFlushTimeout bounds how long the end of an install waits for a
+// status sink to send its remaining events.
+var statusSinkFlushTimeout = 15 * time.Second
+
+// StatusSinkEvent is a single install status event, as sent to a StatusSink.
+type StatusSinkEvent struct {
+	Event      string `json:"event"`
+	Timestamp  int64  `json:"timestamp"`
+	InstallID  string `json:"installId"`
+	CLIVersion string `json:"cliVersion,omitempty"`
+
+	Recipe                         string           `json:"recipe,omitempty"`
+	DisplayName                    string           `json:"displayName,omitempty"`
+	Status                         RecipeStatusType `json:"status,omitempty"`
+	Message                        string           `json:"message,omitempty"`
+	TaskPath                       []string         `json:"taskPath,omitempty"`
+	EntityGUID                     string           `json:"entityGuid,omitempty"`
+	ValidationDurationMilliseconds int64            `json:"validationDurationMilliseconds,omitempty"`
+
+	Recipes        []string                   `json:"recipes,omitempty"`
+	RecipeStatuses []*RecipeStatus            `json:"recipeStatuses,omitempty"`
+	Packs          []*ObservabilityPackStatus `json:"packs,omitempty"`
+	Error          *StatusError               `json:"error,omitempty"`
+	RedirectURL    string                     `json:"redirectUrl,omitempty"`
+}
+
+// NewStatusSink returns the sink described by spec.  HTTP and HTTPS URLs are
+// webhooks that each event is posted to, and anything else is the path of a
+// file that events are appended to as JSON lines.
+func NewStatusSink(spec string) (StatusSink, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("status sink must not be empty")
+	}
+
+	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+		u, err := url.Parse(spec)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid status sink URL %s", spec)
+		}
+
+		return NewWebhookStatusSink(spec), nil
+	}
+
+	return NewFileStatusSink(strings.TrimPrefix(spec, "file://")), nil
+}
+
+// SinkStatusReporter is an implementation of the StatusSubscriber interface
+// that forwards every status event to a StatusSink.
+type SinkStatusReporter struct {
+	sink StatusSink
+}
+
+// NewSinkStatusReporter returns a status reporter that sends events to the given sink.
+func NewSinkStatusReporter(sink StatusSink) *SinkStatusReporter {
+	r := SinkStatusReporter{
+		sink: sink,
+	}
+
+	return &r
+}
+
+func (r SinkStatusReporter) send(status *InstallStatus, name string, e StatusSinkEvent) error {
+	e.Event = name
+	e.Timestamp = utils.GetTimestamp()
+	e.InstallID = status.DocumentID
+	e.CLIVersion = status.CLIVersion
+
+	return r.sink.Send(e)
+}
+
+func (r SinkStatusReporter) sendRecipe(status *InstallStatus, name string, event RecipeStatusEvent, rs RecipeStatusType) error {
+	return r.send(status, name, StatusSinkEvent{
+		Recipe:                         event.Recipe.Name,
+		DisplayName:                    event.Recipe.DisplayName,
+		Status:                         rs,
+		Message:                        event.Msg,
+		TaskPath:                       event.TaskPath,
+		EntityGUID:                     event.EntityGUID,
+		ValidationDurationMilliseconds: event.ValidationDurationMilliseconds,
+	})
+}
+
+func (r SinkStatusReporter) sendPacks(status *InstallStatus, name string) error {
+	return r.send(status, name, StatusSinkEvent{
+		Packs: status.ObservabilityPackStatuses,
+	})
+}
+
+func (r SinkStatusReporter) sendFinal(status *InstallStatus, name string) error {
+	e := StatusSinkEvent{
+		RecipeStatuses: status.Statuses,
+		Packs:          status.ObservabilityPackStatuses,
+		RedirectURL:    status.RedirectURL,
+	}
+
+	if status.Error.Message != "" {
+		e.Error = &status.Error
+	}
+
+	return r.send(status, name, e)
+}
+
+// flush waits for a sink that sends events in the background to send the
+// events it has been given.
+func (r SinkStatusReporter) flush() error {
+	if f, ok := r.sink.(flushingStatusSink); ok {
+		return f.Flush(statusSinkFlushTimeout)
+	}
+
+	return nil
+}
+
+func (r SinkStatusReporter) InstallCanceled(status *InstallStatus) error {
+	if err := r.sendFinal(status, "installCanceled"); err != nil {
+		return err
+	}
+
+	return r.flush()
+}
+
+func (r SinkStatusReporter) InstallComplete(status *InstallStatus) error {
+	if err := r.sendFinal(status, "installComplete"); err != nil {
+		return err
+	}
+
+	return r.flush()
+}
+
+func (r SinkStatusReporter) DiscoveryComplete(status *InstallStatus, dm types.DiscoveryManifest) error {
+	return r.send(status, "discoveryComplete", StatusSinkEvent{})
+}
+
+func (r SinkStatusReporter) RecipeAvailable(status *InstallStatus, recipe types.OpenInstallationRecipe) error {
+	return r.sendRecipe(status, "recipeAvailable", RecipeStatusEvent{Recipe: recipe}, RecipeStatusTypes.AVAILABLE)
+}
+
+func (r SinkStatusReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.sendRecipe(status, "recipeFailed", event, RecipeStatusTypes.FAILED)
+}
+
+func (r SinkStatusReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.sendRecipe(status, "recipeInstalled", event, RecipeStatusTypes.INSTALLED)
+}
+
+func (r SinkStatusReporter) RecipeInstalling(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.sendRecipe(status, "recipeInstalling", event, RecipeStatusTypes.INSTALLING)
+}
+
+func (r SinkStatusReporter) RecipeRecommended(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.sendRecipe(status, "recipeRecommended", event, RecipeStatusTypes.RECOMMENDED)
+}
+
+func (r SinkStatusReporter) RecipeSkipped(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.sendRecipe(status, "recipeSkipped", event, RecipeStatusTypes.SKIPPED)
+}
+
+func (r SinkStatusReporter) RecipeUnsupported(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.sendRecipe(status, "recipeUnsupported", event, RecipeStatusTypes.UNSUPPORTED)
+}
+
+func (r SinkStatusReporter) RecipesSelected(status *InstallStatus, recipes []types.OpenInstallationRecipe) error {
+	e := StatusSinkEvent{
+		Recipes: []string{},
+	}
+
+	for _, rr := range recipes {
+		e.Recipes = append(e.Recipes, rr.Name)
+	}
+
+	return r.send(status, "recipesSelected", e)
+}
+
+func (r SinkStatusReporter) ObservabilityPackFetchPending(status *InstallStatus) error {
+	return r.sendPacks(status, "observabilityPackFetchPending")
+}
+
+func (r SinkStatusReporter) ObservabilityPackFetchSuccess(status *InstallStatus) error {
+	return r.sendPacks(status, "observabilityPackFetchSuccess")
+}
+
+func (r SinkStatusReporter) ObservabilityPackFetchFailed(status *InstallStatus) error {
+	return r.sendPacks(status, "observabilityPackFetchFailed")
+}
+
+func (r SinkStatusReporter) ObservabilityPackInstallPending(status *InstallStatus) error {
+	return r.sendPacks(status, "observabilityPackInstallPending")
+}
+
+func (r SinkStatusReporter) ObservabilityPackInstallSuccess(status *InstallStatus) error {
+	return r.sendPacks(status, "observabilityPackInstallSuccess")
+}
+
+func (r SinkStatusReporter) ObservabilityPackInstallFailed(status *InstallStatus) error {
+	return r.sendPacks(status, "observabilityPackInstallFailed")
+}
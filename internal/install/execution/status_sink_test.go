@@ -0,0 +1,205 @@
+// +build unit
+
+package execution
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+func TestSinkStatusReporter_interface(t *testing.T) {
+	var r StatusSubscriber = NewSinkStatusReporter(NewFileStatusSink("events.jsonl"))
+	require.NotNil(t, r)
+}
+
+func TestNewStatusSink(t *testing.T) {
+	s, err := NewStatusSink("https://example.com/hook")
+	require.NoError(t, err)
+	require.IsType(t, &WebhookStatusSink{}, s)
+
+	s, err = NewStatusSink("/tmp/events.jsonl")
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/events.jsonl", s.(*FileStatusSink).path)
+
+	s, err = NewStatusSink("file:///tmp/events.jsonl")
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/events.jsonl", s.(*FileStatusSink).path)
+
+	_, err = NewStatusSink("https://")
+	require.Error(t, err)
+
+	_, err = NewStatusSink("")
+	require.Error(t, err)
+}
+
+func TestFileStatusSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.jsonl")
+	status := NewInstallStatus([]StatusSubscriber{NewSinkStatusReporter(NewFileStatusSink(path))}, NewMockPlatformLinkGenerator())
+
+	status.RecipesSelected([]types.OpenInstallationRecipe{{Name: "test-recipe"}})
+	status.RecipeInstalling(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "test-recipe"}})
+	status.RecipeFailed(RecipeStatusEvent{
+		Recipe:   types.OpenInstallationRecipe{Name: "test-recipe"},
+		Msg:      "execution failed",
+		TaskPath: []string{"default"},
+	})
+	status.InstallComplete(nil)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	events := []StatusSinkEvent{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e StatusSinkEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		events = append(events, e)
+	}
+
+	require.Equal(t, 4, len(events))
+	require.Equal(t, "recipesSelected", events[0].Event)
+	require.Equal(t, []string{"test-recipe"}, events[0].Recipes)
+	require.Equal(t, "recipeInstalling", events[1].Event)
+	require.Equal(t, "recipeFailed", events[2].Event)
+	require.Equal(t, RecipeStatusTypes.FAILED, events[2].Status)
+	require.Equal(t, "execution failed", events[2].Message)
+	require.Equal(t, []string{"default"}, events[2].TaskPath)
+	require.Equal(t, "installComplete", events[3].Event)
+	require.Equal(t, 1, len(events[3].RecipeStatuses))
+
+	for _, e := range events {
+		require.Equal(t, status.DocumentID, e.InstallID)
+	}
+}
+
+func TestWebhookStatusSink_Retries(t *testing.T) {
+	var mutex sync.Mutex
+	requests := 0
+	var received StatusSinkEvent
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	s := NewWebhookStatusSink(server.URL)
+	s.RetryDelaySec = 0
+
+	err := s.Send(StatusSinkEvent{Event: "recipeInstalled", Recipe: "test-recipe"})
+	require.NoError(t, err)
+	require.NoError(t, s.Flush(time.Second))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Equal(t, 2, requests)
+	require.Equal(t, "test-recipe", received.Recipe)
+}
+
+func TestWebhookStatusSink_GivesUp(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	s := NewWebhookStatusSink(server.URL)
+	s.RetryDelaySec = 0
+
+	require.NoError(t, s.Send(StatusSinkEvent{Event: "recipeInstalled"}))
+	require.NoError(t, s.Flush(time.Second))
+	require.Equal(t, s.MaxRetries, requests)
+}
+
+func TestWebhookStatusSink_HangingWebhook(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sink := NewWebhookStatusSink(server.URL)
+	status := NewInstallStatus([]StatusSubscriber{NewSinkStatusReporter(sink)}, NewPlatformLinkGenerator())
+
+	installed := make(chan struct{})
+	go func() {
+		status.RecipeInstalling(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "test-recipe"}})
+		status.RecipeInstalled(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "test-recipe"}})
+		close(installed)
+	}()
+
+	select {
+	case <-installed:
+	case <-time.After(time.Second):
+		t.Fatal("a hanging status webhook delayed RecipeInstalled")
+	}
+
+	require.Error(t, sink.Flush(10*time.Millisecond))
+}
+
+func TestWebhookStatusSink_SendsAfterInterrupt(t *testing.T) {
+	var mutex sync.Mutex
+	events := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e StatusSinkEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&e))
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, e.Event)
+	}))
+	defer server.Close()
+
+	signalCtx := utils.SignalCtx
+	defer func() { utils.SignalCtx = signalCtx }()
+
+	interrupted, cancel := context.WithCancel(context.Background())
+	cancel()
+	utils.SignalCtx = interrupted
+
+	status := NewInstallStatus([]StatusSubscriber{NewSinkStatusReporter(NewWebhookStatusSink(server.URL))}, NewPlatformLinkGenerator())
+	status.InstallCanceled()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Equal(t, []string{"installCanceled"}, events)
+}
+
+func TestWebhookStatusSink_DropsWhenFull(t *testing.T) {
+	s := &WebhookStatusSink{queue: make(chan webhookQueueItem, 1)}
+	s.start.Do(func() {})
+
+	require.NoError(t, s.Send(StatusSinkEvent{Event: "recipeInstalling"}))
+	require.Error(t, s.Send(StatusSinkEvent{Event: "recipeInstalled"}))
+}
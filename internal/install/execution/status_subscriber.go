@@ -30,6 +30,8 @@ type RecipeStatusEvent struct {
 	TaskPath                       []string
 	EntityGUID                     string
 	ValidationDurationMilliseconds int64
+	// Vars are the variables the recipe was executed with.
+	Vars types.RecipeVars
 }
 
 type ObservabilityPackStatusEvent struct {
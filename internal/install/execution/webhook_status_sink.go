@@ -0,0 +1,151 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// webhookQueueSize is the number of events a WebhookStatusSink holds while
+// they wait to be posted.
+var webhookQueueSize = 100
+
+// WebhookStatusSink posts each status event as JSON to a URL, retrying
+// requests that fail.  Events are posted in the background, in order, so that
+// a slow or unreachable webhook does not hold up the install.  Events are
+// dropped if too many are waiting to be posted.
+type WebhookStatusSink struct {
+	URL           string
+	MaxRetries    int
+	RetryDelaySec int
+	client        *http.Client
+	queue         chan webhookQueueItem
+	start         sync.Once
+}
+
+// webhookQueueItem is either an event to post, or a marker that is closed
+// once every event queued before it has been handled.
+type webhookQueueItem struct {
+	event   StatusSinkEvent
+	flushed chan struct{}
+}
+
+// NewWebhookStatusSink returns a sink that posts events to the given URL.
+func NewWebhookStatusSink(url string) *WebhookStatusSink {
+	return &WebhookStatusSink{
+		URL:           url,
+		MaxRetries:    3,
+		RetryDelaySec: 1,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		queue: make(chan webhookQueueItem, webhookQueueSize),
+	}
+}
+
+// Send queues an event to be posted.
+func (s *WebhookStatusSink) Send(event StatusSinkEvent) error {
+	s.start.Do(func() { go s.run() })
+
+	select {
+	case s.queue <- webhookQueueItem{event: event}:
+		return nil
+	default:
+		return fmt.Errorf("status webhook is not keeping up, dropped %s event", event.Event)
+	}
+}
+
+// Flush waits until every event sent so far has been posted, or has failed,
+// for at most the given time.
+func (s *WebhookStatusSink) Flush(timeout time.Duration) error {
+	s.start.Do(func() { go s.run() })
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	flushed := make(chan struct{})
+
+	select {
+	case s.queue <- webhookQueueItem{flushed: flushed}:
+	case <-timer.C:
+		return fmt.Errorf("timed out after %s waiting for status webhook events to be sent", timeout)
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("timed out after %s waiting for status webhook events to be sent", timeout)
+	}
+}
+
+func (s *WebhookStatusSink) run() {
+	for item := range s.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+
+		if err := s.deliver(item.event); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (s *WebhookStatusSink) deliver(event StatusSinkEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// Delivery is not tied to the interrupt signal, so that the installCanceled
+	// event is still posted when the install is interrupted.  Each request is
+	// bounded by the client's timeout, and retries by the flush timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), statusSinkFlushTimeout)
+	defer cancel()
+
+	r := utils.NewRetry(s.MaxRetries, s.RetryDelaySec, func() error {
+		return s.post(ctx, b)
+	})
+
+	if err = r.ExecWithRetries(ctx); err != nil {
+		return fmt.Errorf("could not send %s event to status webhook: %s", event.Event, err)
+	}
+
+	return nil
+}
+
+func (s *WebhookStatusSink) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Debugf("status webhook request failed: %s", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		log.Debugf("status webhook returned %s", resp.Status)
+		return fmt.Errorf("unexpected response %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// InstallPlan describes what an install would do, without doing it.
+type InstallPlan struct {
+	Recipes  []PlannedRecipe
+	Filtered []PlannedRecipe
+	Packs    []string
+}
+
+// PlannedRecipe is a recipe considered by the install plan, along with the
+// reason it was chosen or filtered out.
+type PlannedRecipe struct {
+	Name        string
+	DisplayName string
+	Reason      string
+	Vars        types.RecipeVars
+	VarsError   string
+}
+
+func (i *RecipeInstaller) plan(ctx context.Context, m *types.DiscoveryManifest, recipesForPlatform []types.OpenInstallationRecipe, recipesForInstall []types.OpenInstallationRecipe) (*InstallPlan, error) {
+	p := &InstallPlan{}
+
+	licenseKey, err := i.licenseKeyFetcher.FetchLicenseKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchFinder := recipes.NewRegexProcessMatchFinder()
+
+	for _, r := range recipesForInstall {
+		pr := PlannedRecipe{
+			Name:        r.Name,
+			DisplayName: r.DisplayName,
+			Reason:      i.chosenReason(ctx, matchFinder, m, r, recipesForInstall),
+		}
+
+		// Defaults are used for input variables, rather than prompting.
+		vars, varsErr := i.recipeVarPreparer.Prepare(*m, r, true, licenseKey)
+		if varsErr != nil {
+			pr.VarsError = varsErr.Error()
+		} else {
+			pr.Vars = execution.RedactSecretVars(vars, r.InputVars)
+		}
+
+		p.Recipes = append(p.Recipes, pr)
+	}
+
+	reasons := i.recipeFilterer.FilterReasons()
+	for _, r := range recipesForPlatform {
+		if reason, ok := reasons[r.Name]; ok && findRecipeInRecipes(r.Name, recipesForInstall) == nil {
+			p.Filtered = append(p.Filtered, PlannedRecipe{
+				Name:        r.Name,
+				DisplayName: r.DisplayName,
+				Reason:      reason,
+			})
+		}
+	}
+
+	packs, err := i.packsFetcher.FetchPacks(ctx, recipesForInstall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch observability packs: %s", err)
+	}
+
+	for _, pack := range i.answeredPacks(packs) {
+		p.Packs = append(p.Packs, pack.Name)
+	}
+
+	return p, nil
+}
+
+func (i *RecipeInstaller) chosenReason(ctx context.Context, f recipes.ProcessMatchFinder, m *types.DiscoveryManifest, r types.OpenInstallationRecipe, recipesForInstall []types.OpenInstallationRecipe) string {
+	dependents := []string{}
+	for _, other := range recipesForInstall {
+		for _, d := range other.Dependencies {
+			if d == r.Name {
+				dependents = append(dependents, other.Name)
+			}
+		}
+	}
+
+	if len(dependents) > 0 {
+		return fmt.Sprintf("required by %s", strings.Join(dependents, ", "))
+	}
+
+	if i.RecipesProvided() {
+		return "requested by name or path"
+	}
+
+	if matches := f.FindMatches(ctx, m.DiscoveredProcesses, r); len(matches) > 0 {
+		name, _ := matches[0].Name()
+		return fmt.Sprintf("matched running process %s (pattern %q)", name, matches[0].MatchingPattern)
+	}
+
+	if matches := recipes.FindProcessRuleMatches(r, m); len(matches) > 0 {
+		name, _ := matches[0].Name()
+		return fmt.Sprintf("matched running process %s (%s)", name, matches[0].MatchingPattern)
+	}
+
+	if matches := recipes.FindPackageMatches(r, m); len(matches) > 0 {
+		return fmt.Sprintf("matched installed package %s", matches[0].Name)
+	}
+
+	if matches := recipes.FindPortMatches(r, m); len(matches) > 0 {
+		return fmt.Sprintf("matched listening port %d", matches[0].Port)
+	}
+
+	if r.PreInstall.RequireAtDiscovery != "" {
+		return "discovery script succeeded on this host"
+	}
+
+	return "available for this host"
+}
+
+// Print writes a human readable form of the plan.
+func (p *InstallPlan) Print(w io.Writer) {
+	fmt.Fprintf(w, "\nInstall plan (dry run, nothing has been executed)\n")
+
+	fmt.Fprintf(w, "\nRecipes that would be installed, in order:\n")
+	if len(p.Recipes) == 0 {
+		fmt.Fprintf(w, "  (none)\n")
+	}
+	for n, r := range p.Recipes {
+		fmt.Fprintf(w, "  %d. %s: %s\n", n+1, r.Name, r.Reason)
+
+		if r.VarsError != "" {
+			fmt.Fprintf(w, "     variables could not be resolved: %s\n", r.VarsError)
+			continue
+		}
+
+		names := make([]string, 0, len(r.Vars))
+		for k := range r.Vars {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		for _, k := range names {
+			fmt.Fprintf(w, "     %s=%s\n", k, r.Vars[k])
+		}
+	}
+
+	fmt.Fprintf(w, "\nRecipes that would not be installed:\n")
+	if len(p.Filtered) == 0 {
+		fmt.Fprintf(w, "  (none)\n")
+	}
+	for _, r := range p.Filtered {
+		fmt.Fprintf(w, "  - %s: %s\n", r.Name, r.Reason)
+	}
+
+	fmt.Fprintf(w, "\nObservability packs that would be created:\n")
+	if len(p.Packs) == 0 {
+		fmt.Fprintf(w, "  (none)\n")
+	}
+	for _, pack := range p.Packs {
+		fmt.Fprintf(w, "  - %s\n", pack)
+	}
+}
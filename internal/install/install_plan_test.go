@@ -0,0 +1,141 @@
+// +build unit
+
+package install
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/credentials"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/packs"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/validation"
+)
+
+var dryRunRecipes = []types.OpenInstallationRecipe{
+	{
+		Name:           types.InfraAgentRecipeName,
+		DisplayName:    types.InfraAgentRecipeName,
+		ValidationNRQL: "testNrql",
+	},
+	{
+		Name:           testRecipeName,
+		DisplayName:    testRecipeName,
+		ValidationNRQL: "testNrql",
+		Dependencies:   []string{types.InfraAgentRecipeName},
+		InputVars: []types.OpenInstallationRecipeInputVariable{
+			{Name: "DB_PASSWD", Default: "hunter2", Secret: true},
+			{Name: "DB_PORT", Default: "5432"},
+		},
+	},
+	{
+		Name:           anotherTestRecipeName,
+		DisplayName:    anotherTestRecipeName,
+		ValidationNRQL: "testNrql",
+		ProcessMatch:   []string{"not-running"},
+	},
+}
+
+func TestInstall_DryRun(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{APIKey: "testApiKey"})
+	ic := types.InstallerContext{DryRun: true}
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = dryRunRecipes
+
+	rv := validation.NewMockRecipeValidator()
+	packInstaller := packs.NewMockPacksInstaller(status)
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, rv, ff, status, p, pi, lkf, cv, rvp, rf, pf, packInstaller}
+	err := i.Install()
+	require.NoError(t, err)
+	require.Equal(t, 0, rv.ValidateCallCount)
+	require.Equal(t, 0, statusReporters[0].(*execution.MockStatusReporter).RecipeInstallingCallCount)
+	require.Equal(t, 0, packInstaller.InstallCallCount)
+}
+
+func TestInstall_ExplainDepsDoesNotInstall(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{APIKey: "testApiKey"})
+	ic := types.InstallerContext{ExplainDeps: true}
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = dryRunRecipes
+
+	rv := validation.NewMockRecipeValidator()
+	packInstaller := packs.NewMockPacksInstaller(status)
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, rv, ff, status, p, pi, lkf, cv, rvp, rf, pf, packInstaller}
+	err := i.Install()
+	require.NoError(t, err)
+	require.Equal(t, 0, rv.ValidateCallCount)
+	require.Equal(t, 0, statusReporters[0].(*execution.MockStatusReporter).RecipeInstallingCallCount)
+	require.Equal(t, 0, packInstaller.InstallCallCount)
+}
+
+func TestInstall_ExplainMatchDoesNotInstall(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{APIKey: "testApiKey"})
+	ic := types.InstallerContext{ExplainMatch: true}
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = dryRunRecipes
+
+	rv := validation.NewMockRecipeValidator()
+	packInstaller := packs.NewMockPacksInstaller(status)
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, rv, ff, status, p, pi, lkf, cv, rvp, rf, pf, packInstaller}
+	err := i.Install()
+	require.NoError(t, err)
+	require.Equal(t, 0, rv.ValidateCallCount)
+	require.Equal(t, 0, statusReporters[0].(*execution.MockStatusReporter).RecipeInstallingCallCount)
+	require.Equal(t, 0, packInstaller.InstallCallCount)
+}
+
+func TestPlan(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{APIKey: "testApiKey"})
+	ic := types.InstallerContext{DryRun: true}
+	status = execution.NewInstallStatus([]execution.StatusSubscriber{}, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	pf := packs.NewMockPacksFetcher(status)
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, v, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+
+	m := &types.DiscoveryManifest{}
+	recipesForInstall := rf.RunFilterAll(context.Background(), dryRunRecipes, m)
+
+	plan, err := i.plan(context.Background(), m, dryRunRecipes, recipesForInstall)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(plan.Recipes))
+	require.Equal(t, types.InfraAgentRecipeName, plan.Recipes[0].Name)
+	require.Equal(t, "required by "+testRecipeName, plan.Recipes[0].Reason)
+	require.Equal(t, "available for this host", plan.Recipes[1].Reason)
+
+	vars := plan.Recipes[1].Vars
+	require.Equal(t, execution.RedactedValue, vars["DB_PASSWD"])
+	require.Equal(t, execution.RedactedValue, vars["NEW_RELIC_LICENSE_KEY"])
+	require.Equal(t, "5432", vars["DB_PORT"])
+
+	require.Equal(t, 1, len(plan.Filtered))
+	require.Equal(t, anotherTestRecipeName, plan.Filtered[0].Name)
+	require.Contains(t, plan.Filtered[0].Reason, "no running process")
+
+	require.Equal(t, []string{"test-pack"}, plan.Packs)
+
+	var b bytes.Buffer
+	plan.Print(&b)
+	require.Contains(t, b.String(), "DB_PASSWD="+execution.RedactedValue)
+	require.NotContains(t, b.String(), "hunter2")
+	require.NotContains(t, b.String(), "mockLicenseKey")
+	require.NotContains(t, b.String(), "testApiKey")
+}
@@ -34,6 +34,7 @@ type RecipeFileFetcher interface {
 type RecipeFilterRunner interface {
 	RunFilterAll(ctx context.Context, r []types.OpenInstallationRecipe, m *types.DiscoveryManifest) []types.OpenInstallationRecipe
 	EnsureDoesNotFilter(ctx context.Context, r []types.OpenInstallationRecipe, m *types.DiscoveryManifest) error
+	FilterReasons() map[string]string
 }
 
 // RecipeValidator validates installation of a recipe.
@@ -2,15 +2,14 @@ package packs
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"regexp"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/newrelic/newrelic-cli/internal/credentials"
+	"github.com/newrelic/newrelic-cli/internal/dashboard"
 	"github.com/newrelic/newrelic-cli/internal/install/execution"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 	"github.com/newrelic/newrelic-cli/internal/install/ux"
@@ -133,16 +132,13 @@ func getJSONfromURL(url string) ([]byte, error) {
 }
 
 func transformDashboardJSON(body []byte, accountID int) (dashboards.DashboardInput, error) {
-	dashboard := dashboards.DashboardInput{}
-	re := regexp.MustCompile("\"accountId\": 0")
-	dash := re.ReplaceAllString(string(body), fmt.Sprintf("\"accountId\": %d", accountID))
-
-	if err := json.Unmarshal([]byte(dash), &dashboard); err != nil {
-		return dashboard, err
+	d, err := dashboard.TransformDashboardJSON(body, accountID)
+	if err != nil {
+		return d, err
 	}
 
-	dashboard.Permissions = entities.DashboardPermissionsTypes.PUBLIC_READ_WRITE
-	log.Tracef("Dashboard definition: %+v", dashboard)
+	d.Permissions = entities.DashboardPermissionsTypes.PUBLIC_READ_WRITE
+	log.Tracef("Dashboard definition: %+v", d)
 
-	return dashboard, nil
+	return d, nil
 }
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -43,38 +45,78 @@ type RecipeInstaller struct {
 	packsInstaller    PacksInstaller
 }
 
+type recipeResult struct {
+	recipe types.OpenInstallationRecipe
+	err    error
+}
+
 type RecipeInstallFunc func(ctx context.Context, i *RecipeInstaller, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, recipes []types.OpenInstallationRecipe) error
 
 var (
 	recipeInstallFuncs map[string]RecipeInstallFunc = map[string]RecipeInstallFunc{
 		"logs-integration": installLogging,
 	}
-)
-
-func NewRecipeInstaller(ic types.InstallerContext, nrClient *newrelic.NewRelic) *RecipeInstaller {
-	checkNetwork(nrClient)
 
-	var recipeFetcher recipes.RecipeFetcher
+	// prepareMutex serializes the parts of a recipe install that may prompt, or
+	// that read and write the shared recipe variables, so that recipes can be
+	// installed concurrently.
+	prepareMutex sync.Mutex
+)
 
+// newRecipeFetcher returns the fetcher for the recipe source chosen by the
+// installer context: a local directory, a configured git or tarball source, or
+// the New Relic service.
+func newRecipeFetcher(ic types.InstallerContext, nrClient *newrelic.NewRelic) recipes.RecipeFetcher {
 	if ic.LocalRecipes != "" {
-		recipeFetcher = &recipes.LocalRecipeFetcher{
+		return &recipes.LocalRecipeFetcher{
 			Path: ic.LocalRecipes,
 		}
-	} else {
-		recipeFetcher = recipes.NewServiceRecipeFetcher(&nrClient.NerdGraph)
 	}
 
+	if ic.RecipeSource.URL != "" {
+		return recipes.NewRemoteRecipeFetcher(ic.RecipeSource)
+	}
+
+	return recipes.NewServiceRecipeFetcher(&nrClient.NerdGraph)
+}
+
+func NewRecipeInstaller(ic types.InstallerContext, nrClient *newrelic.NewRelic) *RecipeInstaller {
+	checkNetwork(nrClient)
+
+	recipeFetcher := newRecipeFetcher(ic, nrClient)
 	mv := discovery.NewManifestValidator()
 	ff := recipes.NewRecipeFileFetcher()
-	ers := []execution.StatusSubscriber{
-		execution.NewNerdStorageStatusReporter(&nrClient.NerdStorage),
-		execution.NewTerminalStatusReporter(),
+	ers := []execution.StatusSubscriber{}
+	if !ic.DryRun {
+		ers = append(ers,
+			execution.NewNerdStorageStatusReporter(&nrClient.NerdStorage),
+			execution.NewTerminalStatusReporter(),
+			execution.NewLedgerStatusReporter(execution.NewLedger(execution.DefaultLedgerPath())),
+		)
+
+		if ic.ReportFile != "" {
+			ers = append(ers, execution.NewReportFileStatusReporter(ic.ReportFile))
+		}
+
+		for _, spec := range ic.StatusSinks {
+			sink, err := execution.NewStatusSink(spec)
+			if err != nil {
+				log.Warnf("Ignoring status sink: %s", err)
+				continue
+			}
+
+			ers = append(ers, execution.NewSinkStatusReporter(sink))
+		}
 	}
 	lkf := NewServiceLicenseKeyFetcher(&nrClient.NerdGraph)
 	slg := execution.NewPlatformLinkGenerator()
 	statusRollup := execution.NewInstallStatus(ers, slg)
 
-	d := discovery.NewPSUtilDiscoverer()
+	var d Discoverer = discovery.NewPSUtilDiscoverer()
+	if ic.DiscoveryManifest != "" {
+		d = discovery.NewManifestFileDiscoverer(ic.DiscoveryManifest)
+	}
+
 	gff := discovery.NewGlobFileFilterer()
 	re := execution.NewGoTaskRecipeExecutor()
 	v := validation.NewPollingRecipeValidator(&nrClient.Nrdb)
@@ -82,6 +124,9 @@ func NewRecipeInstaller(ic types.InstallerContext, nrClient *newrelic.NewRelic)
 	p := ux.NewPromptUIPrompter()
 	pi := ux.NewPlainProgress()
 	rvp := execution.NewRecipeVarProvider()
+	rvp.Answers = ic.Answers
+	rvp.DownloadURL = ic.DownloadURL
+	rvp.MirrorPolicy = execution.NewDownloadMirrorPolicy(ic.DownloadMirror)
 	rf := recipes.NewRecipeFilterRunner(ic, statusRollup)
 	spf := packs.NewServicePacksFetcher(&nrClient.NerdGraph, statusRollup)
 	cpi := packs.NewServicePacksInstaller(nrClient, statusRollup)
@@ -193,6 +238,16 @@ func (i *RecipeInstaller) install(ctx context.Context) error {
 	}
 	log.Tracef("recipes found for platform: %v\n", recipesForPlatform)
 
+	if i.ExplainMatch {
+		recipes.ExplainMatches(ctx, os.Stdout, recipesForPlatform, m)
+
+		// Explaining stops before anything is installed, unless combined with a
+		// dependency explanation or a dry run.
+		if !i.ExplainDeps && !i.DryRun {
+			return nil
+		}
+	}
+
 	var recipesForInstall []types.OpenInstallationRecipe
 	if i.RecipesProvided() {
 		recipesForInstall, err = i.fetchProvidedRecipe(m, recipesForPlatform)
@@ -226,8 +281,38 @@ func (i *RecipeInstaller) install(ctx context.Context) error {
 
 	i.status.RecipesSelected(recipesForInstall)
 
-	dependencies := resolveDependencies(recipesForInstall, recipesForPlatform)
-	recipesForInstall = addIfMissing(recipesForInstall, dependencies)
+	graph := recipes.NewDependencyGraph(recipesForPlatform)
+	if i.ExplainDeps {
+		if err = graph.Explain(os.Stdout, recipesForInstall); err != nil {
+			return err
+		}
+
+		// Explaining stops before anything is installed, unless combined with a dry run.
+		if !i.DryRun {
+			return nil
+		}
+	}
+
+	recipesForInstall, err = graph.Resolve(recipesForInstall)
+	if err != nil {
+		return err
+	}
+
+	// Answers are validated up front, so that a bad answers file fails before
+	// anything has been installed.
+	if err = i.Answers.Validate(recipesForInstall); err != nil {
+		return err
+	}
+
+	if i.DryRun {
+		p, planErr := i.plan(ctx, m, recipesForPlatform, recipesForInstall)
+		if planErr != nil {
+			return planErr
+		}
+
+		p.Print(os.Stdout)
+		return nil
+	}
 
 	if err = i.installRecipes(ctx, m, recipesForInstall); err != nil {
 		return err
@@ -249,6 +334,8 @@ func (i *RecipeInstaller) fetchAndInstallPacks(ctx context.Context, recipesForIn
 	}
 	log.Debugf("Fetched Packs: %d", len(packs))
 
+	packs = i.answeredPacks(packs)
+
 	if len(packs) > 0 {
 		if err := i.packsInstaller.Install(ctx, packs); err != nil {
 			// nolint: golint
@@ -271,38 +358,76 @@ func (i *RecipeInstaller) assertDiscoveryValid(ctx context.Context, m *types.Dis
 func (i *RecipeInstaller) installRecipes(ctx context.Context, m *types.DiscoveryManifest, recipes []types.OpenInstallationRecipe) error {
 	log.WithFields(log.Fields{
 		"recipe_count": len(recipes),
+		"concurrency":  i.concurrency(),
 	}).Debug("installing recipes")
-	var lastError error
 
-	for _, r := range recipes {
-		var err error
+	var lastError, abortError error
+	started := map[string]bool{}
+	finished := map[string]bool{}
+	succeeded := map[string]bool{}
+	results := make(chan recipeResult)
+	running := 0
+
+	for {
+		// Start the recipes whose dependencies have installed, in order, up to
+		// the concurrency limit.  Recipes with a dependency that did not
+		// install are skipped, which in turn skips their own dependents.
+		for n := 0; n < len(recipes) && abortError == nil && running < i.concurrency(); n++ {
+			r := recipes[n]
+			if started[r.Name] {
+				continue
+			}
 
-		log.WithFields(log.Fields{
-			"name": r.Name,
-		}).Debug("installing recipe")
+			ready, failed := i.recipeReady(r, recipes, finished, succeeded)
+			if failed != "" {
+				started[r.Name] = true
+				finished[r.Name] = true
+				i.skipForDependency(r, failed)
+				n = -1
+				continue
+			}
 
-		if f, ok := recipeInstallFuncs[r.Name]; ok {
-			err = f(ctx, i, m, &r, recipes)
-		} else {
-			_, err = i.executeAndValidateWithProgress(ctx, m, &r)
+			if !ready {
+				continue
+			}
+
+			started[r.Name] = true
+			running++
+
+			go func(r types.OpenInstallationRecipe) {
+				err := i.installRecipe(i.recipeContext(ctx, r), m, &r, recipes)
+				results <- recipeResult{recipe: r, err: err}
+			}(r)
 		}
 
-		if err != nil {
-			if err == types.ErrInterrupt {
-				return err
-			}
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+		finished[result.recipe.Name] = true
+		succeeded[result.recipe.Name] = result.err == nil
 
-			if r.Name == types.InfraAgentRecipeName || r.Name == types.LoggingRecipeName || i.RecipesProvided() {
-				return err
+		if err := result.err; err != nil {
+			if i.shouldAbort(result.recipe, err) {
+				if abortError == nil {
+					abortError = err
+				}
+				continue
 			}
 
 			lastError = err
 
-			log.Debugf("Failed while executing and validating with progress for recipe name %s, detail:%s", r.Name, err)
+			log.Debugf("Failed while executing and validating with progress for recipe name %s, detail:%s", result.recipe.Name, err)
 			log.Warn(err)
-			log.Warn(i.failMessage(r.DisplayName))
+			log.Warn(i.failMessage(result.recipe.DisplayName))
 		}
-		log.Debugf("Done executing and validating with progress for recipe name %s.", r.Name)
+		log.Debugf("Done executing and validating with progress for recipe name %s.", result.recipe.Name)
+	}
+
+	if abortError != nil {
+		return abortError
 	}
 
 	if lastError != nil {
@@ -317,6 +442,85 @@ func (i *RecipeInstaller) installRecipes(ctx context.Context, m *types.Discovery
 	return nil
 }
 
+func (i *RecipeInstaller) installRecipe(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, recipes []types.OpenInstallationRecipe) error {
+	log.WithFields(log.Fields{
+		"name": r.Name,
+	}).Debug("installing recipe")
+
+	if f, ok := recipeInstallFuncs[r.Name]; ok {
+		return f(ctx, i, m, r, recipes)
+	}
+
+	_, err := i.executeAndValidateWithProgress(ctx, m, r)
+	return err
+}
+
+// shouldAbort returns whether a recipe failure should stop any further recipes
+// from being installed.
+func (i *RecipeInstaller) shouldAbort(r types.OpenInstallationRecipe, err error) bool {
+	if err == types.ErrInterrupt {
+		return true
+	}
+
+	return r.Name == types.InfraAgentRecipeName || r.Name == types.LoggingRecipeName || i.RecipesProvided()
+}
+
+// recipeReady returns whether the dependencies of a recipe that are part of
+// this install have installed, or else the first of them that finished
+// without installing.  When installing concurrently, every recipe also waits
+// for the infrastructure agent, which is required for additional
+// instrumentation.
+func (i *RecipeInstaller) recipeReady(r types.OpenInstallationRecipe, recipes []types.OpenInstallationRecipe, finished, succeeded map[string]bool) (bool, string) {
+	dependencies := r.Dependencies
+	if i.concurrency() > 1 && r.Name != types.InfraAgentRecipeName {
+		dependencies = append([]string{types.InfraAgentRecipeName}, dependencies...)
+	}
+
+	ready := true
+	for _, d := range dependencies {
+		if findRecipeInRecipes(d, recipes) == nil {
+			continue
+		}
+
+		if finished[d] && !succeeded[d] {
+			return false, d
+		}
+
+		if !finished[d] {
+			ready = false
+		}
+	}
+
+	return ready, ""
+}
+
+// skipForDependency reports a recipe as skipped because one of its
+// dependencies did not install.
+func (i *RecipeInstaller) skipForDependency(r types.OpenInstallationRecipe, dependency string) {
+	msg := fmt.Sprintf("skipping %s, its dependency %s was not installed", r.Name, dependency)
+	log.Warn(msg)
+
+	i.status.RecipeSkipped(execution.RecipeStatusEvent{Recipe: r, Msg: msg})
+}
+
+// recipeContext prefixes the output of each recipe when installing
+// concurrently, so that interleaved output can be told apart.
+func (i *RecipeInstaller) recipeContext(ctx context.Context, r types.OpenInstallationRecipe) context.Context {
+	if i.concurrency() > 1 {
+		return execution.WithOutputPrefix(ctx, fmt.Sprintf("[%s] ", r.Name))
+	}
+
+	return ctx
+}
+
+func (i *RecipeInstaller) concurrency() int {
+	if i.Concurrency < 1 {
+		return 1
+	}
+
+	return i.Concurrency
+}
+
 func (i *RecipeInstaller) discover(ctx context.Context) (*types.DiscoveryManifest, error) {
 	log.Debug("discovering system information")
 
@@ -350,6 +554,7 @@ func (i *RecipeInstaller) executeAndValidate(ctx context.Context, m *types.Disco
 		se := execution.RecipeStatusEvent{
 			Recipe: *r,
 			Msg:    msg,
+			Vars:   vars,
 		}
 
 		if e, ok := err.(types.GoTaskError); ok {
@@ -376,7 +581,13 @@ func (i *RecipeInstaller) executeAndValidate(ctx context.Context, m *types.Disco
 				Recipe:                         *r,
 				Msg:                            msg,
 				ValidationDurationMilliseconds: validationDurationMilliseconds,
+				Vars:                           vars,
 			})
+
+			if i.UninstallOnFailure {
+				i.rollback(ctx, r, vars)
+			}
+
 			return "", errors.New(msg)
 		}
 	} else {
@@ -388,26 +599,36 @@ func (i *RecipeInstaller) executeAndValidate(ctx context.Context, m *types.Disco
 		Recipe:                         *r,
 		EntityGUID:                     entityGUID,
 		ValidationDurationMilliseconds: validationDurationMilliseconds,
+		Vars:                           vars,
 	})
 
 	return entityGUID, nil
 }
 
+// rollback runs the uninstall task of a recipe that failed validation, so that
+// the host is not left with a half working install.
+func (i *RecipeInstaller) rollback(ctx context.Context, r *types.OpenInstallationRecipe, vars types.RecipeVars) {
+	if r.Uninstall == "" {
+		log.Debugf("recipe %s has no uninstall task, skipping rollback", r.Name)
+		return
+	}
+
+	log.Infof("Rolling back %s after failed validation", r.Name)
+
+	if err := i.recipeExecutor.Execute(ctx, r.UninstallRecipe(), vars); err != nil {
+		log.Errorf("Could not roll back %s: %s", r.Name, err)
+		return
+	}
+
+	log.Infof("Rolled back %s", r.Name)
+}
+
 func (i *RecipeInstaller) executeAndValidateWithProgress(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe) (string, error) {
 	msg := fmt.Sprintf("Installing %s", r.Name)
 	i.progressIndicator.Start(msg)
 	defer func() { i.progressIndicator.Stop() }()
 
-	if r.PreInstallMessage() != "" {
-		fmt.Println(r.PreInstallMessage())
-	}
-
-	licenseKey, err := i.licenseKeyFetcher.FetchLicenseKey(ctx)
-	if err != nil {
-		return "", err
-	}
-
-	vars, err := i.recipeVarPreparer.Prepare(*m, *r, i.AssumeYes, licenseKey)
+	vars, err := i.prepareRecipe(ctx, m, r)
 	if err != nil {
 		return "", err
 	}
@@ -426,6 +647,22 @@ func (i *RecipeInstaller) executeAndValidateWithProgress(ctx context.Context, m
 	return entityGUID, nil
 }
 
+func (i *RecipeInstaller) prepareRecipe(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe) (types.RecipeVars, error) {
+	prepareMutex.Lock()
+	defer prepareMutex.Unlock()
+
+	if r.PreInstallMessage() != "" {
+		fmt.Println(r.PreInstallMessage())
+	}
+
+	licenseKey, err := i.licenseKeyFetcher.FetchLicenseKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.recipeVarPreparer.Prepare(*m, *r, i.AssumeYes, licenseKey)
+}
+
 func (i *RecipeInstaller) failMessage(componentName string) error {
 	searchURL := "https://docs.newrelic.com/docs/using-new-relic/cross-product-functions/troubleshooting/not-seeing-data/"
 
@@ -499,7 +736,11 @@ func (i *RecipeInstaller) promptUserSelect(recipes []types.OpenInstallationRecip
 		return []types.OpenInstallationRecipe{}, []types.OpenInstallationRecipe{}, nil
 	}
 
-	if i.AssumeYes {
+	if i.Answers != nil {
+		return i.answeredSelect(recipes)
+	}
+
+	if i.AssumeYes || i.DryRun {
 		return recipes, []types.OpenInstallationRecipe{}, nil
 	}
 
@@ -535,51 +776,56 @@ func (i *RecipeInstaller) promptUserSelect(recipes []types.OpenInstallationRecip
 	return selectedRecipes, unselectedRecipes, nil
 }
 
-func findRecipeInRecipes(name string, recipes []types.OpenInstallationRecipe) *types.OpenInstallationRecipe {
+func (i *RecipeInstaller) answeredSelect(recipes []types.OpenInstallationRecipe) ([]types.OpenInstallationRecipe, []types.OpenInstallationRecipe, error) {
+	var selectedRecipes, unselectedRecipes []types.OpenInstallationRecipe
+
 	for _, r := range recipes {
-		if r.Name == name {
-			return &r
+		if i.Answers.IncludesRecipe(r) || r.Name == types.InfraAgentRecipeName {
+			selectedRecipes = append(selectedRecipes, r)
+		} else {
+			unselectedRecipes = append(unselectedRecipes, r)
 		}
 	}
 
-	return nil
-}
-
-// This is a naive implementation that only resolves dependencies one level deep.
-func resolveDependencies(recipes []types.OpenInstallationRecipe, recipesForPlatform []types.OpenInstallationRecipe) []types.OpenInstallationRecipe {
-	var results []types.OpenInstallationRecipe
-
-	for _, r := range recipes {
-		if len(r.Dependencies) > 0 {
-			for _, n := range r.Dependencies {
-				d := findRecipeInRecipes(n, recipesForPlatform)
-				if d != nil {
-					results = append(results, *d)
-				}
+	for _, n := range i.Answers.Recipes {
+		found := false
+		for _, r := range recipes {
+			if strings.EqualFold(n, r.Name) || strings.EqualFold(n, r.DisplayName) {
+				found = true
+				break
 			}
 		}
+
+		if !found {
+			log.Warnf("Recipe %s from the answers file is not recommended for this host and will not be installed.", n)
+		}
 	}
 
-	return results
+	return selectedRecipes, unselectedRecipes, nil
 }
 
-// This is a naive implementation that only resolves dependencies one level deep.
-func addIfMissing(recipes []types.OpenInstallationRecipe, dependencies []types.OpenInstallationRecipe) []types.OpenInstallationRecipe {
-	var results []types.OpenInstallationRecipe
+func (i *RecipeInstaller) answeredPacks(packs []types.OpenInstallationObservabilityPack) []types.OpenInstallationObservabilityPack {
+	var results []types.OpenInstallationObservabilityPack
 
-	for _, d := range dependencies {
-		if found := findRecipeInRecipes(d.Name, results); found == nil {
-			results = append(results, d)
+	for _, p := range packs {
+		if i.Answers.IncludesPack(p.Name) {
+			results = append(results, p)
+		} else {
+			log.Debugf("skipping observability pack %s, not selected in the answers file", p.Name)
 		}
 	}
 
+	return results
+}
+
+func findRecipeInRecipes(name string, recipes []types.OpenInstallationRecipe) *types.OpenInstallationRecipe {
 	for _, r := range recipes {
-		if found := findRecipeInRecipes(r.Name, results); found == nil {
-			results = append(results, r)
+		if r.Name == name {
+			return &r
 		}
 	}
 
-	return results
+	return nil
 }
 
 func checkNetwork(nrClient *newrelic.NewRelic) {
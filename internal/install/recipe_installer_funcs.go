@@ -12,6 +12,18 @@ import (
 )
 
 func installLogging(ctx context.Context, i *RecipeInstaller, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, recipes []types.OpenInstallationRecipe) error {
+	if err := i.selectLogFiles(r, recipes); err != nil {
+		return err
+	}
+
+	_, err := i.executeAndValidateWithProgress(ctx, m, r)
+	return err
+}
+
+func (i *RecipeInstaller) selectLogFiles(r *types.OpenInstallationRecipe, recipes []types.OpenInstallationRecipe) error {
+	prepareMutex.Lock()
+	defer prepareMutex.Unlock()
+
 	log.WithFields(log.Fields{
 		"recipe_count": len(recipes),
 	}).Debug("filtering log matches")
@@ -54,8 +66,7 @@ func installLogging(ctx context.Context, i *RecipeInstaller, m *types.DiscoveryM
 		"NR_DISCOVERED_LOG_FILES": discoveredLogFilesString,
 	}).Debug("discovered log files")
 
-	_, err = i.executeAndValidateWithProgress(ctx, m, r)
-	return err
+	return nil
 }
 
 func (i *RecipeInstaller) userAccepts(msg string) (bool, error) {
@@ -72,6 +83,10 @@ func (i *RecipeInstaller) userAccepts(msg string) (bool, error) {
 }
 
 func (i *RecipeInstaller) userAcceptsLogFile(match types.OpenInstallationLogMatch) (bool, error) {
+	if i.Answers != nil && i.Answers.AcceptLogFiles != nil {
+		return *i.Answers.AcceptLogFiles, nil
+	}
+
 	msg := fmt.Sprintf("Files have been found at the following pattern: %s Do you want to watch them?", match.File)
 	return i.userAccepts(msg)
 }
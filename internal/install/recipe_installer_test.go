@@ -3,11 +3,14 @@
 package install
 
 import (
+	"context"
 	"errors"
 	"net/url"
 	"os"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -304,6 +307,68 @@ func TestInstall_RecipeFailed(t *testing.T) {
 	require.Equal(t, 1, statusReporters[0].(*execution.MockStatusReporter).RecipeFailedCallCount)
 }
 
+func TestInstall_UninstallOnFailure(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{AccountID: 12345})
+	ic := types.InstallerContext{
+		UninstallOnFailure: true,
+	}
+
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = []types.OpenInstallationRecipe{
+		{
+			Name:           types.InfraAgentRecipeName,
+			DisplayName:    types.InfraAgentRecipeName,
+			ValidationNRQL: "testNrql",
+			Install:        "install",
+			Uninstall:      "uninstall",
+		},
+	}
+
+	rv := validation.NewMockRecipeValidator()
+	rv.ValidateErr = errors.New("validationErr")
+	re := &recordingExecutor{}
+
+	i := RecipeInstaller{ic, d, l, mv, f, re, rv, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+	err := i.Install()
+	require.Error(t, err)
+	require.Equal(t, 2, len(re.executed))
+	require.Equal(t, "install", re.executed[0].Install)
+	require.Equal(t, "uninstall", re.executed[1].Install)
+}
+
+func TestInstall_NoUninstallOnFailureByDefault(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{AccountID: 12345})
+	ic := types.InstallerContext{}
+
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = []types.OpenInstallationRecipe{
+		{
+			Name:           types.InfraAgentRecipeName,
+			DisplayName:    types.InfraAgentRecipeName,
+			ValidationNRQL: "testNrql",
+			Install:        "install",
+			Uninstall:      "uninstall",
+		},
+	}
+
+	rv := validation.NewMockRecipeValidator()
+	rv.ValidateErr = errors.New("validationErr")
+	re := &recordingExecutor{}
+
+	i := RecipeInstaller{ic, d, l, mv, f, re, rv, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+	err := i.Install()
+	require.Error(t, err)
+	require.Equal(t, 1, len(re.executed))
+}
+
 func TestInstall_NonInfraRecipeFailed(t *testing.T) {
 	credentials.SetDefaultProfile(credentials.Profile{AccountID: 12345})
 	ic := types.InstallerContext{}
@@ -735,6 +800,114 @@ func TestInstall_RecipeSkipped_AssumeYes(t *testing.T) {
 	require.Equal(t, 3, statusReporters[0].(*execution.MockStatusReporter).RecipeInstalledCallCount)
 }
 
+func TestInstall_Answers_SelectsRecipes(t *testing.T) {
+	ic := types.InstallerContext{
+		AssumeYes: true,
+		Answers: &types.Answers{
+			Recipes: []string{testRecipeName},
+		},
+	}
+
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = []types.OpenInstallationRecipe{
+		{
+			Name:        types.InfraAgentRecipeName,
+			DisplayName: "Infra Recipe",
+		},
+		{
+			Name:           testRecipeName,
+			DisplayName:    "test displayName",
+			ValidationNRQL: "testNrql",
+		},
+		{
+			Name:           anotherTestRecipeName,
+			DisplayName:    "another displayName",
+			ValidationNRQL: "testNrql",
+		},
+	}
+
+	mp := &ux.MockPrompter{
+		PromptMultiSelectErr: errors.New("prompted"),
+	}
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, v, ff, status, mp, pi, lkf, cv, rvp, rf, pf, cpi}
+	err := i.Install()
+	require.NoError(t, err)
+	require.Equal(t, 1, statusReporters[0].(*execution.MockStatusReporter).RecipeSkippedCallCount)
+	require.Equal(t, 2, statusReporters[0].(*execution.MockStatusReporter).RecipeInstalledCallCount)
+	require.Equal(t, 1, statusReporters[0].(*execution.MockStatusReporter).ReportInstalled[testRecipeName])
+	require.Equal(t, 1, statusReporters[0].(*execution.MockStatusReporter).ReportInstalled[types.InfraAgentRecipeName])
+}
+
+func TestInstall_Answers_InvalidVariable(t *testing.T) {
+	ic := types.InstallerContext{
+		AssumeYes: true,
+		Answers: &types.Answers{
+			Variables: map[string]map[string]string{
+				testRecipeName: {"NOT_AN_INPUT_VAR": "value"},
+			},
+		},
+	}
+
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = []types.OpenInstallationRecipe{
+		{
+			Name:        types.InfraAgentRecipeName,
+			DisplayName: "Infra Recipe",
+		},
+		{
+			Name:           testRecipeName,
+			DisplayName:    "test displayName",
+			ValidationNRQL: "testNrql",
+		},
+	}
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, v, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+	err := i.Install()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NOT_AN_INPUT_VAR")
+	require.Equal(t, 0, statusReporters[0].(*execution.MockStatusReporter).RecipeInstallingCallCount)
+}
+
+func TestUserAcceptsLogFile_Answers(t *testing.T) {
+	accept := false
+	ic := types.InstallerContext{
+		Answers: &types.Answers{
+			AcceptLogFiles: &accept,
+		},
+	}
+
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	mp := &ux.MockPrompter{
+		PromptYesNoVal: true,
+	}
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, v, ff, status, mp, pi, lkf, cv, rvp, rf, pf, cpi}
+	ok, err := i.userAcceptsLogFile(types.OpenInstallationLogMatch{File: "/var/log/test.log"})
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, 0, mp.PromptYesNoCallCount)
+}
+
+func TestAnsweredPacks(t *testing.T) {
+	ic := types.InstallerContext{
+		Answers: &types.Answers{
+			Packs: []string{"mysql"},
+		},
+	}
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, v, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+	packs := i.answeredPacks([]types.OpenInstallationObservabilityPack{{Name: "MySQL"}, {Name: "Apache"}})
+	require.Equal(t, []types.OpenInstallationObservabilityPack{{Name: "MySQL"}}, packs)
+}
+
 func TestInstall_TargetedInstall_InstallsInfraAgent(t *testing.T) {
 	ic := types.InstallerContext{}
 	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
@@ -814,6 +987,34 @@ func TestInstall_TargetedInstall_InstallsInfraAgentDependency(t *testing.T) {
 	require.Equal(t, 1, statusReporters[0].(*execution.MockStatusReporter).InstallCompleteCallCount)
 }
 
+func TestInstall_TargetedInstall_DependencyCycle(t *testing.T) {
+	ic := types.InstallerContext{
+		RecipeNames: []string{testRecipeName},
+	}
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = []types.OpenInstallationRecipe{
+		{
+			Name:           testRecipeName,
+			ValidationNRQL: "testNrql",
+			Dependencies:   []string{anotherTestRecipeName},
+		},
+		{
+			Name:           anotherTestRecipeName,
+			ValidationNRQL: "testNrql",
+			Dependencies:   []string{testRecipeName},
+		},
+	}
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, v, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+	err := i.Install()
+	require.Error(t, err)
+	require.IsType(t, &recipes.DependencyCycleError{}, err)
+	require.Equal(t, 0, statusReporters[0].(*execution.MockStatusReporter).RecipeInstallingCallCount)
+}
+
 func TestInstall_TargetedInstallInfraAgent_NoInfraAgentDuplicate(t *testing.T) {
 	credentials.SetDefaultProfile(credentials.Profile{AccountID: 12345})
 	ic := types.InstallerContext{
@@ -949,3 +1150,136 @@ func fetchRecipeFileFunc(recipeURL *url.URL) (*types.OpenInstallationRecipe, err
 func loadRecipeFileFunc(filename string) (*types.OpenInstallationRecipe, error) {
 	return testRecipeFile, nil
 }
+
+type concurrencyTrackingExecutor struct {
+	sync.Mutex
+	active    int
+	maxActive int
+	order     []string
+}
+
+func (e *concurrencyTrackingExecutor) Execute(ctx context.Context, r types.OpenInstallationRecipe, v types.RecipeVars) error {
+	e.Lock()
+	e.active++
+	if e.active > e.maxActive {
+		e.maxActive = e.active
+	}
+	e.order = append(e.order, r.Name)
+	e.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+
+	e.Lock()
+	e.active--
+	e.Unlock()
+
+	return nil
+}
+
+func (e *concurrencyTrackingExecutor) ExecutePreInstall(ctx context.Context, r types.OpenInstallationRecipe, v types.RecipeVars) error {
+	return nil
+}
+
+func TestInstall_Concurrency(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{AccountID: 12345})
+	ic := types.InstallerContext{
+		AssumeYes:          true,
+		SkipLoggingInstall: true,
+		Concurrency:        2,
+	}
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = []types.OpenInstallationRecipe{
+		{Name: testRecipeName, DisplayName: testRecipeName},
+		{Name: types.InfraAgentRecipeName, DisplayName: types.InfraAgentRecipeName},
+		{Name: anotherTestRecipeName, DisplayName: anotherTestRecipeName},
+		{Name: "dependent-recipe", DisplayName: "dependent-recipe", Dependencies: []string{testRecipeName}},
+	}
+
+	te := &concurrencyTrackingExecutor{}
+
+	i := RecipeInstaller{ic, d, l, mv, f, te, v, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+	err := i.Install()
+	require.NoError(t, err)
+	require.Equal(t, 4, statusReporters[0].(*execution.MockStatusReporter).RecipeInstalledCallCount)
+	require.Equal(t, 2, te.maxActive)
+	require.Equal(t, types.InfraAgentRecipeName, te.order[0])
+	require.ElementsMatch(t, []string{testRecipeName, anotherTestRecipeName}, te.order[1:3])
+	require.Equal(t, "dependent-recipe", te.order[3])
+}
+
+func TestInstall_Concurrency_AbortsOnInfraFailure(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{AccountID: 12345})
+	ic := types.InstallerContext{
+		AssumeYes:          true,
+		SkipLoggingInstall: true,
+		Concurrency:        2,
+	}
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = []types.OpenInstallationRecipe{
+		{Name: types.InfraAgentRecipeName, DisplayName: types.InfraAgentRecipeName},
+		{Name: testRecipeName, DisplayName: testRecipeName},
+		{Name: anotherTestRecipeName, DisplayName: anotherTestRecipeName},
+	}
+
+	e := execution.NewMockRecipeExecutor()
+	e.ExecuteErr = errors.New("execution failed")
+
+	i := RecipeInstaller{ic, d, l, mv, f, e, v, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+	err := i.Install()
+	require.Error(t, err)
+	require.Equal(t, 1, statusReporters[0].(*execution.MockStatusReporter).RecipeInstallingCallCount)
+	require.Equal(t, 1, statusReporters[0].(*execution.MockStatusReporter).RecipeFailedCallCount)
+}
+
+type failingRecipeExecutor struct {
+	concurrencyTrackingExecutor
+	failing string
+}
+
+func (e *failingRecipeExecutor) Execute(ctx context.Context, r types.OpenInstallationRecipe, v types.RecipeVars) error {
+	if err := e.concurrencyTrackingExecutor.Execute(ctx, r, v); err != nil {
+		return err
+	}
+
+	if r.Name == e.failing {
+		return errors.New("execution failed")
+	}
+
+	return nil
+}
+
+func TestInstall_Concurrency_SkipsDependentsOfFailedRecipe(t *testing.T) {
+	credentials.SetDefaultProfile(credentials.Profile{AccountID: 12345})
+	ic := types.InstallerContext{
+		AssumeYes:          true,
+		SkipLoggingInstall: true,
+		Concurrency:        2,
+	}
+	statusReporters = []execution.StatusSubscriber{execution.NewMockStatusReporter()}
+	status = execution.NewInstallStatus(statusReporters, execution.NewPlatformLinkGenerator())
+	rf := recipes.NewRecipeFilterRunner(ic, status)
+	f = recipes.NewMockRecipeFetcher()
+	f.FetchRecipesVal = []types.OpenInstallationRecipe{
+		{Name: types.InfraAgentRecipeName, DisplayName: types.InfraAgentRecipeName},
+		{Name: testRecipeName, DisplayName: testRecipeName},
+		{Name: anotherTestRecipeName, DisplayName: anotherTestRecipeName},
+		{Name: "dependent-recipe", DisplayName: "dependent-recipe", Dependencies: []string{testRecipeName}},
+		{Name: "transitive-recipe", DisplayName: "transitive-recipe", Dependencies: []string{"dependent-recipe"}},
+	}
+
+	te := &failingRecipeExecutor{failing: testRecipeName}
+
+	i := RecipeInstaller{ic, d, l, mv, f, te, v, ff, status, p, pi, lkf, cv, rvp, rf, pf, cpi}
+	err := i.Install()
+	require.Error(t, err)
+	require.ElementsMatch(t, []string{types.InfraAgentRecipeName, testRecipeName, anotherTestRecipeName}, te.order)
+	require.Equal(t, 1, statusReporters[0].(*execution.MockStatusReporter).RecipeFailedCallCount)
+	require.Equal(t, 2, statusReporters[0].(*execution.MockStatusReporter).RecipeSkippedCallCount)
+	require.Equal(t, 2, statusReporters[0].(*execution.MockStatusReporter).RecipeInstalledCallCount)
+}
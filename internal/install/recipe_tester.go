@@ -0,0 +1,567 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// RecipeTestScenario describes a simulated host to test a recipe against, and
+// what is expected to happen there.
+//
+//	discovery:
+//	  os: linux
+//	  platform: ubuntu
+//	  processes:
+//	    - name: mysqld
+//	      cmdline: /usr/sbin/mysqld
+//	      user: mysql
+//	  logFiles:
+//	    - /var/log/mysql/error.log
+//	  packages:
+//	    - mysql-server
+//	  ports:
+//	    - 3306
+//	vars:
+//	  NR_CLI_DB_USERNAME: newrelic
+//	stubs:
+//	  systemctl:
+//	    output: active
+//	expect:
+//	  matched: true
+//	  status: INSTALLED
+//	  calls:
+//	    - systemctl restart newrelic-infra
+type RecipeTestScenario struct {
+	Discovery RecipeTestDiscovery `yaml:"discovery"`
+	// Vars are values for the recipe's input variables.
+	Vars map[string]string `yaml:"vars"`
+	// Stubs replace commands on the PATH while the recipe runs.  Commands that
+	// are neither stubbed nor allowed in the sandbox fail the scenario.
+	Stubs  map[string]RecipeTestStub `yaml:"stubs"`
+	Expect RecipeTestExpectations    `yaml:"expect"`
+}
+
+// RecipeTestDiscovery is the simulated result of host discovery.
+type RecipeTestDiscovery struct {
+	Hostname        string              `yaml:"hostname"`
+	OS              string              `yaml:"os"`
+	Platform        string              `yaml:"platform"`
+	PlatformFamily  string              `yaml:"platformFamily"`
+	PlatformVersion string              `yaml:"platformVersion"`
+	KernelArch      string              `yaml:"kernelArch"`
+	KernelVersion   string              `yaml:"kernelVersion"`
+	Processes       []RecipeTestProcess `yaml:"processes"`
+	// LogFiles are the files that exist on the simulated host, for matching
+	// against the recipe's logMatch patterns.
+	LogFiles         []string `yaml:"logFiles"`
+	ContainerRuntime string   `yaml:"containerRuntime"`
+	Kubernetes       bool     `yaml:"kubernetes"`
+	CloudProvider    string   `yaml:"cloudProvider"`
+	// Packages are the names of the packages installed on the simulated host.
+	Packages []string `yaml:"packages"`
+	// Ports are the TCP ports listened on by the simulated host.
+	Ports []uint32 `yaml:"ports"`
+}
+
+// RecipeTestProcess is a process running on the simulated host.
+type RecipeTestProcess struct {
+	// PID defaults to the process' position in the list, counting from 1.
+	PID     int32  `yaml:"pid"`
+	Name    string `yaml:"name"`
+	Cmdline string `yaml:"cmdline"`
+	Exe     string `yaml:"exe"`
+	User    string `yaml:"user"`
+	PPID    int32  `yaml:"ppid"`
+	// Env are the names of the process' environment variables.
+	Env []string `yaml:"env"`
+	// Ports are the TCP ports the process listens on.
+	Ports []uint32 `yaml:"ports"`
+}
+
+// RecipeTestStub is a command that prints its output and exits with its exit
+// code, in place of the real command.
+type RecipeTestStub struct {
+	Output   string `yaml:"output"`
+	ExitCode int    `yaml:"exitCode"`
+}
+
+// RecipeTestExpectations are the outcomes a scenario asserts.  Expectations
+// that are not set are not checked.
+type RecipeTestExpectations struct {
+	// Matched is whether the recipe's install targets match the host.
+	Matched *bool `yaml:"matched"`
+	// Status is the outcome of the recipe: INSTALLED, FAILED, UNSUPPORTED,
+	// CANCELED, or SKIPPED when it does not match or is filtered out.
+	Status execution.RecipeStatusType `yaml:"status"`
+	// LogFiles are the names of the recipe's logMatch entries that match.
+	LogFiles []string `yaml:"logFiles"`
+	// Calls are stubbed command lines that must have been run, in order.
+	Calls []string `yaml:"calls"`
+	// Output are strings that must appear in the recipe's output.
+	Output []string `yaml:"output"`
+}
+
+// RecipeTestResult is what happened when a recipe was run against a scenario.
+type RecipeTestResult struct {
+	Matched      bool
+	Filtered     bool
+	FilterReason string
+	Status       execution.RecipeStatusType
+	Error        string
+	LogFiles     []string
+	Calls        []string
+	Output       string
+	// UnstubbedCommands are the commands the recipe ran that were neither
+	// stubbed nor allowed in the sandbox.
+	UnstubbedCommands []string
+	// Failures are the expectations that were not met.
+	Failures []string
+}
+
+// Passed returns whether every expectation of the scenario was met.
+func (r *RecipeTestResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// LoadRecipeTestScenario reads a scenario file.
+func LoadRecipeTestScenario(path string) (*RecipeTestScenario, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s RecipeTestScenario
+	if err = yaml.UnmarshalStrict(b, &s); err != nil {
+		return nil, fmt.Errorf("could not read scenario %s: %s", path, err)
+	}
+
+	return &s, nil
+}
+
+// RecipeTester runs a recipe against a simulated host: install target
+// matching, the recipe filters and the recipe's install steps, in a sandbox
+// working directory with stubbed commands.
+type RecipeTester struct {
+	// Stdout receives the recipe's output as it runs, in addition to it being
+	// captured in the result.
+	Stdout io.Writer
+	// AllowHostCommands keeps the host's PATH while the recipe runs, so that
+	// commands which are not stubbed run for real.
+	AllowHostCommands bool
+}
+
+// sandboxCommands are the host commands that recipes may run in a sandbox
+// without being stubbed.  They only print, transform text or inspect the
+// host; anything that installs, downloads or removes must be stubbed.
+var sandboxCommands = []string{
+	"awk", "basename", "bash", "cat", "cut", "date", "dirname", "echo", "egrep", "env", "expr", "false", "grep",
+	"head", "id", "printf", "sed", "seq", "sh", "sleep", "sort", "tail", "test", "tr", "true",
+	"uname", "uniq", "wc", "whoami",
+}
+
+// unstubbedCommandRE matches the error the task runner prints when a command
+// is not found on the PATH.
+var unstubbedCommandRE = regexp.MustCompile(`"([^"]+)": executable file not found in \$PATH`)
+
+func NewRecipeTester() *RecipeTester {
+	return &RecipeTester{
+		Stdout: ioutil.Discard,
+	}
+}
+
+// Test runs the recipe against the scenario.
+func (t *RecipeTester) Test(ctx context.Context, r types.OpenInstallationRecipe, s RecipeTestScenario) (*RecipeTestResult, error) {
+	sandbox, err := newRecipeTestSandbox(s.Stubs, t.AllowHostCommands)
+	if err != nil {
+		return nil, err
+	}
+	defer sandbox.remove()
+
+	restore, err := sandbox.enter(t.AllowHostCommands)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	m := s.Discovery.manifest()
+	result := &RecipeTestResult{
+		Status:   execution.RecipeStatusTypes.SKIPPED,
+		LogFiles: s.Discovery.matchLogFiles(r),
+	}
+
+	repo := recipes.NewRecipeRepository(func() ([]types.OpenInstallationRecipe, error) {
+		return []types.OpenInstallationRecipe{r}, nil
+	})
+
+	matched, err := repo.FindAll(*m)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Matched = len(matched) > 0
+
+	if result.Matched {
+		status := execution.NewInstallStatus([]execution.StatusSubscriber{}, execution.NewPlatformLinkGenerator())
+		rf := recipes.NewRecipeFilterRunner(types.InstallerContext{AssumeYes: true}, status)
+
+		result.Filtered = rf.RunFilter(ctx, &r, m)
+		result.FilterReason = rf.FilterReasons()[r.Name]
+	}
+
+	if result.Matched && !result.Filtered {
+		if err = t.execute(ctx, r, s, m, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Calls, err = sandbox.calls(); err != nil {
+		return nil, err
+	}
+
+	result.Failures = s.Expect.check(result)
+
+	return result, nil
+}
+
+func (t *RecipeTester) execute(ctx context.Context, r types.OpenInstallationRecipe, s RecipeTestScenario, m *types.DiscoveryManifest, result *RecipeTestResult) error {
+	answers := &types.Answers{
+		Variables: map[string]map[string]string{
+			r.Name: s.Vars,
+		},
+	}
+
+	vars, err := execution.SimulatedRecipeVars(*m, r, answers, nil)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	w := io.MultiWriter(&output, t.Stdout)
+
+	re := execution.NewGoTaskRecipeExecutor()
+	re.Stdin = bytes.NewReader(nil)
+	re.Stdout = w
+	re.Stderr = w
+
+	err = re.Execute(ctx, r, vars)
+	result.Output = output.String()
+
+	if !t.AllowHostCommands {
+		for _, m := range unstubbedCommandRE.FindAllStringSubmatch(result.Output, -1) {
+			if !utils.StringInSlice(m[1], result.UnstubbedCommands) {
+				result.UnstubbedCommands = append(result.UnstubbedCommands, m[1])
+			}
+		}
+	}
+
+	switch err.(type) {
+	case nil:
+		result.Status = execution.RecipeStatusTypes.INSTALLED
+	case *types.UnsupportedOperatingSytemError:
+		result.Status = execution.RecipeStatusTypes.UNSUPPORTED
+	default:
+		if err == types.ErrInterrupt {
+			result.Status = execution.RecipeStatusTypes.CANCELED
+		} else {
+			result.Status = execution.RecipeStatusTypes.FAILED
+		}
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return nil
+}
+
+func (d RecipeTestDiscovery) manifest() *types.DiscoveryManifest {
+	m := types.DiscoveryManifest{
+		Hostname:         d.Hostname,
+		OS:               d.OS,
+		Platform:         d.Platform,
+		PlatformFamily:   d.PlatformFamily,
+		PlatformVersion:  d.PlatformVersion,
+		KernelArch:       d.KernelArch,
+		KernelVersion:    d.KernelVersion,
+		ContainerRuntime: d.ContainerRuntime,
+		Kubernetes:       d.Kubernetes,
+		Cloud:            types.CloudMetadata{Provider: d.CloudProvider},
+	}
+
+	for _, p := range d.Packages {
+		m.InstalledPackages = append(m.InstalledPackages, types.InstalledPackage{Name: p, Manager: "simulated"})
+	}
+
+	for _, p := range d.Ports {
+		m.ListeningPorts = append(m.ListeningPorts, types.ListeningPort{Port: p})
+	}
+
+	if m.Hostname == "" {
+		m.Hostname = "recipe-test-host"
+	}
+
+	for i, p := range d.Processes {
+		pid := p.PID
+		if pid == 0 {
+			pid = int32(i + 1)
+		}
+
+		m.DiscoveredProcesses = append(m.DiscoveredProcesses, types.DiscoveredProcess{
+			ProcessID:   pid,
+			ProcessName: p.Name,
+			Cmdline:     p.Cmdline,
+			ExePath:     p.Exe,
+			User:        p.User,
+			ParentID:    p.PPID,
+			EnvVarNames: p.Env,
+		})
+
+		for _, port := range p.Ports {
+			m.ListeningPorts = append(m.ListeningPorts, types.ListeningPort{Port: port, PID: pid})
+		}
+	}
+
+	return &m
+}
+
+// matchLogFiles returns the names of the recipe's logMatch entries whose file
+// pattern matches one of the simulated log files.
+func (d RecipeTestDiscovery) matchLogFiles(r types.OpenInstallationRecipe) []string {
+	matches := []string{}
+
+	for _, l := range r.LogMatch {
+		for _, f := range d.LogFiles {
+			if ok, _ := filepath.Match(l.File, f); ok {
+				matches = append(matches, l.Name)
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+func (e RecipeTestExpectations) check(result *RecipeTestResult) []string {
+	failures := []string{}
+
+	for _, c := range result.UnstubbedCommands {
+		failures = append(failures, fmt.Sprintf("command %s is not stubbed, add a stub for it to the scenario", c))
+	}
+
+	if e.Matched != nil && *e.Matched != result.Matched {
+		failures = append(failures, fmt.Sprintf("expected matched to be %t, was %t", *e.Matched, result.Matched))
+	}
+
+	if e.Status != "" && !strings.EqualFold(string(e.Status), string(result.Status)) {
+		failures = append(failures, fmt.Sprintf("expected status %s, was %s", e.Status, result.Status))
+	}
+
+	for _, l := range e.LogFiles {
+		if !utils.StringInSlice(l, result.LogFiles) {
+			failures = append(failures, fmt.Sprintf("expected log file %s to match", l))
+		}
+	}
+
+	if missing := missingCalls(e.Calls, result.Calls); len(missing) > 0 {
+		failures = append(failures, fmt.Sprintf("expected calls were not made, in order: %s", strings.Join(missing, "; ")))
+	}
+
+	for _, o := range e.Output {
+		if !strings.Contains(result.Output, o) {
+			failures = append(failures, fmt.Sprintf("expected output to contain %q", o))
+		}
+	}
+
+	return failures
+}
+
+// missingCalls returns the expected calls that were not made, in the order
+// they were expected.
+func missingCalls(expected []string, calls []string) []string {
+	missing := []string{}
+	next := 0
+
+	for _, e := range expected {
+		found := false
+
+		for next < len(calls) {
+			c := calls[next]
+			next++
+
+			if c == e {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, e)
+		}
+	}
+
+	return missing
+}
+
+// recipeTestSandbox is a working directory with a directory of stub commands
+// and a directory of links to the allowed host commands, which make up the
+// PATH.
+type recipeTestSandbox struct {
+	dir      string
+	stubsDir string
+	binDir   string
+	callsLog string
+}
+
+func newRecipeTestSandbox(stubs map[string]RecipeTestStub, allowHostCommands bool) (*recipeTestSandbox, error) {
+	if runtime.GOOS == "windows" {
+		if len(stubs) > 0 {
+			return nil, fmt.Errorf("command stubs are not supported on windows")
+		}
+
+		if !allowHostCommands {
+			return nil, fmt.Errorf("sandboxed commands are not supported on windows, allow host commands to run recipes")
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "newrelic-recipe-test")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &recipeTestSandbox{
+		dir:      dir,
+		stubsDir: filepath.Join(dir, ".stubs"),
+		binDir:   filepath.Join(dir, ".bin"),
+		callsLog: filepath.Join(dir, ".stubs", "calls.log"),
+	}
+
+	if err = os.MkdirAll(s.stubsDir, 0750); err != nil {
+		s.remove()
+		return nil, err
+	}
+
+	if !allowHostCommands {
+		if err = s.linkHostCommands(sandboxCommands); err != nil {
+			s.remove()
+			return nil, err
+		}
+	}
+
+	for name, stub := range stubs {
+		if err = s.writeStub(name, stub); err != nil {
+			s.remove()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func (s *recipeTestSandbox) writeStub(name string, stub RecipeTestStub) error {
+	if strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid stub name %s, stubs replace commands on the PATH", name)
+	}
+
+	script := fmt.Sprintf("#!/bin/sh\necho \"%s $*\" >> %s\nprintf '%%s\\n' %s\nexit %d\n",
+		name, shellQuote(s.callsLog), shellQuote(stub.Output), stub.ExitCode)
+
+	if stub.Output == "" {
+		script = fmt.Sprintf("#!/bin/sh\necho \"%s $*\" >> %s\nexit %d\n", name, shellQuote(s.callsLog), stub.ExitCode)
+	}
+
+	// nolint: gosec
+	return ioutil.WriteFile(filepath.Join(s.stubsDir, name), []byte(script), 0750)
+}
+
+// linkHostCommands links the host commands that are found on the PATH into
+// the sandbox's bin directory.
+func (s *recipeTestSandbox) linkHostCommands(commands []string) error {
+	if err := os.MkdirAll(s.binDir, 0750); err != nil {
+		return err
+	}
+
+	for _, c := range commands {
+		path, err := exec.LookPath(c)
+		if err != nil {
+			continue
+		}
+
+		if err = os.Symlink(path, filepath.Join(s.binDir, c)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enter makes the sandbox the working directory and sets the PATH to the
+// stubs followed by the allowed host commands, or by the host's PATH when
+// host commands are allowed.  It returns the function that restores both.
+func (s *recipeTestSandbox) enter(allowHostCommands bool) (func(), error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	path := os.Getenv("PATH")
+
+	if err = os.Chdir(s.dir); err != nil {
+		return nil, err
+	}
+
+	sandboxPath := s.binDir
+	if allowHostCommands {
+		sandboxPath = path
+	}
+
+	if err = os.Setenv("PATH", s.stubsDir+string(os.PathListSeparator)+sandboxPath); err != nil {
+		_ = os.Chdir(wd)
+		return nil, err
+	}
+
+	return func() {
+		_ = os.Setenv("PATH", path)
+		_ = os.Chdir(wd)
+	}, nil
+}
+
+func (s *recipeTestSandbox) calls() ([]string, error) {
+	b, err := ioutil.ReadFile(s.callsLog)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	calls := []string{}
+	for _, c := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		calls = append(calls, strings.TrimSpace(c))
+	}
+
+	return calls, nil
+}
+
+func (s *recipeTestSandbox) remove() {
+	os.RemoveAll(s.dir)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,182 @@
+// +build unit
+
+package install
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+var testerRecipe = types.OpenInstallationRecipe{
+	Name: "test-recipe",
+	InstallTargets: []types.OpenInstallationRecipeInstallTarget{
+		{Os: types.OpenInstallationOperatingSystemTypes.LINUX},
+	},
+	ProcessMatch: []string{"mysqld"},
+	LogMatch: []types.OpenInstallationLogMatch{
+		{Name: "MySQL error log", File: "/var/log/mysql/*.log"},
+	},
+	InputVars: []types.OpenInstallationRecipeInputVariable{
+		{Name: "NR_CLI_DB_USERNAME"},
+	},
+	Install: `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - systemctl restart newrelic-infra
+      - echo "user {{.NR_CLI_DB_USERNAME}} on {{.HOSTNAME}}"
+`,
+}
+
+func testerScenario() RecipeTestScenario {
+	return RecipeTestScenario{
+		Discovery: RecipeTestDiscovery{
+			Hostname: "db-1",
+			OS:       "linux",
+			Platform: "ubuntu",
+			Processes: []RecipeTestProcess{
+				{Name: "mysqld", Cmdline: "/usr/sbin/mysqld"},
+			},
+			LogFiles: []string{"/var/log/mysql/error.log"},
+		},
+		Vars: map[string]string{
+			"NR_CLI_DB_USERNAME": "newrelic",
+		},
+		Stubs: map[string]RecipeTestStub{
+			"systemctl": {Output: "restarted"},
+		},
+	}
+}
+
+func TestRecipeTester_Installed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("command stubs are not supported on windows")
+	}
+
+	matched := true
+	s := testerScenario()
+	s.Expect = RecipeTestExpectations{
+		Matched:  &matched,
+		Status:   execution.RecipeStatusTypes.INSTALLED,
+		LogFiles: []string{"MySQL error log"},
+		Calls:    []string{"systemctl restart newrelic-infra"},
+		Output:   []string{"restarted", "user newrelic on db-1"},
+	}
+
+	result, err := NewRecipeTester().Test(context.Background(), testerRecipe, s)
+	require.NoError(t, err)
+	require.Empty(t, result.Failures)
+	require.True(t, result.Passed())
+}
+
+func TestRecipeTester_StubFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("command stubs are not supported on windows")
+	}
+
+	s := testerScenario()
+	s.Stubs["systemctl"] = RecipeTestStub{ExitCode: 1}
+	s.Expect.Status = execution.RecipeStatusTypes.INSTALLED
+
+	result, err := NewRecipeTester().Test(context.Background(), testerRecipe, s)
+	require.NoError(t, err)
+	require.Equal(t, execution.RecipeStatusTypes.FAILED, result.Status)
+	require.NotEmpty(t, result.Error)
+	require.False(t, result.Passed())
+}
+
+func TestRecipeTester_UnstubbedCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("command stubs are not supported on windows")
+	}
+
+	s := testerScenario()
+	delete(s.Stubs, "systemctl")
+
+	result, err := NewRecipeTester().Test(context.Background(), testerRecipe, s)
+	require.NoError(t, err)
+	require.Equal(t, execution.RecipeStatusTypes.FAILED, result.Status)
+	require.Equal(t, []string{"systemctl"}, result.UnstubbedCommands)
+	require.False(t, result.Passed())
+	require.Empty(t, result.Calls)
+}
+
+func TestRecipeTester_NotMatched(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sandboxed commands are not supported on windows")
+	}
+
+	matched := false
+	s := testerScenario()
+	s.Discovery.OS = "windows"
+	s.Expect = RecipeTestExpectations{
+		Matched: &matched,
+		Status:  execution.RecipeStatusTypes.SKIPPED,
+	}
+
+	result, err := NewRecipeTester().Test(context.Background(), testerRecipe, s)
+	require.NoError(t, err)
+	require.True(t, result.Passed())
+	require.Empty(t, result.Calls)
+}
+
+func TestRecipeTester_Filtered(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sandboxed commands are not supported on windows")
+	}
+
+	s := testerScenario()
+	s.Discovery.Processes = []RecipeTestProcess{
+		{Name: "postgres", Cmdline: "/usr/lib/postgresql/bin/postgres"},
+	}
+
+	result, err := NewRecipeTester().Test(context.Background(), testerRecipe, s)
+	require.NoError(t, err)
+	require.True(t, result.Matched)
+	require.True(t, result.Filtered)
+	require.Equal(t, execution.RecipeStatusTypes.SKIPPED, result.Status)
+	require.Empty(t, result.Calls)
+}
+
+func TestLoadRecipeTestScenario(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic-recipe-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "scenario.yml")
+	err = ioutil.WriteFile(path, []byte(`
+discovery:
+  os: linux
+  processes:
+    - name: mysqld
+stubs:
+  systemctl:
+    exitCode: 1
+expect:
+  status: FAILED
+`), 0600)
+	require.NoError(t, err)
+
+	s, err := LoadRecipeTestScenario(path)
+	require.NoError(t, err)
+	require.Equal(t, "linux", s.Discovery.OS)
+	require.Equal(t, "mysqld", s.Discovery.Processes[0].Name)
+	require.Equal(t, 1, s.Stubs["systemctl"].ExitCode)
+	require.Equal(t, execution.RecipeStatusTypes.FAILED, s.Expect.Status)
+
+	err = ioutil.WriteFile(path, []byte("discovery:\n  operatingSystem: linux\n"), 0600)
+	require.NoError(t, err)
+
+	_, err = LoadRecipeTestScenario(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package recipes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// DependencyCycleError is returned when recipes depend on each other, directly
+// or through other recipes.
+type DependencyCycleError struct {
+	// Cycle is the chain of recipe names, starting and ending with the same recipe.
+	Cycle []string
+}
+
+func (e *DependencyCycleError) Error() string {
+	return fmt.Sprintf("recipe dependency cycle detected: %s", strings.Join(e.Cycle, " -> "))
+}
+
+// MissingDependencyError is returned when a recipe depends on a recipe that is
+// not available for the current platform.
+type MissingDependencyError struct {
+	Recipe     string
+	Dependency string
+}
+
+func (e *MissingDependencyError) Error() string {
+	return fmt.Sprintf("recipe %s depends on %s, which is not available for this platform", e.Recipe, e.Dependency)
+}
+
+// DependencyGraph resolves recipe dependencies against the recipes available
+// for the current platform.
+type DependencyGraph struct {
+	available map[string]types.OpenInstallationRecipe
+}
+
+// NewDependencyGraph returns a dependency graph over the available recipes.
+func NewDependencyGraph(available []types.OpenInstallationRecipe) *DependencyGraph {
+	g := DependencyGraph{
+		available: map[string]types.OpenInstallationRecipe{},
+	}
+
+	for _, r := range available {
+		g.available[r.Name] = r
+	}
+
+	return &g
+}
+
+// Resolve returns the selected recipes along with all of their transitive
+// dependencies, ordered so that each recipe comes after the recipes it depends
+// on.  Otherwise the order of the selected recipes is kept.
+func (g *DependencyGraph) Resolve(selected []types.OpenInstallationRecipe) ([]types.OpenInstallationRecipe, error) {
+	lookup := g.lookup(selected)
+	visited := map[string]bool{}
+	visiting := map[string]bool{}
+	path := []string{}
+	results := []types.OpenInstallationRecipe{}
+
+	var visit func(r types.OpenInstallationRecipe) error
+	visit = func(r types.OpenInstallationRecipe) error {
+		if visited[r.Name] {
+			return nil
+		}
+
+		if visiting[r.Name] {
+			return &DependencyCycleError{Cycle: cycleFrom(path, r.Name)}
+		}
+
+		visiting[r.Name] = true
+		path = append(path, r.Name)
+
+		for _, n := range r.Dependencies {
+			d, ok := lookup[n]
+			if !ok {
+				return &MissingDependencyError{Recipe: r.Name, Dependency: n}
+			}
+
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		visiting[r.Name] = false
+		visited[r.Name] = true
+		results = append(results, r)
+
+		return nil
+	}
+
+	for _, r := range selected {
+		if err := visit(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// Explain writes the dependency tree of each selected recipe, followed by the
+// resulting install order.
+func (g *DependencyGraph) Explain(w io.Writer, selected []types.OpenInstallationRecipe) error {
+	ordered, err := g.Resolve(selected)
+	if err != nil {
+		return err
+	}
+
+	lookup := g.lookup(selected)
+	shown := map[string]bool{}
+
+	var explain func(name string, depth int)
+	explain = func(name string, depth int) {
+		r := lookup[name]
+		indent := strings.Repeat("  ", depth+1)
+
+		if shown[name] && len(r.Dependencies) > 0 {
+			fmt.Fprintf(w, "%s- %s (dependencies shown above)\n", indent, name)
+			return
+		}
+
+		fmt.Fprintf(w, "%s- %s\n", indent, name)
+		shown[name] = true
+
+		for _, d := range r.Dependencies {
+			explain(d, depth+1)
+		}
+	}
+
+	fmt.Fprintf(w, "\nRecipe dependencies:\n")
+	for _, r := range selected {
+		explain(r.Name, 0)
+	}
+
+	fmt.Fprintf(w, "\nInstall order:\n")
+	for n, r := range ordered {
+		fmt.Fprintf(w, "  %d. %s\n", n+1, r.Name)
+	}
+
+	return nil
+}
+
+// lookup indexes the available recipes by name.  Selected recipes take
+// precedence, since they may have been loaded from a path rather than fetched.
+func (g *DependencyGraph) lookup(selected []types.OpenInstallationRecipe) map[string]types.OpenInstallationRecipe {
+	lookup := map[string]types.OpenInstallationRecipe{}
+	for n, r := range g.available {
+		lookup[n] = r
+	}
+
+	for _, r := range selected {
+		lookup[r.Name] = r
+	}
+
+	return lookup
+}
+
+func cycleFrom(path []string, name string) []string {
+	for i, n := range path {
+		if n == name {
+			cycle := append([]string{}, path[i:]...)
+			return append(cycle, name)
+		}
+	}
+
+	return []string{name, name}
+}
@@ -0,0 +1,135 @@
+// +build unit
+
+package recipes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func recipeNames(recipes []types.OpenInstallationRecipe) []string {
+	names := []string{}
+	for _, r := range recipes {
+		names = append(names, r.Name)
+	}
+
+	return names
+}
+
+func TestDependencyGraph_Resolve_Transitive(t *testing.T) {
+	available := []types.OpenInstallationRecipe{
+		{Name: "a", Dependencies: []string{"b"}},
+		{Name: "b", Dependencies: []string{"c"}},
+		{Name: "c"},
+		{Name: "d", Dependencies: []string{"c"}},
+	}
+
+	g := NewDependencyGraph(available)
+
+	resolved, err := g.Resolve([]types.OpenInstallationRecipe{available[0], available[3]})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "b", "a", "d"}, recipeNames(resolved))
+}
+
+func TestDependencyGraph_Resolve_KeepsSelectedOrder(t *testing.T) {
+	available := []types.OpenInstallationRecipe{
+		{Name: types.InfraAgentRecipeName},
+		{Name: "a", Dependencies: []string{types.InfraAgentRecipeName}},
+		{Name: "b"},
+	}
+
+	g := NewDependencyGraph(available)
+
+	resolved, err := g.Resolve([]types.OpenInstallationRecipe{available[2], available[1], available[0]})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", types.InfraAgentRecipeName, "a"}, recipeNames(resolved))
+}
+
+func TestDependencyGraph_Resolve_PrefersSelected(t *testing.T) {
+	g := NewDependencyGraph([]types.OpenInstallationRecipe{{Name: "b"}})
+
+	selected := []types.OpenInstallationRecipe{
+		{Name: "a", Dependencies: []string{"c"}},
+		{Name: "c", Dependencies: []string{"b"}},
+	}
+
+	resolved, err := g.Resolve(selected)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c", "a"}, recipeNames(resolved))
+}
+
+func TestDependencyGraph_Resolve_Cycle(t *testing.T) {
+	available := []types.OpenInstallationRecipe{
+		{Name: "a", Dependencies: []string{"b"}},
+		{Name: "b", Dependencies: []string{"c"}},
+		{Name: "c", Dependencies: []string{"a"}},
+	}
+
+	g := NewDependencyGraph(available)
+
+	_, err := g.Resolve(available[:1])
+	require.Error(t, err)
+
+	cycleErr, ok := err.(*DependencyCycleError)
+	require.True(t, ok)
+	require.Equal(t, []string{"a", "b", "c", "a"}, cycleErr.Cycle)
+	require.Equal(t, "recipe dependency cycle detected: a -> b -> c -> a", err.Error())
+}
+
+func TestDependencyGraph_Resolve_SelfCycle(t *testing.T) {
+	available := []types.OpenInstallationRecipe{
+		{Name: "a", Dependencies: []string{"a"}},
+	}
+
+	_, err := NewDependencyGraph(available).Resolve(available)
+	require.Error(t, err)
+	require.Equal(t, []string{"a", "a"}, err.(*DependencyCycleError).Cycle)
+}
+
+func TestDependencyGraph_Resolve_Missing(t *testing.T) {
+	available := []types.OpenInstallationRecipe{
+		{Name: "a", Dependencies: []string{"b"}},
+		{Name: "b", Dependencies: []string{"missing"}},
+	}
+
+	_, err := NewDependencyGraph(available).Resolve(available[:1])
+	require.Error(t, err)
+
+	missingErr, ok := err.(*MissingDependencyError)
+	require.True(t, ok)
+	require.Equal(t, "b", missingErr.Recipe)
+	require.Equal(t, "missing", missingErr.Dependency)
+}
+
+func TestDependencyGraph_Explain(t *testing.T) {
+	available := []types.OpenInstallationRecipe{
+		{Name: "a", Dependencies: []string{"b", "c"}},
+		{Name: "b", Dependencies: []string{"c"}},
+		{Name: "c", Dependencies: []string{"d"}},
+		{Name: "d"},
+	}
+
+	var w bytes.Buffer
+	err := NewDependencyGraph(available).Explain(&w, available[:1])
+	require.NoError(t, err)
+
+	expected := `
+Recipe dependencies:
+  - a
+    - b
+      - c
+        - d
+    - c (dependencies shown above)
+
+Install order:
+  1. d
+  2. c
+  3. b
+  4. a
+`
+	require.Equal(t, expected, w.String())
+}
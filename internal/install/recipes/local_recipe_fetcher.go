@@ -43,9 +43,11 @@ func loadRecipesFromDir(ctx context.Context, path string) ([]types.OpenInstallat
 	err := filepath.Walk(
 		path,
 		func(path string, info os.FileInfo, err error) error {
-			ext := filepath.Ext(path)
+			if info != nil && info.IsDir() && info.Name() == ".git" {
+				return filepath.SkipDir
+			}
 
-			if ext == ".yml" || ext == ".yaml" {
+			if isRecipeFile(path) {
 				recipePaths = append(recipePaths, path)
 			}
 
@@ -0,0 +1,107 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// ExplainMatches writes which processes, packages and ports on the host
+// matched each of the recipes' matchers, so that ambiguous or missing
+// matches can be diagnosed.
+func ExplainMatches(ctx context.Context, w io.Writer, recipes []types.OpenInstallationRecipe, m *types.DiscoveryManifest) {
+	f := NewRegexProcessMatchFinder()
+
+	fmt.Fprintf(w, "\nRecipe matches:\n")
+	if len(recipes) == 0 {
+		fmt.Fprintf(w, "  (none)\n")
+	}
+
+	for _, r := range recipes {
+		if !HasDiscoveryMatchers(r) {
+			fmt.Fprintf(w, "  - %s: no process, package or port matchers\n", r.Name)
+			continue
+		}
+
+		fmt.Fprintf(w, "  - %s\n", r.Name)
+
+		processMatches := f.FindMatches(ctx, m.DiscoveredProcesses, r)
+		for _, pattern := range r.ProcessMatch {
+			explainProcessMatches(w, fmt.Sprintf("processMatch %q", pattern), pattern, processMatches)
+		}
+
+		for i, rule := range r.ProcessMatchRules {
+			description := DescribeProcessMatchRule(rule)
+			if !processRuleHasCriteria(rule) {
+				fmt.Fprintf(w, "    processMatchRules[%d] %s:\n      ignored, the rule has no criteria\n", i, description)
+				continue
+			}
+
+			candidates := processRuleCandidates(r, i, m)
+			explainProcessMatches(w, fmt.Sprintf("processMatchRules[%d] %s", i, description), description, candidates)
+
+			if len(candidates) > 0 && len(candidates) < rule.MinCount {
+				fmt.Fprintf(w, "      not matched, %d of at least %d processes are running\n", len(candidates), rule.MinCount)
+			}
+		}
+
+		for _, pattern := range r.PackageMatch {
+			explainPackageMatches(w, pattern, FindPackageMatches(types.OpenInstallationRecipe{PackageMatch: []string{pattern}}, m))
+		}
+
+		for _, port := range r.PortMatch {
+			explainPortMatches(w, port, FindPortMatches(types.OpenInstallationRecipe{PortMatch: []int{port}}, m))
+		}
+	}
+}
+
+func explainProcessMatches(w io.Writer, label string, pattern string, matches []types.MatchedProcess) {
+	fmt.Fprintf(w, "    %s:\n", label)
+
+	found := false
+	for _, p := range matches {
+		if p.MatchingPattern != pattern {
+			continue
+		}
+
+		name, _ := p.Name()
+		cmd, _ := p.Cmd()
+		fmt.Fprintf(w, "      pid %d %s: %s\n", p.PID(), name, cmd)
+		found = true
+	}
+
+	if !found {
+		fmt.Fprintf(w, "      no matching process\n")
+	}
+}
+
+func explainPackageMatches(w io.Writer, pattern string, matches []types.InstalledPackage) {
+	fmt.Fprintf(w, "    packageMatch %q:\n", pattern)
+
+	for _, p := range matches {
+		fmt.Fprintf(w, "      %s %s (%s)\n", p.Name, p.Version, p.Manager)
+	}
+
+	if len(matches) == 0 {
+		fmt.Fprintf(w, "      no matching package\n")
+	}
+}
+
+func explainPortMatches(w io.Writer, port int, matches []types.ListeningPort) {
+	fmt.Fprintf(w, "    portMatch %d:\n", port)
+
+	for _, p := range matches {
+		owner := "owner unknown"
+		if p.PID != 0 {
+			owner = fmt.Sprintf("pid %d", p.PID)
+		}
+
+		fmt.Fprintf(w, "      listening on %s:%d (%s)\n", p.Address, p.Port, owner)
+	}
+
+	if len(matches) == 0 {
+		fmt.Fprintf(w, "      no process listening\n")
+	}
+}
@@ -4,6 +4,10 @@ type mockProcess struct {
 	cmdline string
 	name    string
 	pid     int32
+	exe     string
+	user    string
+	ppid    int32
+	env     []string
 }
 
 func (p mockProcess) Name() (string, error) {
@@ -17,3 +21,19 @@ func (p mockProcess) Cmd() (string, error) {
 func (p mockProcess) PID() int32 {
 	return p.pid
 }
+
+func (p mockProcess) Exe() (string, error) {
+	return p.exe, nil
+}
+
+func (p mockProcess) Username() (string, error) {
+	return p.user, nil
+}
+
+func (p mockProcess) PPID() (int32, error) {
+	return p.ppid, nil
+}
+
+func (p mockProcess) EnvNames() ([]string, error) {
+	return p.env, nil
+}
@@ -0,0 +1,53 @@
+package recipes
+
+import (
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// HasDiscoveryMatchers returns whether the recipe declares any process,
+// package or port matchers.
+func HasDiscoveryMatchers(r types.OpenInstallationRecipe) bool {
+	return len(r.ProcessMatch) > 0 || len(r.ProcessMatchRules) > 0 || len(r.PackageMatch) > 0 || len(r.PortMatch) > 0
+}
+
+// FindPackageMatches returns the installed packages whose names match one of
+// the recipe's packageMatch patterns.
+func FindPackageMatches(r types.OpenInstallationRecipe, m *types.DiscoveryManifest) []types.InstalledPackage {
+	matches := []types.InstalledPackage{}
+
+	for _, pattern := range r.PackageMatch {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Debugf("could not compile package pattern %s for recipe %s: %s", pattern, r.Name, err)
+			continue
+		}
+
+		for _, p := range m.InstalledPackages {
+			if re.MatchString(p.Name) {
+				matches = append(matches, p)
+			}
+		}
+	}
+
+	return matches
+}
+
+// FindPortMatches returns the listening ports that are one of the recipe's
+// portMatch ports.
+func FindPortMatches(r types.OpenInstallationRecipe, m *types.DiscoveryManifest) []types.ListeningPort {
+	matches := []types.ListeningPort{}
+
+	for _, port := range r.PortMatch {
+		for _, p := range m.ListeningPorts {
+			if int(p.Port) == port {
+				matches = append(matches, p)
+			}
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,241 @@
+package recipes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// processRule is a compiled processMatchRules entry.
+type processRule struct {
+	rule    types.OpenInstallationProcessMatchRule
+	cmdline *regexp.Regexp
+	exe     *regexp.Regexp
+	user    *regexp.Regexp
+	parent  *regexp.Regexp
+}
+
+func compileProcessRule(rule types.OpenInstallationProcessMatchRule) (*processRule, error) {
+	pr := &processRule{rule: rule}
+
+	patterns := []struct {
+		field   string
+		pattern string
+		re      **regexp.Regexp
+	}{
+		{"cmdline", rule.Cmdline, &pr.cmdline},
+		{"exe", rule.Exe, &pr.exe},
+		{"user", rule.User, &pr.user},
+		{"parent", rule.Parent, &pr.parent},
+	}
+
+	for _, p := range patterns {
+		if p.pattern == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(p.pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid regular expression: %s", p.field, err)
+		}
+
+		*p.re = re
+	}
+
+	return pr, nil
+}
+
+// DescribeProcessMatchRule returns a short description of a rule's criteria.
+func DescribeProcessMatchRule(rule types.OpenInstallationProcessMatchRule) string {
+	criteria := []string{}
+
+	for _, c := range []struct{ field, pattern string }{
+		{"cmdline", rule.Cmdline},
+		{"exe", rule.Exe},
+		{"user", rule.User},
+		{"parent", rule.Parent},
+	} {
+		if c.pattern != "" {
+			criteria = append(criteria, fmt.Sprintf("%s=~%q", c.field, c.pattern))
+		}
+	}
+
+	if len(rule.Env) > 0 {
+		criteria = append(criteria, fmt.Sprintf("env %s", strings.Join(rule.Env, ", ")))
+	}
+
+	if len(rule.Ports) > 0 {
+		ports := make([]string, len(rule.Ports))
+		for i, p := range rule.Ports {
+			ports[i] = fmt.Sprint(p)
+		}
+		criteria = append(criteria, fmt.Sprintf("listening on %s", strings.Join(ports, " or ")))
+	}
+
+	if rule.MinCount > 1 {
+		criteria = append(criteria, fmt.Sprintf("at least %d processes", rule.MinCount))
+	}
+
+	if len(criteria) == 0 {
+		return "any process"
+	}
+
+	return strings.Join(criteria, ", ")
+}
+
+// processRuleHasCriteria returns whether the rule restricts which processes
+// it matches.
+func processRuleHasCriteria(rule types.OpenInstallationProcessMatchRule) bool {
+	return rule.Cmdline != "" || rule.Exe != "" || rule.User != "" || rule.Parent != "" || len(rule.Env) > 0 || len(rule.Ports) > 0
+}
+
+// FindProcessRuleMatches returns the processes meeting the recipe's
+// processMatchRules.  A rule with a minCount only matches when at least that
+// many processes meet its other criteria.
+func FindProcessRuleMatches(r types.OpenInstallationRecipe, m *types.DiscoveryManifest) []types.MatchedProcess {
+	matches := []types.MatchedProcess{}
+
+	for i, rule := range r.ProcessMatchRules {
+		candidates := processRuleCandidates(r, i, m)
+
+		if len(candidates) < rule.MinCount {
+			log.Debugf("recipe %s rule %s matched %d processes, fewer than %d", r.Name, DescribeProcessMatchRule(rule), len(candidates), rule.MinCount)
+			continue
+		}
+
+		matches = append(matches, candidates...)
+	}
+
+	return matches
+}
+
+// processRuleCandidates returns the processes meeting a rule's criteria other
+// than its minCount, or none for a rule without criteria.
+func processRuleCandidates(r types.OpenInstallationRecipe, i int, m *types.DiscoveryManifest) []types.MatchedProcess {
+	rule := r.ProcessMatchRules[i]
+
+	// A rule without criteria would match every process, so it is ignored
+	// here as well as reported by the linter.
+	if !processRuleHasCriteria(rule) {
+		log.Debugf("ignoring processMatchRules[%d] for recipe %s, it has no criteria", i, r.Name)
+		return nil
+	}
+
+	pr, err := compileProcessRule(rule)
+	if err != nil {
+		log.Debugf("could not compile processMatchRules[%d] for recipe %s: %s", i, r.Name, err)
+		return nil
+	}
+
+	names := map[int32]string{}
+	for _, p := range m.DiscoveredProcesses {
+		if name, nameErr := p.Name(); nameErr == nil {
+			names[p.PID()] = name
+		}
+	}
+
+	description := DescribeProcessMatchRule(rule)
+	matches := []types.MatchedProcess{}
+
+	for _, p := range m.DiscoveredProcesses {
+		if pr.matches(p, m, names) {
+			matches = append(matches, types.MatchedProcess{
+				GenericProcess:  p,
+				MatchingPattern: description,
+				MatchingRecipe:  r,
+			})
+		}
+	}
+
+	return matches
+}
+
+// matches returns whether the process meets every criterion of the rule.
+// Criteria that cannot be read for the process are not met.
+func (pr *processRule) matches(p types.GenericProcess, m *types.DiscoveryManifest, names map[int32]string) bool {
+	cmd, err := p.Cmd()
+	if err != nil || newrelicInstallRegex.MatchString(cmd) {
+		return false
+	}
+
+	if pr.cmdline != nil && !pr.cmdline.MatchString(cmd) {
+		return false
+	}
+
+	if pr.exe != nil && !matchProcessValue(pr.exe, p.Exe) {
+		return false
+	}
+
+	if pr.user != nil && !matchProcessValue(pr.user, p.Username) {
+		return false
+	}
+
+	if pr.parent != nil && !matchParent(pr.parent, p, names) {
+		return false
+	}
+
+	return hasEnvNames(p, pr.rule.Env) && listensOnPort(p, pr.rule.Ports, m)
+}
+
+func matchProcessValue(re *regexp.Regexp, value func() (string, error)) bool {
+	v, err := value()
+	return err == nil && re.MatchString(v)
+}
+
+func matchParent(re *regexp.Regexp, p types.GenericProcess, names map[int32]string) bool {
+	ppid, err := p.PPID()
+	if err != nil {
+		return false
+	}
+
+	name, ok := names[ppid]
+	return ok && re.MatchString(name)
+}
+
+func hasEnvNames(p types.GenericProcess, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+
+	names, err := p.EnvNames()
+	if err != nil {
+		return false
+	}
+
+	set := map[string]bool{}
+	for _, n := range names {
+		set[n] = true
+	}
+
+	for _, n := range required {
+		if !set[n] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func listensOnPort(p types.GenericProcess, ports []int, m *types.DiscoveryManifest) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	for _, lp := range m.ListeningPorts {
+		if lp.PID != p.PID() {
+			continue
+		}
+
+		for _, port := range ports {
+			if int(lp.Port) == port {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+// +build unit
+
+package recipes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func givenJVMHost() *types.DiscoveryManifest {
+	return &types.DiscoveryManifest{
+		DiscoveredProcesses: []types.GenericProcess{
+			mockProcess{pid: 1, name: "systemd", cmdline: "/sbin/init"},
+			mockProcess{pid: 10, name: "supervisord", cmdline: "/usr/bin/supervisord"},
+			mockProcess{
+				pid:     100,
+				ppid:    1,
+				name:    "java",
+				cmdline: "java -jar app.jar",
+				exe:     "/usr/lib/jvm/java-11/bin/java",
+				user:    "orders",
+				env:     []string{"PATH", "SPRING_PROFILES_ACTIVE"},
+			},
+			mockProcess{
+				pid:     101,
+				ppid:    10,
+				name:    "java",
+				cmdline: "java -jar app.jar",
+				exe:     "/opt/jdk8/bin/java",
+				user:    "billing",
+				env:     []string{"PATH"},
+			},
+			mockProcess{pid: 200, name: "newrelic", cmdline: "/usr/local/bin/newrelic install -n java"},
+		},
+		ListeningPorts: []types.ListeningPort{
+			{Port: 8080, PID: 100},
+			{Port: 9090, PID: 101},
+		},
+	}
+}
+
+func matchedPIDs(matches []types.MatchedProcess) []int32 {
+	pids := []int32{}
+	for _, m := range matches {
+		pids = append(pids, m.PID())
+	}
+
+	return pids
+}
+
+func TestFindProcessRuleMatches(t *testing.T) {
+	m := givenJVMHost()
+
+	tests := map[string]struct {
+		rule     types.OpenInstallationProcessMatchRule
+		expected []int32
+	}{
+		"cmdline":    {types.OpenInstallationProcessMatchRule{Cmdline: "java"}, []int32{100, 101}},
+		"exe":        {types.OpenInstallationProcessMatchRule{Cmdline: "java", Exe: "java-11"}, []int32{100}},
+		"user":       {types.OpenInstallationProcessMatchRule{Cmdline: "java", User: "^billing$"}, []int32{101}},
+		"parent":     {types.OpenInstallationProcessMatchRule{Cmdline: "java", Parent: "supervisord"}, []int32{101}},
+		"env":        {types.OpenInstallationProcessMatchRule{Cmdline: "java", Env: []string{"SPRING_PROFILES_ACTIVE"}}, []int32{100}},
+		"ports":      {types.OpenInstallationProcessMatchRule{Cmdline: "java", Ports: []int{9090, 9091}}, []int32{101}},
+		"count":      {types.OpenInstallationProcessMatchRule{Cmdline: "java", MinCount: 2}, []int32{100, 101}},
+		"too few":    {types.OpenInstallationProcessMatchRule{Cmdline: "java", MinCount: 3}, []int32{}},
+		"none":       {types.OpenInstallationProcessMatchRule{Cmdline: "java", User: "root"}, []int32{}},
+		"invalid":    {types.OpenInstallationProcessMatchRule{Cmdline: "java("}, []int32{}},
+		"empty":      {types.OpenInstallationProcessMatchRule{}, []int32{}},
+		"count only": {types.OpenInstallationProcessMatchRule{MinCount: 1}, []int32{}},
+	}
+
+	for name, test := range tests {
+		r := types.OpenInstallationRecipe{
+			Name:              "test-recipe",
+			ProcessMatchRules: []types.OpenInstallationProcessMatchRule{test.rule},
+		}
+
+		require.Equal(t, test.expected, matchedPIDs(FindProcessRuleMatches(r, m)), name)
+	}
+}
+
+func TestProcessMatchRecipeFilterer_Rules(t *testing.T) {
+	recipe := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		ProcessMatchRules: []types.OpenInstallationProcessMatchRule{
+			{Cmdline: "java", User: "orders"},
+		},
+	}
+
+	f := NewProcessMatchRecipeFilterer()
+	require.False(t, f.Filter(context.Background(), &recipe, givenJVMHost()))
+
+	recipe.ProcessMatchRules[0].User = "shipping"
+	require.True(t, f.Filter(context.Background(), &recipe, givenJVMHost()))
+}
+
+func TestDescribeProcessMatchRule(t *testing.T) {
+	rule := types.OpenInstallationProcessMatchRule{
+		Cmdline:  "java",
+		User:     "orders",
+		Env:      []string{"A", "B"},
+		Ports:    []int{8080, 8443},
+		MinCount: 2,
+	}
+
+	require.Equal(t, `cmdline=~"java", user=~"orders", env A, B, listening on 8080 or 8443, at least 2 processes`, DescribeProcessMatchRule(rule))
+	require.Equal(t, "any process", DescribeProcessMatchRule(types.OpenInstallationProcessMatchRule{}))
+}
+
+func TestExplainMatches(t *testing.T) {
+	m := givenJVMHost()
+	m.InstalledPackages = []types.InstalledPackage{{Name: "openjdk-11-jre", Version: "11.0.13", Manager: "dpkg"}}
+
+	recipes := []types.OpenInstallationRecipe{
+		{
+			Name:         "java-agent",
+			ProcessMatch: []string{"java -jar"},
+			ProcessMatchRules: []types.OpenInstallationProcessMatchRule{
+				{Cmdline: "java", User: "orders"},
+				{Cmdline: "java", MinCount: 3},
+				{MinCount: 2},
+			},
+			PackageMatch: []string{"openjdk"},
+			PortMatch:    []int{5432},
+		},
+		{
+			Name: "infrastructure-agent-installer",
+		},
+	}
+
+	var b bytes.Buffer
+	ExplainMatches(context.Background(), &b, recipes, m)
+
+	expected := `
+Recipe matches:
+  - java-agent
+    processMatch "java -jar":
+      pid 100 java: java -jar app.jar
+      pid 101 java: java -jar app.jar
+    processMatchRules[0] cmdline=~"java", user=~"orders":
+      pid 100 java: java -jar app.jar
+    processMatchRules[1] cmdline=~"java", at least 3 processes:
+      pid 100 java: java -jar app.jar
+      pid 101 java: java -jar app.jar
+      not matched, 2 of at least 3 processes are running
+    processMatchRules[2] at least 2 processes:
+      ignored, the rule has no criteria
+    packageMatch "openjdk":
+      openjdk-11-jre 11.0.13 (dpkg)
+    portMatch 5432:
+      no process listening
+  - infrastructure-agent-installer: no process, package or port matchers
+`
+	require.Equal(t, expected, b.String())
+}
@@ -15,6 +15,7 @@ type RecipeFilterRunner struct {
 	availablilityFilters []RecipeFilterer
 	userSkippedFilters   []RecipeFilterer
 	installStatus        *execution.InstallStatus
+	filterReasons        map[string]string
 }
 
 func NewRecipeFilterRunner(ic types.InstallerContext, s *execution.InstallStatus) *RecipeFilterRunner {
@@ -26,6 +27,7 @@ func NewRecipeFilterRunner(ic types.InstallerContext, s *execution.InstallStatus
 
 	return &RecipeFilterRunner{
 		installStatus: s,
+		filterReasons: map[string]string{},
 		availablilityFilters: []RecipeFilterer{
 			NewProcessMatchRecipeFilterer(),
 			NewScriptEvaluationRecipeFilterer(),
@@ -41,6 +43,7 @@ func (rf *RecipeFilterRunner) RunFilter(ctx context.Context, r *types.OpenInstal
 		filtered := f.Filter(ctx, r, m)
 		if filtered {
 			log.Debugf("Filtering out unavailable recipe %s", r.Name)
+			rf.filterReasons[r.Name] = filterReason(f)
 			return true
 		}
 	}
@@ -58,14 +61,23 @@ func (rf *RecipeFilterRunner) RunFilter(ctx context.Context, r *types.OpenInstal
 
 		if filtered {
 			log.Debugf("Filtering out skipped recipe %s", r.Name)
+			rf.filterReasons[r.Name] = filterReason(f)
 			rf.installStatus.RecipeSkipped(execution.RecipeStatusEvent{Recipe: *r})
 			return true
 		}
 	}
 
+	delete(rf.filterReasons, r.Name)
+
 	return false
 }
 
+// FilterReasons returns the reason each recipe was filtered out by the filter
+// runs so far, keyed by recipe name.
+func (rf *RecipeFilterRunner) FilterReasons() map[string]string {
+	return rf.filterReasons
+}
+
 func (rf *RecipeFilterRunner) RunFilterAll(ctx context.Context, r []types.OpenInstallationRecipe, m *types.DiscoveryManifest) []types.OpenInstallationRecipe {
 	results := []types.OpenInstallationRecipe{}
 
@@ -106,6 +118,20 @@ type RecipeFilterer interface {
 	Filter(context.Context, *types.OpenInstallationRecipe, *types.DiscoveryManifest) bool
 }
 
+// RecipeFilterReasoner is implemented by filterers that can describe why they
+// filter out a recipe.
+type RecipeFilterReasoner interface {
+	Reason() string
+}
+
+func filterReason(f RecipeFilterer) string {
+	if r, ok := f.(RecipeFilterReasoner); ok {
+		return r.Reason()
+	}
+
+	return "filtered out"
+}
+
 type ProcessMatchRecipeFilterer struct {
 	processMatchFinder ProcessMatchFinder
 }
@@ -116,16 +142,33 @@ func NewProcessMatchRecipeFilterer() *ProcessMatchRecipeFilterer {
 	}
 }
 
+// Filter filters out recipes that declare process, package or port matchers
+// when none of them match the host.  Each kind of matcher is an alternative
+// to the others.
 func (f *ProcessMatchRecipeFilterer) Filter(ctx context.Context, r *types.OpenInstallationRecipe, m *types.DiscoveryManifest) bool {
-	matches := f.processMatchFinder.FindMatches(ctx, m.DiscoveredProcesses, *r)
+	if !HasDiscoveryMatchers(*r) {
+		return false
+	}
+
+	if len(f.processMatchFinder.FindMatches(ctx, m.DiscoveredProcesses, *r)) > 0 {
+		return false
+	}
 
-	filtered := len(r.ProcessMatch) > 0 && len(matches) == 0
+	if len(FindProcessRuleMatches(*r, m)) > 0 {
+		return false
+	}
 
-	if filtered {
-		log.Debugf("recipe %s not matching any process", r.Name)
+	if len(FindPackageMatches(*r, m)) > 0 || len(FindPortMatches(*r, m)) > 0 {
+		return false
 	}
 
-	return filtered
+	log.Debugf("recipe %s not matching any process, package or port", r.Name)
+
+	return true
+}
+
+func (f *ProcessMatchRecipeFilterer) Reason() string {
+	return "no running process, installed package or listening port matched the recipe"
 }
 
 type ScriptEvaluationRecipeFilterer struct {
@@ -149,6 +192,10 @@ func (f *ScriptEvaluationRecipeFilterer) Filter(ctx context.Context, r *types.Op
 	return false
 }
 
+func (f *ScriptEvaluationRecipeFilterer) Reason() string {
+	return "the recipe's discovery script did not succeed on this host"
+}
+
 type SkipFilterer struct {
 	*execution.InstallStatus
 	skipNames    []string
@@ -183,6 +230,10 @@ func (f *SkipFilterer) SkipKeywords(keywords ...string) {
 	f.skipKeywords = append(f.skipNames, keywords...)
 }
 
+func (f *SkipFilterer) Reason() string {
+	return "excluded by the provided install options"
+}
+
 func (f *SkipFilterer) Filter(ctx context.Context, r *types.OpenInstallationRecipe, m *types.DiscoveryManifest) bool {
 	if len(f.onlyNames) > 0 {
 		filtered := true
@@ -42,6 +42,53 @@ func TestRecommend_CustomScript_Failure(t *testing.T) {
 	require.True(t, filtered)
 }
 
+func TestFilterReasons(t *testing.T) {
+	recipe := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		PreInstall: types.OpenInstallationPreInstallConfiguration{
+			RequireAtDiscovery: "bogus command",
+		},
+	}
+
+	m := &types.DiscoveryManifest{}
+
+	r := NewRecipeFilterRunner(types.InstallerContext{}, &execution.InstallStatus{})
+
+	filtered := r.RunFilter(context.Background(), &recipe, m)
+	require.True(t, filtered)
+	require.Equal(t, "the recipe's discovery script did not succeed on this host", r.FilterReasons()["test-recipe"])
+
+	recipe.PreInstall.RequireAtDiscovery = "echo 1234"
+
+	filtered = r.RunFilter(context.Background(), &recipe, m)
+	require.False(t, filtered)
+	require.NotContains(t, r.FilterReasons(), "test-recipe")
+}
+
+func TestProcessMatchRecipeFilterer_PackagesAndPorts(t *testing.T) {
+	recipe := types.OpenInstallationRecipe{
+		Name:         "test-recipe",
+		ProcessMatch: []string{"mysqld"},
+		PackageMatch: []string{"^mysql-server"},
+		PortMatch:    []int{3306},
+	}
+
+	f := NewProcessMatchRecipeFilterer()
+
+	m := &types.DiscoveryManifest{}
+	require.True(t, f.Filter(context.Background(), &recipe, m))
+
+	m.InstalledPackages = []types.InstalledPackage{{Name: "mysql-server-8.0", Manager: "dpkg"}}
+	require.False(t, f.Filter(context.Background(), &recipe, m))
+
+	m = &types.DiscoveryManifest{
+		ListeningPorts: []types.ListeningPort{{Port: 3306, PID: 42}},
+	}
+	require.False(t, f.Filter(context.Background(), &recipe, m))
+
+	require.False(t, f.Filter(context.Background(), &types.OpenInstallationRecipe{Name: "no-matchers"}, &types.DiscoveryManifest{}))
+}
+
 func TestShouldGetRecipeFirstNameValid(t *testing.T) {
 	recipe := types.OpenInstallationRecipe{
 		Name:        "test-recipe",
@@ -0,0 +1,466 @@
+package recipes
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-task/task/v3/taskfile"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// LintSeverity is how serious a lint finding is.
+type LintSeverity string
+
+var LintSeverities = struct {
+	ERROR   LintSeverity
+	WARNING LintSeverity
+}{
+	ERROR:   "error",
+	WARNING: "warning",
+}
+
+// LintFinding is a problem found in a recipe definition.
+type LintFinding struct {
+	// Line is the line of the recipe file the finding refers to, or 0 if it
+	// does not refer to a specific line.
+	Line     int          `json:"line"`
+	Field    string       `json:"field,omitempty"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+}
+
+func (f LintFinding) String() string {
+	var b strings.Builder
+
+	if f.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", f.Line)
+	}
+
+	fmt.Fprintf(&b, "%s: ", f.Severity)
+
+	if f.Field != "" {
+		fmt.Fprintf(&b, "%s: ", f.Field)
+	}
+
+	b.WriteString(f.Message)
+
+	return b.String()
+}
+
+var (
+	yamlErrorLineRE = regexp.MustCompile(`line (\d+)`)
+	pathSegmentRE   = regexp.MustCompile(`^([^\[]*)((?:\[\d+\])*)$`)
+	pathIndexRE     = regexp.MustCompile(`\[(\d+)\]`)
+)
+
+// RecipeLinter checks recipe definitions for problems that would stop them
+// from being fetched, matched or executed.
+type RecipeLinter struct {
+	root     *yamlv3.Node
+	findings []LintFinding
+}
+
+func NewRecipeLinter() *RecipeLinter {
+	return &RecipeLinter{}
+}
+
+// LintFile lints the recipe definition in the given file.
+func (l *RecipeLinter) LintFile(path string) ([]LintFinding, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Lint(b), nil
+}
+
+// Lint returns the problems found in a recipe definition, ordered by line.
+func (l *RecipeLinter) Lint(b []byte) []LintFinding {
+	l.root = nil
+	l.findings = []LintFinding{}
+
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(b, &doc); err != nil {
+		l.findings = append(l.findings, LintFinding{
+			Line:     yamlErrorLine(err),
+			Severity: LintSeverities.ERROR,
+			Message:  err.Error(),
+		})
+		return l.findings
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yamlv3.MappingNode {
+		l.errorf("", "recipe must be a YAML map")
+		return l.findings
+	}
+
+	l.root = doc.Content[0]
+
+	var r types.OpenInstallationRecipe
+	if err := yaml.Unmarshal(b, &r); err != nil {
+		var fieldErr *types.RecipeFieldError
+		if errors.As(err, &fieldErr) {
+			l.errorf(fieldErr.Field, "%s", fieldErr.Message)
+		} else {
+			l.errorf("", "%s", err)
+		}
+
+		return l.sorted()
+	}
+
+	l.lintFields()
+	l.lintRequired(r)
+	l.lintInstallTargets(r)
+	l.lintEnums(r)
+	l.lintMatchers(r)
+	l.lintInputVars(r)
+	l.lintTask("install", r.Install)
+	l.lintTask("uninstall", r.Uninstall)
+	l.lintValidationNRQL(r)
+
+	return l.sorted()
+}
+
+// HasErrors returns whether any of the findings is an error.
+func HasErrors(findings []LintFinding) bool {
+	for _, f := range findings {
+		if f.Severity == LintSeverities.ERROR {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *RecipeLinter) lintFields() {
+	known := map[string]bool{}
+	t := reflect.TypeOf(types.OpenInstallationRecipe{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		known[name] = true
+	}
+
+	for i := 0; i+1 < len(l.root.Content); i += 2 {
+		key := l.root.Content[i]
+		if !known[key.Value] {
+			l.add(key.Line, key.Value, LintSeverities.WARNING, "unknown field")
+		}
+	}
+}
+
+func (l *RecipeLinter) lintRequired(r types.OpenInstallationRecipe) {
+	required := []struct {
+		field string
+		value string
+	}{
+		{"name", r.Name},
+		{"displayName", r.DisplayName},
+		{"install", r.Install},
+	}
+
+	for _, f := range required {
+		if f.value == "" {
+			l.errorf(f.field, "required field is missing")
+		}
+	}
+
+	if r.Description == "" {
+		l.warnf("description", "field is missing")
+	}
+
+	if r.ValidationNRQL == "" {
+		l.warnf("validationNrql", "field is missing, so installs cannot be validated")
+	}
+
+	if len(r.InstallTargets) == 0 {
+		l.warnf("installTargets", "field is missing, so the recipe is not matched to any host")
+	}
+}
+
+func (l *RecipeLinter) lintInstallTargets(r types.OpenInstallationRecipe) {
+	for i, t := range r.InstallTargets {
+		field := fmt.Sprintf("installTargets[%d]", i)
+
+		if t.Type == "" {
+			l.errorf(field+".type", "required field is missing")
+		}
+
+		l.checkEnum(field+".type", string(t.Type), types.OpenInstallationTargetTypeTypes)
+		l.checkEnum(field+".os", string(t.Os), types.OpenInstallationOperatingSystemTypes)
+		l.checkEnum(field+".platform", string(t.Platform), types.OpenInstallationPlatformTypes)
+		l.checkEnum(field+".platformFamily", string(t.PlatformFamily), types.OpenInstallationPlatformFamilyTypes)
+	}
+}
+
+func (l *RecipeLinter) lintEnums(r types.OpenInstallationRecipe) {
+	l.checkEnum("stability", string(r.Stability), types.OpenInstallationStabilityTypes)
+	l.checkEnum("successLinkConfig.type", string(r.SuccessLinkConfig.Type), types.OpenInstallationSuccessLinkTypeTypes)
+	l.checkEnum("quickstarts.category", string(r.Quickstarts.Category), types.OpenInstallationCategoryTypes)
+
+	for i, p := range r.ObservabilityPacks {
+		l.checkEnum(fmt.Sprintf("observabilityPacks[%d].level", i), string(p.Level), types.OpenInstallationObservabilityPackLevelTypes)
+	}
+}
+
+func (l *RecipeLinter) lintMatchers(r types.OpenInstallationRecipe) {
+	for i, p := range r.ProcessMatch {
+		if _, err := regexp.Compile(p); err != nil {
+			l.errorf(fmt.Sprintf("processMatch[%d]", i), "invalid regular expression: %s", err)
+		}
+	}
+
+	for i, p := range r.PackageMatch {
+		if _, err := regexp.Compile(p); err != nil {
+			l.errorf(fmt.Sprintf("packageMatch[%d]", i), "invalid regular expression: %s", err)
+		}
+	}
+
+	for i, p := range r.PortMatch {
+		if p < 1 || p > 65535 {
+			l.errorf(fmt.Sprintf("portMatch[%d]", i), "%d is not a valid TCP port", p)
+		}
+	}
+
+	for i, rule := range r.ProcessMatchRules {
+		l.lintProcessMatchRule(fmt.Sprintf("processMatchRules[%d]", i), rule)
+	}
+}
+
+func (l *RecipeLinter) lintProcessMatchRule(field string, rule types.OpenInstallationProcessMatchRule) {
+	if _, err := compileProcessRule(rule); err != nil {
+		l.errorf(field, "%s", err)
+	}
+
+	for i, p := range rule.Ports {
+		if p < 1 || p > 65535 {
+			l.errorf(fmt.Sprintf("%s.ports[%d]", field, i), "%d is not a valid TCP port", p)
+		}
+	}
+
+	if rule.MinCount < 0 {
+		l.errorf(field+".minCount", "must not be negative")
+	}
+
+	if !processRuleHasCriteria(rule) {
+		l.warnf(field, "rule has no criteria, so it matches any process")
+	}
+}
+
+func (l *RecipeLinter) lintInputVars(r types.OpenInstallationRecipe) {
+	for i, v := range r.InputVars {
+		if v.Name == "" {
+			l.errorf(fmt.Sprintf("inputVars[%d].name", i), "required field is missing")
+		}
+	}
+}
+
+// lintTask checks a go-task definition the way the recipe executor will run
+// it: as a version 3 taskfile whose default task is run.
+func (l *RecipeLinter) lintTask(field string, task string) {
+	if task == "" {
+		return
+	}
+
+	var tf taskfile.Taskfile
+	if err := yaml.Unmarshal([]byte(task), &tf); err != nil {
+		l.errorf(field, "invalid taskfile: %s", err)
+		return
+	}
+
+	if tf.Version == "" {
+		l.errorf(field+".version", "required field is missing")
+	} else if v, err := tf.ParsedVersion(); err != nil || v < 3 {
+		l.errorf(field+".version", "taskfile version must be 3 or later, got %q", tf.Version)
+	}
+
+	if len(tf.Tasks) == 0 {
+		l.errorf(field+".tasks", "no tasks are defined")
+		return
+	}
+
+	if _, ok := tf.Tasks["default"]; !ok {
+		l.errorf(field+".tasks", "a default task is required, since it is the task that is run")
+	}
+
+	names := make([]string, 0, len(tf.Tasks))
+	for name := range tf.Tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		l.lintTaskReferences(fmt.Sprintf("%s.tasks.%s", field, name), tf.Tasks[name], tf.Tasks)
+	}
+}
+
+func (l *RecipeLinter) lintTaskReferences(field string, t *taskfile.Task, tasks taskfile.Tasks) {
+	if t == nil {
+		l.errorf(field, "task is empty")
+		return
+	}
+
+	refs := []string{}
+	for _, c := range t.Cmds {
+		if c != nil && c.Task != "" {
+			refs = append(refs, c.Task)
+		}
+	}
+
+	for _, d := range t.Deps {
+		if d != nil && d.Task != "" {
+			refs = append(refs, d.Task)
+		}
+	}
+
+	for _, ref := range refs {
+		// Tasks of included taskfiles are namespaced, and cannot be checked here.
+		if strings.Contains(ref, ":") {
+			continue
+		}
+
+		if _, ok := tasks[ref]; !ok {
+			l.errorf(field, "refers to task %s, which is not defined", ref)
+		}
+	}
+}
+
+// lintValidationNRQL checks that the validation query is a template that the
+// recipe validator can render.
+func (l *RecipeLinter) lintValidationNRQL(r types.OpenInstallationRecipe) {
+	if r.ValidationNRQL == "" {
+		return
+	}
+
+	tmpl, err := template.New("validationNRQL").Parse(string(r.ValidationNRQL))
+	if err != nil {
+		l.errorf("validationNrql", "invalid template: %s", err)
+		return
+	}
+
+	v := struct {
+		HOSTNAME string
+	}{}
+
+	if err = tmpl.Execute(ioutil.Discard, v); err != nil {
+		l.errorf("validationNrql", "invalid template, only {{.HOSTNAME}} is available: %s", err)
+	}
+}
+
+// checkEnum reports a value that is not one of the values of an enum types
+// struct, such as types.OpenInstallationOperatingSystemTypes.
+func (l *RecipeLinter) checkEnum(field string, value string, enum interface{}) {
+	if value == "" {
+		return
+	}
+
+	allowed := []string{}
+	v := reflect.ValueOf(enum)
+	for i := 0; i < v.NumField(); i++ {
+		s := v.Field(i).String()
+		if s == value {
+			return
+		}
+
+		allowed = append(allowed, s)
+	}
+
+	l.errorf(field, "unknown value %s, expected one of %s", value, strings.Join(allowed, ", "))
+}
+
+func (l *RecipeLinter) errorf(field string, format string, a ...interface{}) {
+	l.add(l.line(field), field, LintSeverities.ERROR, fmt.Sprintf(format, a...))
+}
+
+func (l *RecipeLinter) warnf(field string, format string, a ...interface{}) {
+	l.add(l.line(field), field, LintSeverities.WARNING, fmt.Sprintf(format, a...))
+}
+
+func (l *RecipeLinter) add(line int, field string, severity LintSeverity, msg string) {
+	l.findings = append(l.findings, LintFinding{
+		Line:     line,
+		Field:    field,
+		Severity: severity,
+		Message:  msg,
+	})
+}
+
+func (l *RecipeLinter) sorted() []LintFinding {
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		return l.findings[i].Line < l.findings[j].Line
+	})
+
+	return l.findings
+}
+
+// line returns the line of the field at the given path, such as
+// installTargets[0].os.  If the field is missing, the line of the nearest
+// parent that exists is returned.
+func (l *RecipeLinter) line(path string) int {
+	if l.root == nil || path == "" {
+		return 0
+	}
+
+	node := l.root
+	line := 0
+
+	for _, segment := range strings.Split(path, ".") {
+		m := pathSegmentRE.FindStringSubmatch(segment)
+		if m == nil {
+			return line
+		}
+
+		if m[1] != "" {
+			key, value := mappingValue(node, m[1])
+			if key == nil {
+				return line
+			}
+
+			node, line = value, key.Line
+		}
+
+		for _, idx := range pathIndexRE.FindAllStringSubmatch(m[2], -1) {
+			i, _ := strconv.Atoi(idx[1])
+			if node.Kind != yamlv3.SequenceNode || i >= len(node.Content) {
+				return line
+			}
+
+			node, line = node.Content[i], node.Content[i].Line
+		}
+	}
+
+	return line
+}
+
+func mappingValue(node *yamlv3.Node, key string) (*yamlv3.Node, *yamlv3.Node) {
+	if node.Kind != yamlv3.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+func yamlErrorLine(err error) int {
+	m := yamlErrorLineRE.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
@@ -0,0 +1,196 @@
+//go:build unit
+// +build unit
+
+package recipes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var validLintRecipe = `name: test-recipe
+displayName: Test Recipe
+description: A recipe for testing
+installTargets:
+  - type: HOST
+    os: LINUX
+processMatch:
+  - mysqld
+validationNrql: "SELECT count(*) FROM SystemSample WHERE hostname LIKE '{{.HOSTNAME}}%'"
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - task: setup
+    setup:
+      cmds:
+        - echo setup
+`
+
+func findingAt(findings []LintFinding, field string) *LintFinding {
+	for i, f := range findings {
+		if f.Field == field {
+			return &findings[i]
+		}
+	}
+
+	return nil
+}
+
+func TestLint_Valid(t *testing.T) {
+	findings := NewRecipeLinter().Lint([]byte(validLintRecipe))
+	require.Empty(t, findings)
+	require.False(t, HasErrors(findings))
+}
+
+func TestLint_SyntaxError(t *testing.T) {
+	findings := NewRecipeLinter().Lint([]byte("name: test\ninstallTargets:\n  - type: HOST\n os: LINUX\n"))
+	require.Equal(t, 1, len(findings))
+	require.True(t, HasErrors(findings))
+	require.NotZero(t, findings[0].Line)
+}
+
+func TestLint_NotAMap(t *testing.T) {
+	findings := NewRecipeLinter().Lint([]byte("- name: test\n"))
+	require.True(t, HasErrors(findings))
+}
+
+func TestLint_MalformedField(t *testing.T) {
+	findings := NewRecipeLinter().Lint([]byte("name: test\nkeywords:\n  - mysql\n  - 3\n"))
+	require.Equal(t, 1, len(findings))
+
+	f := findingAt(findings, "keywords[1]")
+	require.NotNil(t, f)
+	require.Equal(t, 4, f.Line)
+	require.Equal(t, LintSeverities.ERROR, f.Severity)
+}
+
+func TestLint_Schema(t *testing.T) {
+	recipe := `name: test-recipe
+installTargets:
+  - type: HOST
+    os: LINUX
+  - os: MARS
+processMatch:
+  - "mysqld("
+processMatchRules:
+  - user: "svc("
+    ports: [0]
+inputVars:
+  - prompt: Password
+stability: SOLID
+unknownField: true
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo install
+`
+
+	findings := NewRecipeLinter().Lint([]byte(recipe))
+	require.True(t, HasErrors(findings))
+
+	expected := map[string]int{
+		"displayName":                   0,
+		"description":                   0,
+		"validationNrql":                0,
+		"installTargets[1].type":        5,
+		"installTargets[1].os":          5,
+		"processMatch[0]":               7,
+		"processMatchRules[0]":          9,
+		"processMatchRules[0].ports[0]": 10,
+		"inputVars[0].name":             12,
+		"stability":                     13,
+		"unknownField":                  14,
+	}
+
+	for field, line := range expected {
+		f := findingAt(findings, field)
+		require.NotNil(t, f, field)
+		require.Equal(t, line, f.Line, field)
+	}
+
+	require.Equal(t, LintSeverities.WARNING, findingAt(findings, "description").Severity)
+	require.Equal(t, LintSeverities.WARNING, findingAt(findings, "unknownField").Severity)
+	require.Equal(t, LintSeverities.ERROR, findingAt(findings, "installTargets[1].os").Severity)
+
+	for i := 1; i < len(findings); i++ {
+		require.LessOrEqual(t, findings[i-1].Line, findings[i].Line)
+	}
+}
+
+func TestLint_Install(t *testing.T) {
+	recipe := `name: test-recipe
+displayName: Test Recipe
+description: A recipe for testing
+installTargets:
+  - type: HOST
+validationNrql: "SELECT count(*) FROM SystemSample"
+install:
+  version: "2"
+  tasks:
+    setup:
+      deps: [missing]
+      cmds:
+        - echo setup
+uninstall:
+  version: "3"
+`
+
+	findings := NewRecipeLinter().Lint([]byte(recipe))
+
+	f := findingAt(findings, "install.version")
+	require.NotNil(t, f)
+	require.Equal(t, 8, f.Line)
+
+	f = findingAt(findings, "install.tasks")
+	require.NotNil(t, f)
+	require.Contains(t, f.Message, "default task")
+
+	f = findingAt(findings, "install.tasks.setup")
+	require.NotNil(t, f)
+	require.Equal(t, 10, f.Line)
+	require.Contains(t, f.Message, "missing")
+
+	f = findingAt(findings, "uninstall.tasks")
+	require.NotNil(t, f)
+	require.Equal(t, 14, f.Line)
+}
+
+func TestLint_ValidationNRQL(t *testing.T) {
+	recipe := `name: test-recipe
+displayName: Test Recipe
+install: |
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo install
+validationNrql: "SELECT count(*) FROM SystemSample WHERE hostname = '{{.HOST}}'"
+`
+
+	findings := NewRecipeLinter().Lint([]byte(recipe))
+
+	f := findingAt(findings, "validationNrql")
+	require.NotNil(t, f)
+	require.Equal(t, 9, f.Line)
+	require.Equal(t, LintSeverities.ERROR, f.Severity)
+
+	findings = NewRecipeLinter().Lint([]byte(`name: test-recipe
+validationNrql: "SELECT count(*) FROM SystemSample WHERE hostname = '{{.HOSTNAME'"
+`))
+	f = findingAt(findings, "validationNrql")
+	require.NotNil(t, f)
+	require.Contains(t, f.Message, "invalid template")
+}
+
+func TestLintFinding_String(t *testing.T) {
+	f := LintFinding{Line: 3, Field: "name", Severity: LintSeverities.ERROR, Message: "required field is missing"}
+	require.Equal(t, "line 3: error: name: required field is missing", f.String())
+
+	f = LintFinding{Severity: LintSeverities.WARNING, Message: "bad"}
+	require.Equal(t, "warning: bad", f.String())
+}
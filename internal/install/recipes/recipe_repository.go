@@ -11,6 +11,8 @@ import (
 )
 
 var (
+	cloudProvider   string = "CloudProvider"
+	container       string = "Container"
 	kernelArch      string = "KernelArch"
 	kernelVersion   string = "KernelVersion"
 	oS              string = "OS"
@@ -166,6 +168,8 @@ func matchRecipeCriteria(hostMap map[string]string, rkey string, rvalue string)
 
 func getHostMap(m types.DiscoveryManifest) map[string]string {
 	hostMap := map[string]string{
+		cloudProvider:   m.Cloud.Provider,
+		container:       m.ContainerType(),
 		kernelArch:      m.KernelArch,
 		kernelVersion:   m.KernelVersion,
 		oS:              m.OS,
@@ -178,6 +182,8 @@ func getHostMap(m types.DiscoveryManifest) map[string]string {
 
 func getRecipeTargetMap(rit types.OpenInstallationRecipeInstallTarget) map[string]string {
 	targetMap := map[string]string{
+		cloudProvider:   rit.CloudProvider,
+		container:       rit.Container,
 		kernelArch:      rit.KernelArch,
 		kernelVersion:   rit.KernelVersion,
 		oS:              string(rit.Os),
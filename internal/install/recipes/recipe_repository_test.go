@@ -136,6 +136,28 @@ func Test_ShouldOrderInfraLogFirst(t *testing.T) {
 	require.Equal(t, results[2].Name, "a-recipe")
 }
 
+func Test_ShouldFindCloudAndContainerRecipes(t *testing.T) {
+	Setup()
+	r := createRecipe("id1", "aws-host-recipe")
+	r.InstallTargets = []types.OpenInstallationRecipeInstallTarget{{CloudProvider: "aws", Container: "none"}}
+	recipeCache = append(recipeCache, *r)
+
+	r = createRecipe("id2", "kubernetes-recipe")
+	r.InstallTargets = []types.OpenInstallationRecipeInstallTarget{{Container: "kubernetes"}}
+	recipeCache = append(recipeCache, *r)
+
+	discoveryManifest.Cloud.Provider = "aws"
+	results, _ := repository.FindAll(discoveryManifest)
+	require.Len(t, results, 1)
+	require.Equal(t, "id1", results[0].ID)
+
+	discoveryManifest.ContainerRuntime = "containerd"
+	discoveryManifest.Kubernetes = true
+	results, _ = repository.FindAll(discoveryManifest)
+	require.Len(t, results, 1)
+	require.Equal(t, "id2", results[0].ID)
+}
+
 func Test_matchRecipeCriteria_Basic(t *testing.T) {
 	Setup()
 	discoveryManifest.Platform = "linux"
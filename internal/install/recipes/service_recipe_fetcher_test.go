@@ -52,6 +52,78 @@ func TestFetchFilters(t *testing.T) {
 	require.True(t, reflect.DeepEqual(r, recipes))
 }
 
+func TestFetchRecipes_UninstallFromFile(t *testing.T) {
+	r := []types.OpenInstallationRecipe{
+		{
+			Name:    "test",
+			Install: "install",
+			File: `
+name: test
+uninstall:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo uninstall
+`,
+		},
+	}
+
+	c := NewMockNerdGraphClient()
+	c.RespBody = wrapRecipes(r)
+
+	s := NewServiceRecipeFetcher(c)
+
+	recipes, err := s.FetchRecipes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(recipes))
+	require.Contains(t, recipes[0].Uninstall, "echo uninstall")
+}
+
+func TestFetchRecipes_FieldsFromFile(t *testing.T) {
+	r := []types.OpenInstallationRecipe{
+		{
+			Name:    "test",
+			Install: "install",
+			InstallTargets: []types.OpenInstallationRecipeInstallTarget{
+				{Type: types.OpenInstallationTargetTypeTypes.HOST, Os: types.OpenInstallationOperatingSystemTypes.LINUX},
+			},
+			File: `
+name: test
+installTargets:
+  - type: host
+    os: linux
+    cloudProvider: aws
+    container: none
+packageMatch:
+  - mysql-server
+portMatch:
+  - 3306
+processMatchRules:
+  - exe: mysqld$
+    minCount: 2
+`,
+		},
+	}
+
+	c := NewMockNerdGraphClient()
+	c.RespBody = wrapRecipes(r)
+
+	s := NewServiceRecipeFetcher(c)
+
+	recipes, err := s.FetchRecipes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(recipes))
+	require.Equal(t, []string{"mysql-server"}, recipes[0].PackageMatch)
+	require.Equal(t, []int{3306}, recipes[0].PortMatch)
+	require.Equal(t, []types.OpenInstallationProcessMatchRule{{Exe: "mysqld$", MinCount: 2}}, recipes[0].ProcessMatchRules)
+	require.Equal(t, 1, len(recipes[0].InstallTargets))
+	require.Equal(t, types.OpenInstallationTargetTypeTypes.HOST, recipes[0].InstallTargets[0].Type)
+	require.Equal(t, types.OpenInstallationOperatingSystemTypes.LINUX, recipes[0].InstallTargets[0].Os)
+	require.Equal(t, "aws", recipes[0].InstallTargets[0].CloudProvider)
+	require.Equal(t, "none", recipes[0].InstallTargets[0].Container)
+}
+
 func wrapRecipes(r []types.OpenInstallationRecipe) recipeSearchQueryResult {
 	return recipeSearchQueryResult{
 		Docs: recipeSearchQueryDocs{
@@ -0,0 +1,94 @@
+package install
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/output"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var (
+	statusRecipeNames []string
+	statusHistory     bool
+)
+
+// ledgerStatus is a ledger entry as shown by the status command.
+type ledgerStatus struct {
+	Recipe     string                     `json:"recipe"`
+	Status     execution.RecipeStatusType `json:"status"`
+	Time       string                     `json:"time"`
+	EntityGUID string                     `json:"entityGuid,omitempty"`
+	RecipeHash string                     `json:"recipeHash,omitempty"`
+	Message    string                     `json:"message,omitempty"`
+	CLIVersion string                     `json:"cliVersion,omitempty"`
+	Vars       types.RecipeVars           `json:"vars,omitempty"`
+}
+
+var cmdStatus = &cobra.Command{
+	Use:   "status",
+	Short: "Show what has been installed on this host.",
+	Long: `Show what has been installed on this host
+
+The status command reads the local install ledger, which records the outcome
+of each recipe run by the install command on this host, including the recipe
+hash, entity GUID and the variables it ran with.  Secret variables are
+redacted.  No network access is needed.
+`,
+	Example: "newrelic install status --recipe infrastructure-agent-installer --history",
+	Run: func(cmd *cobra.Command, args []string) {
+		ledger := execution.NewLedger(execution.DefaultLedgerPath())
+
+		statuses, err := ledgerStatuses(ledger, statusRecipeNames, statusHistory)
+		utils.LogIfFatal(err)
+
+		utils.LogIfFatal(output.Print(statuses))
+	},
+}
+
+// ledgerStatuses returns the latest ledger entry for each recipe, or every
+// entry when history is requested, optionally limited to the named recipes.
+func ledgerStatuses(ledger *execution.Ledger, recipeNames []string, history bool) ([]ledgerStatus, error) {
+	var entries []execution.LedgerEntry
+	var err error
+
+	if history {
+		entries, err = ledger.Entries()
+	} else {
+		entries, err = ledger.LatestByRecipe()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []ledgerStatus{}
+	for _, e := range entries {
+		if len(recipeNames) > 0 && !utils.StringInSlice(e.Recipe, recipeNames) {
+			continue
+		}
+
+		statuses = append(statuses, ledgerStatus{
+			Recipe:     e.Recipe,
+			Status:     e.Status,
+			Time:       time.Unix(e.Timestamp, 0).Format(time.RFC3339),
+			EntityGUID: e.EntityGUID,
+			RecipeHash: e.RecipeHash,
+			Message:    e.Message,
+			CLIVersion: e.CLIVersion,
+			Vars:       e.Vars,
+		})
+	}
+
+	return statuses, nil
+}
+
+func init() {
+	Command.AddCommand(cmdStatus)
+
+	cmdStatus.Flags().StringSliceVarP(&statusRecipeNames, "recipe", "n", []string{}, "only show the named recipes")
+	cmdStatus.Flags().BoolVar(&statusHistory, "history", false, "show every recorded run rather than the latest for each recipe")
+}
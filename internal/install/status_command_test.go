@@ -0,0 +1,38 @@
+// +build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+)
+
+func TestLedgerStatuses(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	require.NoError(t, l.Append(execution.LedgerEntry{Recipe: testRecipeName, Status: execution.RecipeStatusTypes.FAILED, Timestamp: 1}))
+	require.NoError(t, l.Append(execution.LedgerEntry{Recipe: anotherTestRecipeName, Status: execution.RecipeStatusTypes.SKIPPED, Timestamp: 2}))
+	require.NoError(t, l.Append(execution.LedgerEntry{
+		Recipe:     testRecipeName,
+		Status:     execution.RecipeStatusTypes.INSTALLED,
+		Timestamp:  3,
+		EntityGUID: "testGUID",
+	}))
+
+	statuses, err := ledgerStatuses(l, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(statuses))
+	require.Equal(t, execution.RecipeStatusTypes.INSTALLED, statuses[0].Status)
+	require.Equal(t, "testGUID", statuses[0].EntityGUID)
+	require.NotEmpty(t, statuses[0].Time)
+
+	statuses, err = ledgerStatuses(l, []string{testRecipeName}, true)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(statuses))
+	require.Equal(t, execution.RecipeStatusTypes.FAILED, statuses[0].Status)
+	require.Equal(t, execution.RecipeStatusTypes.INSTALLED, statuses[1].Status)
+}
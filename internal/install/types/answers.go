@@ -0,0 +1,139 @@
+package types
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Answers holds responses to the questions asked during an install, so that an
+// install can run where there is no terminal to prompt on.
+//
+//	recipes:
+//	  - infrastructure-agent-installer
+//	  - mysql-open-source-integration
+//	variables:
+//	  mysql-open-source-integration:
+//	    NR_CLI_DB_USERNAME: newrelic
+//	acceptLogFiles: true
+//	packs:
+//	  - MySQL
+type Answers struct {
+	// Recipes are the names of the recommended recipes to install.  When
+	// omitted, all recommended recipes are installed.
+	Recipes []string `yaml:"recipes"`
+	// Variables are values for recipe input variables, keyed by recipe name.
+	Variables map[string]map[string]string `yaml:"variables"`
+	// AcceptLogFiles answers whether discovered log files should be watched.
+	// When omitted, discovered log files are accepted.
+	AcceptLogFiles *bool `yaml:"acceptLogFiles"`
+	// Packs are the names of the observability packs to install.  When
+	// omitted, all packs for the installed recipes are installed.
+	Packs []string `yaml:"packs"`
+}
+
+// LoadAnswers reads an answers file.  Unknown keys are rejected, so that a
+// misspelled key does not silently fall back to a default.
+func LoadAnswers(path string) (*Answers, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read answers file %s: %s", path, err)
+	}
+
+	var a Answers
+	if err = yaml.UnmarshalStrict(b, &a); err != nil {
+		return nil, fmt.Errorf("could not parse answers file %s: %s", path, err)
+	}
+
+	return &a, nil
+}
+
+// Variable returns the answer for the named input variable of a recipe.
+func (a *Answers) Variable(recipeName string, name string) (string, bool) {
+	if a == nil {
+		return "", false
+	}
+
+	v, ok := a.Variables[recipeName][name]
+	return v, ok
+}
+
+// IncludesRecipe returns whether the recipe was selected for install, matching
+// either its name or display name.
+func (a *Answers) IncludesRecipe(r OpenInstallationRecipe) bool {
+	if a == nil || a.Recipes == nil {
+		return true
+	}
+
+	for _, n := range a.Recipes {
+		if strings.EqualFold(n, r.Name) || strings.EqualFold(n, r.DisplayName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IncludesPack returns whether the observability pack was selected for install.
+func (a *Answers) IncludesPack(name string) bool {
+	if a == nil || a.Packs == nil {
+		return true
+	}
+
+	for _, n := range a.Packs {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the answers against the recipes that are about to be
+// installed.  Every input variable must be answered, set in the environment or
+// have a default, and every answered variable must be declared by its recipe.
+// All problems are reported at once.
+func (a *Answers) Validate(recipes []OpenInstallationRecipe) error {
+	if a == nil {
+		return nil
+	}
+
+	problems := []string{}
+
+	for _, r := range recipes {
+		declared := map[string]bool{}
+		for _, v := range r.InputVars {
+			declared[v.Name] = true
+
+			if _, ok := a.Variable(r.Name, v.Name); ok {
+				continue
+			}
+
+			if os.Getenv(v.Name) == "" && v.Default == "" {
+				problems = append(problems, fmt.Sprintf("no answer for variable %s of recipe %s, and it has no default", v.Name, r.Name))
+			}
+		}
+
+		names := []string{}
+		for name := range a.Variables[r.Name] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if !declared[name] {
+				problems = append(problems, fmt.Sprintf("variable %s is not an input variable of recipe %s", name, r.Name))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid answers file:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+// +build unit
+
+package types
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var answersRecipe = OpenInstallationRecipe{
+	Name:        "mysql-open-source-integration",
+	DisplayName: "MySQL Integration",
+	InputVars: []OpenInstallationRecipeInputVariable{
+		{Name: "NR_CLI_DB_USERNAME"},
+		{Name: "NR_CLI_DB_PORT", Default: "3306"},
+	},
+}
+
+func TestLoadAnswers(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "answers.yaml")
+	err = ioutil.WriteFile(path, []byte(`
+recipes:
+  - mysql-open-source-integration
+variables:
+  mysql-open-source-integration:
+    NR_CLI_DB_USERNAME: newrelic
+acceptLogFiles: false
+packs: []
+`), 0644)
+	require.NoError(t, err)
+
+	a, err := LoadAnswers(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mysql-open-source-integration"}, a.Recipes)
+	require.False(t, *a.AcceptLogFiles)
+	require.NotNil(t, a.Packs)
+	require.False(t, a.IncludesPack("MySQL"))
+
+	v, ok := a.Variable("mysql-open-source-integration", "NR_CLI_DB_USERNAME")
+	require.True(t, ok)
+	require.Equal(t, "newrelic", v)
+}
+
+func TestLoadAnswers_UnknownKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "answers.yaml")
+	err = ioutil.WriteFile(path, []byte("recipe:\n  - mysql\n"), 0644)
+	require.NoError(t, err)
+
+	_, err = LoadAnswers(path)
+	require.Error(t, err)
+}
+
+func TestAnswers_Nil(t *testing.T) {
+	var a *Answers
+
+	_, ok := a.Variable("mysql-open-source-integration", "NR_CLI_DB_USERNAME")
+	require.False(t, ok)
+	require.True(t, a.IncludesRecipe(answersRecipe))
+	require.True(t, a.IncludesPack("MySQL"))
+	require.NoError(t, a.Validate([]OpenInstallationRecipe{answersRecipe}))
+}
+
+func TestAnswers_IncludesRecipe(t *testing.T) {
+	a := &Answers{}
+	require.True(t, a.IncludesRecipe(answersRecipe))
+
+	a.Recipes = []string{"mysql integration"}
+	require.True(t, a.IncludesRecipe(answersRecipe))
+
+	a.Recipes = []string{"apache-open-source-integration"}
+	require.False(t, a.IncludesRecipe(answersRecipe))
+}
+
+func TestAnswers_Validate(t *testing.T) {
+	a := &Answers{
+		Variables: map[string]map[string]string{
+			"mysql-open-source-integration": {"NR_CLI_DB_USERNAME": "newrelic"},
+		},
+	}
+	require.NoError(t, a.Validate([]OpenInstallationRecipe{answersRecipe}))
+
+	a.Variables["mysql-open-source-integration"]["NR_CLI_DB_HOST"] = "localhost"
+	err := a.Validate([]OpenInstallationRecipe{answersRecipe})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "variable NR_CLI_DB_HOST is not an input variable of recipe mysql-open-source-integration")
+
+	a.Variables = nil
+	err = a.Validate([]OpenInstallationRecipe{answersRecipe})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no answer for variable NR_CLI_DB_USERNAME")
+
+	os.Setenv("NR_CLI_DB_USERNAME", "fromEnv")
+	defer os.Unsetenv("NR_CLI_DB_USERNAME")
+	require.NoError(t, a.Validate([]OpenInstallationRecipe{answersRecipe}))
+}
@@ -17,7 +17,35 @@ type DiscoveryManifest struct {
 	PlatformVersion     string           `json:"platformVersion"`
 	IsUnsupported       bool             `json:"isUnsupported"`
 	MatchedProcesses    []MatchedProcess `json:"processes"`
-	DiscoveredProcesses []GenericProcess
+	DiscoveredProcesses []GenericProcess `json:"-"`
+	// ContainerRuntime is the container runtime the CLI runs under, if any.
+	ContainerRuntime string `json:"containerRuntime,omitempty"`
+	// Kubernetes is whether the CLI runs in a Kubernetes pod.
+	Kubernetes        bool               `json:"kubernetes,omitempty"`
+	Cloud             CloudMetadata      `json:"cloud,omitempty"`
+	InstalledPackages []InstalledPackage `json:"-"`
+	ListeningPorts    []ListeningPort    `json:"-"`
+}
+
+// CloudMetadata describes the cloud instance the host runs on.
+type CloudMetadata struct {
+	Provider     string `json:"provider,omitempty"`
+	InstanceID   string `json:"instanceId,omitempty"`
+	InstanceType string `json:"instanceType,omitempty"`
+}
+
+// InstalledPackage is a package installed by the host's package manager.
+type InstalledPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Manager string `json:"manager"`
+}
+
+// ListeningPort is a TCP port a process on the host is listening on.
+type ListeningPort struct {
+	Port    uint32 `json:"port"`
+	Address string `json:"address,omitempty"`
+	PID     int32  `json:"pid,omitempty"`
 }
 
 // GenericProcess is an abstracted representation of a process.
@@ -25,6 +53,12 @@ type GenericProcess interface {
 	Name() (string, error)
 	Cmd() (string, error)
 	PID() int32
+	Exe() (string, error)
+	Username() (string, error)
+	PPID() (int32, error)
+	// EnvNames returns the names, not the values, of the process' environment
+	// variables.
+	EnvNames() ([]string, error)
 }
 
 type MatchedProcess struct {
@@ -38,6 +72,20 @@ func (d *DiscoveryManifest) AddMatchedProcess(p MatchedProcess) {
 	d.MatchedProcesses = append(d.MatchedProcesses, p)
 }
 
+// ContainerType returns "kubernetes" for a Kubernetes pod, the container
+// runtime for other containers, and "none" outside of a container.
+func (d *DiscoveryManifest) ContainerType() string {
+	if d.Kubernetes {
+		return "kubernetes"
+	}
+
+	if d.ContainerRuntime != "" {
+		return d.ContainerRuntime
+	}
+
+	return "none"
+}
+
 func (d *DiscoveryManifest) ConstrainRecipes(allRecipes []OpenInstallationRecipe) []OpenInstallationRecipe {
 	var recipes []OpenInstallationRecipe
 
@@ -47,6 +95,18 @@ func (d *DiscoveryManifest) ConstrainRecipes(allRecipes []OpenInstallationRecipe
 		}
 
 		for _, target := range recipe.InstallTargets {
+			if target.CloudProvider != "" {
+				if !strings.EqualFold(target.CloudProvider, d.Cloud.Provider) {
+					continue
+				}
+			}
+
+			if target.Container != "" {
+				if !strings.EqualFold(target.Container, d.ContainerType()) {
+					continue
+				}
+			}
+
 			if target.KernelArch != "" {
 				if !strings.EqualFold(target.KernelArch, d.KernelArch) {
 					continue
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// discoveryManifestFile is the saved form of a discovery manifest.  It holds
+// what is left out of the manifest's JSON when it is reported with the
+// install status, so that recipe selection can be replayed from it exactly.
+type discoveryManifestFile struct {
+	DiscoveryManifest
+	DiscoveredProcesses []DiscoveredProcess `json:"discoveredProcesses"`
+	InstalledPackages   []InstalledPackage  `json:"packages"`
+	ListeningPorts      []ListeningPort     `json:"listeningPorts"`
+}
+
+// DiscoveredProcess is a process read from a saved discovery manifest.
+type DiscoveredProcess struct {
+	ProcessID   int32    `json:"pid"`
+	ProcessName string   `json:"name"`
+	Cmdline     string   `json:"cmdline"`
+	ExePath     string   `json:"exe,omitempty"`
+	User        string   `json:"user,omitempty"`
+	ParentID    int32    `json:"ppid,omitempty"`
+	EnvVarNames []string `json:"env,omitempty"`
+}
+
+func (p DiscoveredProcess) Name() (string, error) {
+	return p.ProcessName, nil
+}
+
+func (p DiscoveredProcess) Cmd() (string, error) {
+	return p.Cmdline, nil
+}
+
+func (p DiscoveredProcess) PID() int32 {
+	return p.ProcessID
+}
+
+func (p DiscoveredProcess) Exe() (string, error) {
+	return p.ExePath, nil
+}
+
+func (p DiscoveredProcess) Username() (string, error) {
+	return p.User, nil
+}
+
+func (p DiscoveredProcess) PPID() (int32, error) {
+	return p.ParentID, nil
+}
+
+func (p DiscoveredProcess) EnvNames() ([]string, error) {
+	return p.EnvVarNames, nil
+}
+
+// WriteDiscoveryManifest writes the manifest as indented JSON, including the
+// processes, packages and ports that were discovered.  Processes that exit
+// before they can be read are left out, and details of processes the CLI is
+// not allowed to inspect are left empty.  Environment variable values are
+// never written.
+func WriteDiscoveryManifest(w io.Writer, m *DiscoveryManifest) error {
+	f := discoveryManifestFile{
+		DiscoveryManifest:   *m,
+		DiscoveredProcesses: []DiscoveredProcess{},
+		InstalledPackages:   m.InstalledPackages,
+		ListeningPorts:      m.ListeningPorts,
+	}
+
+	// Matched processes refer to recipes, and are recomputed on replay.
+	f.MatchedProcesses = nil
+
+	for _, p := range m.DiscoveredProcesses {
+		name, err := p.Name()
+		if err != nil {
+			continue
+		}
+
+		cmd, err := p.Cmd()
+		if err != nil {
+			continue
+		}
+
+		f.DiscoveredProcesses = append(f.DiscoveredProcesses, discoveredProcess(p, name, cmd))
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+
+	return e.Encode(f)
+}
+
+func discoveredProcess(p GenericProcess, name string, cmd string) DiscoveredProcess {
+	d := DiscoveredProcess{
+		ProcessID:   p.PID(),
+		ProcessName: name,
+		Cmdline:     cmd,
+	}
+
+	d.ExePath, _ = p.Exe()
+	d.User, _ = p.Username()
+	d.ParentID, _ = p.PPID()
+	d.EnvVarNames, _ = p.EnvNames()
+
+	return d
+}
+
+// ReadDiscoveryManifest reads a manifest written by WriteDiscoveryManifest.
+func ReadDiscoveryManifest(r io.Reader) (*DiscoveryManifest, error) {
+	var f discoveryManifestFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("could not read the discovery manifest: %s", err)
+	}
+
+	m := f.DiscoveryManifest
+	m.InstalledPackages = f.InstalledPackages
+	m.ListeningPorts = f.ListeningPorts
+
+	for _, p := range f.DiscoveredProcesses {
+		m.DiscoveredProcesses = append(m.DiscoveredProcesses, p)
+	}
+
+	return &m, nil
+}
@@ -0,0 +1,55 @@
+// +build unit
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoveryManifestFile_RoundTrip(t *testing.T) {
+	m := &DiscoveryManifest{
+		Hostname:         "db-01",
+		OS:               "linux",
+		Platform:         "ubuntu",
+		PlatformVersion:  "20.04",
+		KernelArch:       "x86_64",
+		ContainerRuntime: "docker",
+		Cloud:            CloudMetadata{Provider: "aws", InstanceType: "m5.large"},
+		DiscoveredProcesses: []GenericProcess{
+			DiscoveredProcess{ProcessID: 42, ProcessName: "java", Cmdline: "java -jar app.jar"},
+		},
+		InstalledPackages: []InstalledPackage{{Name: "mysql-server", Version: "8.0", Manager: "dpkg"}},
+		ListeningPorts:    []ListeningPort{{Port: 3306, Address: "0.0.0.0", PID: 42}},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, WriteDiscoveryManifest(&b, m))
+
+	replayed, err := ReadDiscoveryManifest(&b)
+	require.NoError(t, err)
+	require.Equal(t, m, replayed)
+}
+
+func TestDiscoveryManifest_StatusJSONOmitsHostDetails(t *testing.T) {
+	m := DiscoveryManifest{
+		OS: "linux",
+		DiscoveredProcesses: []GenericProcess{
+			DiscoveredProcess{ProcessID: 42, ProcessName: "java", Cmdline: "java -Dpassword=secret -jar app.jar"},
+		},
+		InstalledPackages: []InstalledPackage{{Name: "mysql-server", Manager: "dpkg"}},
+	}
+
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "secret")
+	require.NotContains(t, string(b), "mysql-server")
+}
+
+func TestReadDiscoveryManifest_Invalid(t *testing.T) {
+	_, err := ReadDiscoveryManifest(bytes.NewBufferString("not json"))
+	require.Error(t, err)
+}
@@ -10,11 +10,62 @@ type InstallerContext struct {
 	RecipeNames []string
 	RecipePaths []string
 	// LocalRecipes is the path to a local recipe directory from which to load recipes.
-	LocalRecipes       string
+	LocalRecipes string
+	// RecipeSource is a git repository or tarball from which to load recipes.
+	RecipeSource       RecipeSource
 	SkipIntegrations   bool
 	SkipLoggingInstall bool
 	SkipApm            bool
 	SkipInfraInstall   bool
+	// DryRun plans the installation without executing any recipes.
+	DryRun bool
+	// ExplainDeps prints the recipe dependency graph before installing.
+	ExplainDeps bool
+	// ExplainMatch prints which processes, packages and ports matched each
+	// recipe available for the host.
+	ExplainMatch bool
+	// Concurrency is the maximum number of recipes to install at once.
+	Concurrency int
+	// UninstallOnFailure runs a recipe's uninstall task when its validation fails.
+	UninstallOnFailure bool
+	// ReportFile is the path to write a report of the install outcome to.
+	ReportFile string
+	// StatusSinks are files and webhooks that install status events are sent to.
+	StatusSinks []string
+	// Answers are responses supplied ahead of time for questions that would
+	// otherwise be prompted for.
+	Answers *Answers
+	// DownloadURL replaces the URL recipes download their artifacts from.
+	DownloadURL string
+	// DownloadMirror is the trust configuration for download mirrors set in
+	// NEW_RELIC_DOWNLOAD_URL.
+	DownloadMirror DownloadMirror
+	// DiscoveryManifest is the path to a saved discovery manifest to use in
+	// place of discovering the host.
+	DiscoveryManifest string
+}
+
+// RecipeSource is a git repository or tarball URL that recipes are loaded from
+// instead of the New Relic service.
+type RecipeSource struct {
+	URL string
+	// Ref is the branch, tag or commit to use from a git repository.
+	Ref string
+	// Checksums is the path within the source of a sha256 checksum manifest
+	// that every recipe must be listed in.
+	Checksums string
+	// PublicKey is a base64 encoded ed25519 public key.  When set, the checksum
+	// manifest must have a valid detached signature alongside it.
+	PublicKey string
+}
+
+// DownloadMirror configures which mirrors of download.newrelic.com are trusted.
+type DownloadMirror struct {
+	// Hosts are trusted mirror hosts.  A leading "*." matches any subdomain.
+	Hosts []string
+	// PublicKey is a base64 encoded ed25519 public key.  Mirrors that serve a
+	// signature of their URL made with the matching private key are trusted.
+	PublicKey string
 }
 
 func (i *InstallerContext) ShouldInstallInfraAgent() bool {
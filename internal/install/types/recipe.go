@@ -20,6 +20,25 @@ var (
 // RecipeVars is used to pass dynamic data to recipes and go-task.
 type RecipeVars map[string]string
 
+// RecipeFieldError is returned when a field of a recipe definition is not of
+// the expected type.
+type RecipeFieldError struct {
+	// Field is the path to the field, e.g. installTargets[0].os.
+	Field   string
+	Message string
+}
+
+func (e *RecipeFieldError) Error() string {
+	return fmt.Sprintf("invalid recipe field %s: %s", e.Field, e.Message)
+}
+
+func fieldTypeError(field string, expected string, v interface{}) error {
+	return &RecipeFieldError{
+		Field:   field,
+		Message: fmt.Sprintf("expected %s, got %s", expected, yamlTypeName(v)),
+	}
+}
+
 // The API response returns OpenInstallationRecipe.Install as a string.
 // When specifying a recipe path, OpenInstallationRecipe.Install is a map[interface{}]interface{}.
 // For this reason we need a custom unmarshal method for YAML.
@@ -30,335 +49,326 @@ func (r *OpenInstallationRecipe) UnmarshalYAML(unmarshal func(interface{}) error
 		return err
 	}
 
-	if v, ok := recipe["dependencies"]; ok {
-		r.Dependencies = interfaceSliceToStringSlice(v.([]interface{}))
-	}
-
 	r.Description = toStringByFieldName("description", recipe)
 	r.DisplayName = toStringByFieldName("displayName", recipe)
 	r.File = toStringByFieldName("file", recipe)
 	r.ID = toStringByFieldName("id", recipe)
-	r.InputVars = expandInputVars(recipe)
+	r.Name = toStringByFieldName("name", recipe)
+	r.Repository = toStringByFieldName("repository", recipe)
+	r.Stability = OpenInstallationStability(toStringByFieldName("stability", recipe))
+	r.ValidationNRQL = NRQL(toStringByFieldName("validationNrql", recipe))
 
-	installAsString, err := expandInstalllMapToString(recipe)
-	if err != nil {
+	if err = r.expandLists(recipe); err != nil {
 		return err
 	}
-	r.Install = installAsString
 
-	r.InstallTargets = expandInstallTargets(recipe)
+	if err = r.expandTasks(recipe); err != nil {
+		return err
+	}
+
+	return r.expandConfig(recipe)
+}
+
+func (r *OpenInstallationRecipe) expandLists(recipe map[string]interface{}) error {
+	var err error
+
+	if r.Dependencies, err = toStringSliceByFieldName("dependencies", recipe); err != nil {
+		return err
+	}
 
-	if v, ok := recipe["keywords"]; ok {
-		r.Keywords = interfaceSliceToStringSlice(v.([]interface{}))
+	if r.Keywords, err = toStringSliceByFieldName("keywords", recipe); err != nil {
+		return err
 	}
 
-	r.LogMatch = expandLogMatch(recipe)
-	r.Name = toStringByFieldName("name", recipe)
-	r.PostInstall = expandPostInstall(recipe)
-	r.PreInstall = expandPreInstall(recipe)
+	if r.ProcessMatch, err = toStringSliceByFieldName("processMatch", recipe); err != nil {
+		return err
+	}
 
-	if v, ok := recipe["processMatch"]; ok {
-		r.ProcessMatch = interfaceSliceToStringSlice(v.([]interface{}))
+	if r.ProcessMatchRules, err = expandProcessMatchRules(recipe); err != nil {
+		return err
 	}
 
-	r.Quickstarts = expandQuickStarts(recipe)
-	r.ObservabilityPacks = expandObservabilityPacks(recipe)
+	if r.PackageMatch, err = toStringSliceByFieldName("packageMatch", recipe); err != nil {
+		return err
+	}
 
-	r.Repository = toStringByFieldName("repository", recipe)
+	if r.PortMatch, err = toIntSliceByFieldName("portMatch", recipe); err != nil {
+		return err
+	}
 
-	if v, ok := recipe["stability"]; ok {
-		r.Stability = OpenInstallationStability(v.(string))
+	if r.InputVars, err = expandInputVars(recipe); err != nil {
+		return err
 	}
 
-	r.SuccessLinkConfig = expandSuccessLinkConfig(recipe)
+	if r.InstallTargets, err = expandInstallTargets(recipe); err != nil {
+		return err
+	}
 
-	if v, ok := recipe["validationNrql"]; ok {
-		r.ValidationNRQL = NRQL(v.(string))
+	if r.LogMatch, err = expandLogMatch(recipe); err != nil {
+		return err
 	}
 
+	r.ObservabilityPacks, err = expandObservabilityPacks(recipe)
 	return err
 }
 
-func expandObservabilityPacks(recipe map[string]interface{}) []OpenInstallationObservabilityPackFilter {
-	v, ok := recipe["observabilityPacks"]
-	if !ok {
-		return []OpenInstallationObservabilityPackFilter{}
+func (r *OpenInstallationRecipe) expandTasks(recipe map[string]interface{}) error {
+	var err error
+
+	if r.Install, err = expandTaskMapToString("install", recipe); err != nil {
+		return err
 	}
 
-	dataIn := v.([]interface{})
-	dataOut := make([]OpenInstallationObservabilityPackFilter, len(dataIn))
-	dataz := make([]map[string]interface{}, len(dataIn))
+	r.Uninstall, err = expandTaskMapToString("uninstall", recipe)
+	return err
+}
 
-	for i, vv := range dataIn {
-		vvv := vv.(map[interface{}]interface{})
-		varr := map[string]interface{}{}
+func (r *OpenInstallationRecipe) expandConfig(recipe map[string]interface{}) error {
+	var err error
 
-		for k, v := range vvv {
-			varr[k.(string)] = v
-			dataz[i] = varr
-		}
+	if r.PostInstall, err = expandPostInstall(recipe); err != nil {
+		return err
 	}
 
-	for i, v := range dataz {
-		vOut := OpenInstallationObservabilityPackFilter{
-			Name: toStringByFieldName("name", v),
-		}
-		if v, ok := v["level"]; ok {
-			vOut.Level = OpenInstallationObservabilityPackLevel(v.(string))
-		}
-
-		dataOut[i] = vOut
+	if r.PreInstall, err = expandPreInstall(recipe); err != nil {
+		return err
 	}
 
-	return dataOut
-}
-
-func expandQuickStarts(recipe map[string]interface{}) OpenInstallationQuickstartsFilter {
-	v, ok := recipe["quickstarts"]
-	if !ok {
-		return OpenInstallationQuickstartsFilter{}
+	if r.Quickstarts, err = expandQuickStarts(recipe); err != nil {
+		return err
 	}
 
-	dataIn := v.(map[interface{}]interface{})
-	reData := map[string]interface{}{}
-	for k, v := range dataIn {
-		reData[k.(string)] = v
-	}
+	r.SuccessLinkConfig, err = expandSuccessLinkConfig(recipe)
+	return err
+}
 
-	dataOut := OpenInstallationQuickstartsFilter{
-		Name:       toStringByFieldName("name", reData),
-		EntityType: expandEntityType(reData),
+func expandObservabilityPacks(recipe map[string]interface{}) ([]OpenInstallationObservabilityPackFilter, error) {
+	dataz, err := toMapSliceByFieldName("observabilityPacks", recipe)
+	if err != nil {
+		return nil, err
 	}
 
-	if v, ok := reData["category"]; ok {
-		dataOut.Category = OpenInstallationCategory(v.(string))
+	dataOut := make([]OpenInstallationObservabilityPackFilter, len(dataz))
+	for i, v := range dataz {
+		dataOut[i] = OpenInstallationObservabilityPackFilter{
+			Name:  toStringByFieldName("name", v),
+			Level: OpenInstallationObservabilityPackLevel(toStringByFieldName("level", v)),
+		}
 	}
 
-	return dataOut
+	return dataOut, nil
 }
 
-func expandEntityType(data map[string]interface{}) OpenInstallationQuickstartEntityType {
-	v, ok := data["entityType"]
-	if !ok {
-		return OpenInstallationQuickstartEntityType{}
+func expandQuickStarts(recipe map[string]interface{}) (OpenInstallationQuickstartsFilter, error) {
+	reData, err := toMapByFieldName("quickstarts", recipe)
+	if err != nil || reData == nil {
+		return OpenInstallationQuickstartsFilter{}, err
 	}
 
-	dataIn := v.(map[interface{}]interface{})
-	reData := map[string]interface{}{}
-	for k, v := range dataIn {
-		reData[k.(string)] = v
+	entityData, err := toMapByFieldName("entityType", reData)
+	if err != nil {
+		return OpenInstallationQuickstartsFilter{}, withParentField("quickstarts", err)
 	}
 
-	entityType := OpenInstallationQuickstartEntityType{
-		Type:   toStringByFieldName("type", reData),
-		Domain: toStringByFieldName("domain", reData),
+	dataOut := OpenInstallationQuickstartsFilter{
+		Name:     toStringByFieldName("name", reData),
+		Category: OpenInstallationCategory(toStringByFieldName("category", reData)),
+		EntityType: OpenInstallationQuickstartEntityType{
+			Type:   toStringByFieldName("type", entityData),
+			Domain: toStringByFieldName("domain", entityData),
+		},
 	}
 
-	return entityType
+	return dataOut, nil
 }
 
-func expandSuccessLinkConfig(recipe map[string]interface{}) OpenInstallationSuccessLinkConfig {
-	v, ok := recipe["successLinkConfig"]
-	if !ok {
-		return OpenInstallationSuccessLinkConfig{}
-	}
-
-	dataIn := v.(map[interface{}]interface{})
-	reData := map[string]interface{}{}
-	for k, v := range dataIn {
-		reData[k.(string)] = v
+func expandSuccessLinkConfig(recipe map[string]interface{}) (OpenInstallationSuccessLinkConfig, error) {
+	reData, err := toMapByFieldName("successLinkConfig", recipe)
+	if err != nil {
+		return OpenInstallationSuccessLinkConfig{}, err
 	}
 
 	dataOut := OpenInstallationSuccessLinkConfig{
 		Filter: toStringByFieldName("filter", reData),
+		Type:   OpenInstallationSuccessLinkType(toStringByFieldName("type", reData)),
 	}
 
-	if v, ok := reData["type"]; ok {
-		dataOut.Type = OpenInstallationSuccessLinkType(v.(string))
-	}
-
-	return dataOut
+	return dataOut, nil
 }
 
-func expandInstallTargets(recipe map[string]interface{}) []OpenInstallationRecipeInstallTarget {
-	v, ok := recipe["installTargets"]
-	if !ok {
-		return []OpenInstallationRecipeInstallTarget{}
-	}
-
-	dataIn := v.([]interface{})
-	dataOut := make([]OpenInstallationRecipeInstallTarget, len(dataIn))
-	dataz := make([]map[string]interface{}, len(dataIn))
-	for i, vv := range dataIn {
-		vvv := vv.(map[interface{}]interface{})
-		varr := map[string]interface{}{}
-
-		for k, v := range vvv {
-			varr[k.(string)] = v
-			dataz[i] = varr
-		}
+func expandInstallTargets(recipe map[string]interface{}) ([]OpenInstallationRecipeInstallTarget, error) {
+	dataz, err := toMapSliceByFieldName("installTargets", recipe)
+	if err != nil {
+		return nil, err
 	}
 
+	dataOut := make([]OpenInstallationRecipeInstallTarget, len(dataz))
 	for i, v := range dataz {
-		vOut := OpenInstallationRecipeInstallTarget{
+		dataOut[i] = OpenInstallationRecipeInstallTarget{
+			CloudProvider:   toStringByFieldName("cloudProvider", v),
+			Container:       toStringByFieldName("container", v),
 			KernelArch:      toStringByFieldName("kernelArch", v),
 			KernelVersion:   toStringByFieldName("kernelVersion", v),
+			Os:              OpenInstallationOperatingSystem(toStringByFieldName("os", v)),
+			Platform:        OpenInstallationPlatform(toStringByFieldName("platform", v)),
+			PlatformFamily:  OpenInstallationPlatformFamily(toStringByFieldName("platformFamily", v)),
 			PlatformVersion: toStringByFieldName("platformVersion", v),
+			Type:            OpenInstallationTargetType(toStringByFieldName("type", v)),
 		}
-
-		if v, ok := v["os"]; ok {
-			vOut.Os = OpenInstallationOperatingSystem(v.(string))
-		}
-
-		if v, ok := v["platform"]; ok {
-			vOut.Platform = OpenInstallationPlatform(v.(string))
-		}
-
-		if v, ok := v["platformFamily"]; ok {
-			vOut.PlatformFamily = OpenInstallationPlatformFamily(v.(string))
-		}
-
-		if v, ok := v["type"]; ok {
-			vOut.Type = OpenInstallationTargetType(v.(string))
-		}
-
-		dataOut[i] = vOut
 	}
 
-	return dataOut
+	return dataOut, nil
 }
 
-func expandPreInstall(recipe map[string]interface{}) OpenInstallationPreInstallConfiguration {
-	v, ok := recipe["preInstall"]
-	if !ok {
-		return OpenInstallationPreInstallConfiguration{}
-	}
-
-	vv := v.(map[interface{}]interface{})
-	infoOut := map[string]interface{}{}
-	for k, v := range vv {
-		infoOut[k.(string)] = v
+func expandPreInstall(recipe map[string]interface{}) (OpenInstallationPreInstallConfiguration, error) {
+	infoOut, err := toMapByFieldName("preInstall", recipe)
+	if err != nil {
+		return OpenInstallationPreInstallConfiguration{}, err
 	}
 
 	return OpenInstallationPreInstallConfiguration{
 		Info:               toStringByFieldName("info", infoOut),
 		Prompt:             toStringByFieldName("prompt", infoOut),
 		RequireAtDiscovery: toStringByFieldName("requireAtDiscovery", infoOut),
-	}
+	}, nil
 }
 
-func expandPostInstall(recipe map[string]interface{}) OpenInstallationPostInstallConfiguration {
-	v, ok := recipe["postInstall"]
-	if !ok {
-		return OpenInstallationPostInstallConfiguration{}
-	}
-
-	vv := v.(map[interface{}]interface{})
-	infoOut := map[string]interface{}{}
-	for k, v := range vv {
-		infoOut[k.(string)] = v
+func expandPostInstall(recipe map[string]interface{}) (OpenInstallationPostInstallConfiguration, error) {
+	infoOut, err := toMapByFieldName("postInstall", recipe)
+	if err != nil {
+		return OpenInstallationPostInstallConfiguration{}, err
 	}
 
 	return OpenInstallationPostInstallConfiguration{
 		Info: toStringByFieldName("info", infoOut),
-	}
+	}, nil
 }
 
-func expandInputVars(recipe map[string]interface{}) []OpenInstallationRecipeInputVariable {
-	v, ok := recipe["inputVars"]
-	if !ok {
-		return []OpenInstallationRecipeInputVariable{}
+func expandInputVars(recipe map[string]interface{}) ([]OpenInstallationRecipeInputVariable, error) {
+	varz, err := toMapSliceByFieldName("inputVars", recipe)
+	if err != nil {
+		return nil, err
 	}
 
-	vars := v.([]interface{})
-	varsOut := make([]OpenInstallationRecipeInputVariable, len(vars))
-
-	varz := make([]map[string]interface{}, len(vars))
-	for i, vv := range vars {
-		vvv := vv.(map[interface{}]interface{})
-		varr := map[string]interface{}{}
-
-		for k, v := range vvv {
-			varr[k.(string)] = v
-			varz[i] = varr
+	varsOut := make([]OpenInstallationRecipeInputVariable, len(varz))
+	for i, v := range varz {
+		secret, err := toBoolByFieldName("secret", v)
+		if err != nil {
+			return nil, withParentField(fmt.Sprintf("inputVars[%d]", i), err)
 		}
-	}
 
-	for i, v := range varz {
-		vOut := OpenInstallationRecipeInputVariable{
+		varsOut[i] = OpenInstallationRecipeInputVariable{
 			Default: toStringByFieldName("default", v),
 			Name:    toStringByFieldName("name", v),
 			Prompt:  toStringByFieldName("prompt", v),
-			Secret:  toBoolByFieldName("secret", v),
+			Secret:  secret,
 		}
-
-		varsOut[i] = vOut
 	}
 
-	return varsOut
+	return varsOut, nil
 }
 
-func expandLogMatch(recipe map[string]interface{}) []OpenInstallationLogMatch {
-	v, ok := recipe["logMatch"]
-	if !ok {
-		return []OpenInstallationLogMatch{}
+func expandLogMatch(recipe map[string]interface{}) ([]OpenInstallationLogMatch, error) {
+	dataz, err := toMapSliceByFieldName("logMatch", recipe)
+	if err != nil {
+		return nil, err
 	}
 
-	dataIn := v.([]interface{})
-	dataOut := make([]OpenInstallationLogMatch, len(dataIn))
-	dataz := make([]map[string]interface{}, len(dataIn))
-	for i, vv := range dataIn {
-		vvv := vv.(map[interface{}]interface{})
-		varr := map[string]interface{}{}
-
-		for k, v := range vvv {
-			varr[k.(string)] = v
-			dataz[i] = varr
+	dataOut := make([]OpenInstallationLogMatch, len(dataz))
+	for i, v := range dataz {
+		attributes, err := expandLogAttributes(v)
+		if err != nil {
+			return nil, withParentField(fmt.Sprintf("logMatch[%d]", i), err)
 		}
-	}
 
-	for i, v := range dataz {
-		vOut := OpenInstallationLogMatch{
-			Attributes: expandLogAttributes(v),
+		dataOut[i] = OpenInstallationLogMatch{
+			Attributes: attributes,
 			File:       toStringByFieldName("file", v),
 			Name:       toStringByFieldName("name", v),
 			Pattern:    toStringByFieldName("pattern", v),
 			Systemd:    toStringByFieldName("systemd", v),
 		}
-
-		dataOut[i] = vOut
 	}
 
-	return dataOut
+	return dataOut, nil
 }
 
-func expandLogAttributes(data map[string]interface{}) OpenInstallationAttributes {
-	attributesOut := OpenInstallationAttributes{}
+func expandProcessMatchRules(recipe map[string]interface{}) ([]OpenInstallationProcessMatchRule, error) {
+	dataz, err := toMapSliceByFieldName("processMatchRules", recipe)
+	if err != nil {
+		return nil, err
+	}
+
+	dataOut := make([]OpenInstallationProcessMatchRule, len(dataz))
+	for i, v := range dataz {
+		field := fmt.Sprintf("processMatchRules[%d]", i)
+
+		env, err := toStringSliceByFieldName("env", v)
+		if err != nil {
+			return nil, withParentField(field, err)
+		}
 
-	v, ok := data["attributes"]
-	if !ok {
-		return attributesOut
+		ports, err := toIntSliceByFieldName("ports", v)
+		if err != nil {
+			return nil, withParentField(field, err)
+		}
+
+		minCount, err := toIntByFieldName("minCount", v)
+		if err != nil {
+			return nil, withParentField(field, err)
+		}
+
+		dataOut[i] = OpenInstallationProcessMatchRule{
+			Cmdline:  toStringByFieldName("cmdline", v),
+			Env:      env,
+			Exe:      toStringByFieldName("exe", v),
+			MinCount: minCount,
+			Parent:   toStringByFieldName("parent", v),
+			Ports:    ports,
+			User:     toStringByFieldName("user", v),
+		}
+	}
+
+	return dataOut, nil
+}
+
+func expandLogAttributes(data map[string]interface{}) (OpenInstallationAttributes, error) {
+	attrs, err := toMapByFieldName("attributes", data)
+	if err != nil {
+		return OpenInstallationAttributes{}, err
 	}
 
-	attrs := v.(map[interface{}]interface{})
-	attrsOut := map[string]string{}
-	for k, v := range attrs {
-		attrsOut[k.(string)] = v.(string)
+	return OpenInstallationAttributes{
+		Logtype: toStringByFieldName("logtype", attrs),
+	}, nil
+}
+
+func toBoolByFieldName(fieldName string, data map[string]interface{}) (bool, error) {
+	in, ok := data[fieldName]
+	if !ok || in == nil {
+		return false, nil
 	}
 
-	if v, ok := attrsOut["logtype"]; ok {
-		attributesOut.Logtype = v
+	v, ok := in.(bool)
+	if !ok {
+		return false, fieldTypeError(fieldName, "a boolean", in)
 	}
 
-	return attributesOut
+	return v, nil
 }
 
-func toBoolByFieldName(fieldName string, data map[string]interface{}) bool {
-	if v, ok := data[fieldName]; ok {
-		return v.(bool)
+func toIntByFieldName(fieldName string, data map[string]interface{}) (int, error) {
+	in, ok := data[fieldName]
+	if !ok || in == nil {
+		return 0, nil
 	}
 
-	return false
+	v, ok := in.(int)
+	if !ok {
+		return 0, fieldTypeError(fieldName, "a number", in)
+	}
+
+	return v, nil
 }
 
 func toStringByFieldName(fieldName string, data map[string]interface{}) string {
@@ -378,34 +388,166 @@ func toStringByFieldName(fieldName string, data map[string]interface{}) string {
 	return out
 }
 
-func expandInstalllMapToString(recipeIn map[string]interface{}) (string, error) {
-	installIn, ok := recipeIn["install"]
+func toStringSliceByFieldName(fieldName string, data map[string]interface{}) ([]string, error) {
+	in, ok := data[fieldName]
+	if !ok || in == nil {
+		return nil, nil
+	}
+
+	slice, ok := in.([]interface{})
 	if !ok {
-		return "", nil
+		return nil, fieldTypeError(fieldName, "a list", in)
+	}
+
+	out := make([]string, len(slice))
+	for i, v := range slice {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fieldTypeError(fmt.Sprintf("%s[%d]", fieldName, i), "a string", v)
+		}
+
+		out[i] = s
 	}
 
-	installOut := map[string]interface{}{}
-	installMap := installIn.(map[interface{}]interface{})
-	for k, v := range installMap {
-		installOut[k.(string)] = v
+	return out, nil
+}
+
+func toIntSliceByFieldName(fieldName string, data map[string]interface{}) ([]int, error) {
+	in, ok := data[fieldName]
+	if !ok || in == nil {
+		return nil, nil
 	}
 
-	installAsString, err := yaml.Marshal(installOut)
-	if err != nil {
-		return "", fmt.Errorf("error unmarshaling recipe.install to string: %s", err)
+	slice, ok := in.([]interface{})
+	if !ok {
+		return nil, fieldTypeError(fieldName, "a list", in)
 	}
 
-	return string(installAsString), nil
+	out := make([]int, len(slice))
+	for i, v := range slice {
+		n, ok := v.(int)
+		if !ok {
+			return nil, fieldTypeError(fmt.Sprintf("%s[%d]", fieldName, i), "a number", v)
+		}
+
+		out[i] = n
+	}
+
+	return out, nil
 }
 
-func interfaceSliceToStringSlice(slice []interface{}) []string {
-	out := make([]string, len(slice))
+// toMapByFieldName returns the named field as a map with string keys, or nil
+// if the field is not set.
+func toMapByFieldName(fieldName string, data map[string]interface{}) (map[string]interface{}, error) {
+	in, ok := data[fieldName]
+	if !ok || in == nil {
+		return nil, nil
+	}
+
+	return toStringKeyMap(fieldName, in)
+}
+
+// toMapSliceByFieldName returns the named field as a list of maps with string
+// keys, or an empty list if the field is not set.
+func toMapSliceByFieldName(fieldName string, data map[string]interface{}) ([]map[string]interface{}, error) {
+	in, ok := data[fieldName]
+	if !ok || in == nil {
+		return []map[string]interface{}{}, nil
+	}
+
+	slice, ok := in.([]interface{})
+	if !ok {
+		return nil, fieldTypeError(fieldName, "a list", in)
+	}
 
+	out := make([]map[string]interface{}, len(slice))
 	for i, v := range slice {
-		out[i] = v.(string)
+		m, err := toStringKeyMap(fmt.Sprintf("%s[%d]", fieldName, i), v)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = m
 	}
 
-	return out
+	return out, nil
+}
+
+func toStringKeyMap(fieldName string, in interface{}) (map[string]interface{}, error) {
+	switch m := in.(type) {
+	case map[string]interface{}:
+		return m, nil
+	case map[interface{}]interface{}:
+		out := map[string]interface{}{}
+		for k, v := range m {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fieldTypeError(fieldName, "string keys", k)
+			}
+
+			out[key] = v
+		}
+
+		return out, nil
+	}
+
+	return nil, fieldTypeError(fieldName, "a map", in)
+}
+
+// withParentField prefixes the field of a RecipeFieldError with its parent.
+func withParentField(parent string, err error) error {
+	if e, ok := err.(*RecipeFieldError); ok {
+		return &RecipeFieldError{
+			Field:   parent + "." + e.Field,
+			Message: e.Message,
+		}
+	}
+
+	return err
+}
+
+func yamlTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "nothing"
+	case string:
+		return "a string"
+	case int, int64, uint64, float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case []interface{}:
+		return "a list"
+	case map[interface{}]interface{}, map[string]interface{}:
+		return "a map"
+	}
+
+	return fmt.Sprintf("%T", v)
+}
+
+func expandTaskMapToString(fieldName string, recipeIn map[string]interface{}) (string, error) {
+	taskIn, ok := recipeIn[fieldName]
+	if !ok || taskIn == nil {
+		return "", nil
+	}
+
+	// Recipes from the API, and recipes whose tasks are a YAML block string,
+	// already have the task as a string.
+	if s, ok := taskIn.(string); ok {
+		return s, nil
+	}
+
+	taskOut, err := toStringKeyMap(fieldName, taskIn)
+	if err != nil {
+		return "", err
+	}
+
+	taskAsString, err := yaml.Marshal(taskOut)
+	if err != nil {
+		return "", fmt.Errorf("error unmarshaling recipe.%s to string: %s", fieldName, err)
+	}
+
+	return string(taskAsString), nil
 }
 
 func (r *OpenInstallationRecipe) PostInstallMessage() string {
@@ -424,6 +566,16 @@ func (r *OpenInstallationRecipe) PreInstallMessage() string {
 	return ""
 }
 
+// UninstallRecipe returns a recipe whose install steps are this recipe's
+// uninstall task, so that it can be run by a RecipeExecutor.
+func (r *OpenInstallationRecipe) UninstallRecipe() OpenInstallationRecipe {
+	return OpenInstallationRecipe{
+		Name:        r.Name,
+		DisplayName: r.DisplayName,
+		Install:     r.Uninstall,
+	}
+}
+
 // SetRecipeVar is responsible for including a new variable on the RecipeVariables
 // struct, which is used by go-task executor.
 func (r *OpenInstallationRecipe) SetRecipeVar(key string, value string) {
@@ -1,3 +1,4 @@
+//go:build unit
 // +build unit
 
 package types
@@ -6,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 func TestToStringByFieldName(t *testing.T) {
@@ -24,3 +26,115 @@ func TestToStringByFieldName(t *testing.T) {
 	boolAsString := toStringByFieldName("boolField", data)
 	require.Equal(t, "false", boolAsString)
 }
+
+func TestUnmarshalYAML_Uninstall(t *testing.T) {
+	recipe := `
+name: test-recipe
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo install
+uninstall:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo uninstall
+`
+
+	var r OpenInstallationRecipe
+	err := yaml.Unmarshal([]byte(recipe), &r)
+	require.NoError(t, err)
+	require.Contains(t, r.Install, "echo install")
+	require.Contains(t, r.Uninstall, "echo uninstall")
+
+	u := r.UninstallRecipe()
+	require.Equal(t, "test-recipe", u.Name)
+	require.Equal(t, r.Uninstall, u.Install)
+}
+
+func TestUnmarshalYAML_NoUninstall(t *testing.T) {
+	var r OpenInstallationRecipe
+	err := yaml.Unmarshal([]byte("name: test-recipe\n"), &r)
+	require.NoError(t, err)
+	require.Empty(t, r.Uninstall)
+}
+
+func TestUnmarshalYAML_MalformedFields(t *testing.T) {
+	tests := map[string]string{
+		"keywords[0]":                   "keywords: [1]",
+		"processMatch":                  "processMatch: mysqld",
+		"packageMatch":                  "packageMatch: mysql",
+		"portMatch[0]":                  "portMatch: [mysql]",
+		"processMatchRules[0].minCount": "processMatchRules: [{cmdline: java, minCount: two}]",
+		"processMatchRules[0].env":      "processMatchRules: [{cmdline: java, env: JAVA_HOME}]",
+		"dependencies[1]":               "dependencies: [a, {b: c}]",
+		"installTargets":                "installTargets: HOST",
+		"installTargets[0]":             "installTargets: [HOST]",
+		"inputVars[0].secret":           "inputVars: [{name: A, secret: maybe}]",
+		"logMatch[0].attributes":        "logMatch: [{name: A, attributes: [a]}]",
+		"observabilityPacks[0]":         "observabilityPacks: [mysql]",
+		"quickstarts.entityType":        "quickstarts: {name: A, entityType: APM}",
+		"successLinkConfig":             "successLinkConfig: [EXPLORER]",
+		"preInstall":                    "preInstall: info",
+		"install":                       "install: [a]",
+		"uninstall":                     "uninstall: 3",
+		"postInstall":                   "postInstall: [info]",
+	}
+
+	for field, recipe := range tests {
+		var r OpenInstallationRecipe
+		err := yaml.Unmarshal([]byte("name: test\n"+recipe+"\n"), &r)
+		require.Error(t, err, field)
+
+		e, ok := err.(*RecipeFieldError)
+		require.True(t, ok, field)
+		require.Equal(t, field, e.Field)
+	}
+}
+
+func TestUnmarshalYAML_Matchers(t *testing.T) {
+	recipe := `name: test
+installTargets:
+  - type: host
+    cloudProvider: aws
+    container: none
+packageMatch:
+  - ^mysql-server
+portMatch:
+  - 3306
+processMatchRules:
+  - cmdline: java -jar app.jar
+    exe: /usr/lib/jvm/.*/java
+    user: orders
+    parent: systemd
+    env: [SPRING_PROFILES_ACTIVE]
+    ports: [8080]
+    minCount: 2
+`
+	var r OpenInstallationRecipe
+	err := yaml.Unmarshal([]byte(recipe), &r)
+	require.NoError(t, err)
+	require.Equal(t, []string{"^mysql-server"}, r.PackageMatch)
+	require.Equal(t, []int{3306}, r.PortMatch)
+	require.Equal(t, []OpenInstallationProcessMatchRule{{
+		Cmdline:  "java -jar app.jar",
+		Env:      []string{"SPRING_PROFILES_ACTIVE"},
+		Exe:      "/usr/lib/jvm/.*/java",
+		MinCount: 2,
+		Parent:   "systemd",
+		Ports:    []int{8080},
+		User:     "orders",
+	}}, r.ProcessMatchRules)
+	require.Equal(t, "aws", r.InstallTargets[0].CloudProvider)
+	require.Equal(t, "none", r.InstallTargets[0].Container)
+}
+
+func TestUnmarshalYAML_InstallString(t *testing.T) {
+	var r OpenInstallationRecipe
+	err := yaml.Unmarshal([]byte("name: test\ninstall: |\n  version: \"3\"\n"), &r)
+	require.NoError(t, err)
+	require.Equal(t, "version: \"3\"\n", r.Install)
+}
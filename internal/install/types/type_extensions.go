@@ -2,6 +2,110 @@ package types
 
 import "fmt"
 
+// OpenInstallationRecipe - Installation instructions and definition of an instrumentation integration
+//
+// It is not generated by tutone (see skip_type_create in .tutone.yml), since
+// recipe files carry fields the CLI reads that the API does not serve, such as
+// uninstall, packageMatch, portMatch and processMatchRules.  Fields added to
+// the API's type need to be added here as well.
+type OpenInstallationRecipe struct {
+	// Named list of dependencies for this recipe
+	Dependencies []string `json:"dependencies" yaml:"dependencies"`
+	// Description of the recipe
+	Description string `json:"description" yaml:"description"`
+	// Friendly name of the integration
+	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	// The full contents of the recipe file (yaml)
+	File string `json:"file" yaml:"file"`
+	// The ID
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// List of variables to prompt for input from the user
+	InputVars []OpenInstallationRecipeInputVariable `json:"inputVars" yaml:"inputVars"`
+	// Go-task's taskfile definition (see https://taskfile.dev/#/usage)
+	Install string `json:"install" yaml:"install"`
+	// Object representing the intended install target
+	InstallTargets []OpenInstallationRecipeInstallTarget `json:"installTargets" yaml:"installTargets"`
+	// Tags
+	Keywords []string `json:"keywords" yaml:"keywords"`
+	// # Partial list of possible Log forwarding parameters
+	LogMatch []OpenInstallationLogMatch `json:"logMatch" yaml:"logMatch"`
+	// Short unique handle for the name of the integration
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Metadata used to recommend and install Observability Packs
+	ObservabilityPacks []OpenInstallationObservabilityPackFilter `json:"observabilityPacks" yaml:"observabilityPacks"`
+	// List of installed package name patterns used to match CLI package detection
+	PackageMatch []string `json:"packageMatch,omitempty" yaml:"packageMatch,omitempty"`
+	// List of TCP ports used to match CLI listening port detection
+	PortMatch []int `json:"portMatch,omitempty" yaml:"portMatch,omitempty"`
+	// Object representing optional post-install configuration items
+	PostInstall OpenInstallationPostInstallConfiguration `json:"postInstall,omitempty" yaml:"postInstall,omitempty"`
+	// Object representing optional pre-install configuration items
+	PreInstall OpenInstallationPreInstallConfiguration `json:"preInstall,omitempty" yaml:"preInstall,omitempty"`
+	// List of process definitions used to match CLI process detection
+	ProcessMatch []string `json:"processMatch" yaml:"processMatch"`
+	// List of process criteria used to match CLI process detection
+	ProcessMatchRules []OpenInstallationProcessMatchRule `json:"processMatchRules,omitempty" yaml:"processMatchRules,omitempty"`
+	// Metadata used to recommend and install Quickstarts
+	Quickstarts OpenInstallationQuickstartsFilter `json:"quickstarts,omitempty" yaml:"quickstarts,omitempty"`
+	// Github repository url
+	Repository string `json:"repository" yaml:"repository"`
+	// Indicates stability level of recipe
+	Stability OpenInstallationStability `json:"stability,omitempty" yaml:"stability,omitempty"`
+	// Metadata to support generating a URL after installation success
+	SuccessLinkConfig OpenInstallationSuccessLinkConfig `json:"successLinkConfig,omitempty" yaml:"successLinkConfig,omitempty"`
+	// Go-task's taskfile definition that reverses the install (see https://taskfile.dev/#/usage)
+	Uninstall string `json:"uninstall,omitempty" yaml:"uninstall,omitempty"`
+	// NRQL the newrelic-cli uses to validate this recipe
+	// is successfully sending data to New Relic
+	ValidationNRQL NRQL `json:"validationNrql,omitempty" yaml:"validationNrql,omitempty"`
+}
+
+// OpenInstallationProcessMatchRule - Criteria a running process must all meet to match a recipe
+//
+// Process match rules are only read from recipe files by the CLI, so the type
+// is not part of the API's schema.
+type OpenInstallationProcessMatchRule struct {
+	// Regular expression matched against the process command line
+	Cmdline string `json:"cmdline,omitempty" yaml:"cmdline,omitempty"`
+	// Names of environment variables that must be set for the process
+	Env []string `json:"env,omitempty" yaml:"env,omitempty"`
+	// Regular expression matched against the process executable path
+	Exe string `json:"exe,omitempty" yaml:"exe,omitempty"`
+	// Minimum number of processes that must meet the criteria
+	MinCount int `json:"minCount,omitempty" yaml:"minCount,omitempty"`
+	// Regular expression matched against the parent process name
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// TCP ports the process must listen on at least one of
+	Ports []int `json:"ports,omitempty" yaml:"ports,omitempty"`
+	// Regular expression matched against the name of the user running the process
+	User string `json:"user,omitempty" yaml:"user,omitempty"`
+}
+
+// OpenInstallationRecipeInstallTarget - Matrix of supported installation criteria for this recipe
+//
+// Like OpenInstallationRecipe, it is not generated by tutone, since the
+// cloudProvider and container criteria are only read by the CLI.
+type OpenInstallationRecipeInstallTarget struct {
+	// Cloud provider the host runs on
+	CloudProvider string `json:"cloudProvider,omitempty" yaml:"cloudProvider,omitempty"`
+	// Container environment the host runs in, or none
+	Container string `json:"container,omitempty" yaml:"container,omitempty"`
+	// OS kernel architecture
+	KernelArch string `json:"kernelArch,omitempty" yaml:"kernelArch,omitempty"`
+	// OS kernel version
+	KernelVersion string `json:"kernelVersion,omitempty" yaml:"kernelVersion,omitempty"`
+	// Operating system
+	Os OpenInstallationOperatingSystem `json:"os,omitempty" yaml:"os,omitempty"`
+	// Operating System distribution
+	Platform OpenInstallationPlatform `json:"platform,omitempty" yaml:"platform,omitempty"`
+	// Operating System distribution family
+	PlatformFamily OpenInstallationPlatformFamily `json:"platformFamily,omitempty" yaml:"platformFamily,omitempty"`
+	// OS distribution version
+	PlatformVersion string `json:"platformVersion,omitempty" yaml:"platformVersion,omitempty"`
+	// Target type
+	Type OpenInstallationTargetType `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
 func (r RecipeVars) ToSlice() []string {
 	var s []string
 	for k, v := range r {
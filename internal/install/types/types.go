@@ -333,51 +333,6 @@ type OpenInstallationQuickstartsFilter struct {
 	Name string `json:"name" yaml:"name"`
 }
 
-// OpenInstallationRecipe - Installation instructions and definition of an instrumentation integration
-type OpenInstallationRecipe struct {
-	// Named list of dependencies for this recipe
-	Dependencies []string `json:"dependencies" yaml:"dependencies"`
-	// Description of the recipe
-	Description string `json:"description" yaml:"description"`
-	// Friendly name of the integration
-	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty"`
-	// The full contents of the recipe file (yaml)
-	File string `json:"file" yaml:"file"`
-	// The ID
-	ID string `json:"id,omitempty" yaml:"id,omitempty"`
-	// List of variables to prompt for input from the user
-	InputVars []OpenInstallationRecipeInputVariable `json:"inputVars" yaml:"inputVars"`
-	// Go-task's taskfile definition (see https://taskfile.dev/#/usage)
-	Install string `json:"install" yaml:"install"`
-	// Object representing the intended install target
-	InstallTargets []OpenInstallationRecipeInstallTarget `json:"installTargets" yaml:"installTargets"`
-	// Tags
-	Keywords []string `json:"keywords" yaml:"keywords"`
-	// # Partial list of possible Log forwarding parameters
-	LogMatch []OpenInstallationLogMatch `json:"logMatch" yaml:"logMatch"`
-	// Short unique handle for the name of the integration
-	Name string `json:"name,omitempty" yaml:"name,omitempty"`
-	// Metadata used to recommend and install Observability Packs
-	ObservabilityPacks []OpenInstallationObservabilityPackFilter `json:"observabilityPacks" yaml:"observabilityPacks"`
-	// Object representing optional post-install configuration items
-	PostInstall OpenInstallationPostInstallConfiguration `json:"postInstall,omitempty" yaml:"postInstall,omitempty"`
-	// Object representing optional pre-install configuration items
-	PreInstall OpenInstallationPreInstallConfiguration `json:"preInstall,omitempty" yaml:"preInstall,omitempty"`
-	// List of process definitions used to match CLI process detection
-	ProcessMatch []string `json:"processMatch" yaml:"processMatch"`
-	// Metadata used to recommend and install Quickstarts
-	Quickstarts OpenInstallationQuickstartsFilter `json:"quickstarts,omitempty" yaml:"quickstarts,omitempty"`
-	// Github repository url
-	Repository string `json:"repository" yaml:"repository"`
-	// Indicates stability level of recipe
-	Stability OpenInstallationStability `json:"stability,omitempty" yaml:"stability,omitempty"`
-	// Metadata to support generating a URL after installation success
-	SuccessLinkConfig OpenInstallationSuccessLinkConfig `json:"successLinkConfig,omitempty" yaml:"successLinkConfig,omitempty"`
-	// NRQL the newrelic-cli uses to validate this recipe
-	// is successfully sending data to New Relic
-	ValidationNRQL NRQL `json:"validationNrql,omitempty" yaml:"validationNrql,omitempty"`
-}
-
 // OpenInstallationRecipeInputVariable - Recipe input variable prompts displayed to the user prior to execution
 type OpenInstallationRecipeInputVariable struct {
 	// Default value of variable
@@ -390,24 +345,6 @@ type OpenInstallationRecipeInputVariable struct {
 	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
 }
 
-// OpenInstallationRecipeInstallTarget - Matrix of supported installation criteria for this recipe
-type OpenInstallationRecipeInstallTarget struct {
-	// OS kernel architecture
-	KernelArch string `json:"kernelArch,omitempty" yaml:"kernelArch,omitempty"`
-	// OS kernel version
-	KernelVersion string `json:"kernelVersion,omitempty" yaml:"kernelVersion,omitempty"`
-	// Operating system
-	Os OpenInstallationOperatingSystem `json:"os,omitempty" yaml:"os,omitempty"`
-	// Operating System distribution
-	Platform OpenInstallationPlatform `json:"platform,omitempty" yaml:"platform,omitempty"`
-	// Operating System distribution family
-	PlatformFamily OpenInstallationPlatformFamily `json:"platformFamily,omitempty" yaml:"platformFamily,omitempty"`
-	// OS distribution version
-	PlatformVersion string `json:"platformVersion,omitempty" yaml:"platformVersion,omitempty"`
-	// Target type
-	Type OpenInstallationTargetType `json:"type,omitempty" yaml:"type,omitempty"`
-}
-
 // OpenInstallationRecipeListResult - List of recipes
 type OpenInstallationRecipeListResult struct {
 	// Number of recipes returned
@@ -0,0 +1,98 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// RecipeUninstaller removes recipes that were installed on this host, using the
+// uninstall tasks recorded in the install ledger.
+type RecipeUninstaller struct {
+	ledger         *execution.Ledger
+	recipeExecutor execution.RecipeExecutor
+}
+
+func NewRecipeUninstaller(ledger *execution.Ledger, re execution.RecipeExecutor) *RecipeUninstaller {
+	return &RecipeUninstaller{
+		ledger:         ledger,
+		recipeExecutor: re,
+	}
+}
+
+// Uninstall runs the uninstall task of the named recipe, as recorded when the
+// recipe was last installed.
+func (u *RecipeUninstaller) Uninstall(ctx context.Context, recipeName string) error {
+	e, err := u.ledger.Latest(recipeName, execution.RecipeStatusTypes.INSTALLED, execution.RecipeStatusTypes.UNINSTALLED)
+	if err != nil {
+		return err
+	}
+
+	if e == nil || e.Status != execution.RecipeStatusTypes.INSTALLED {
+		return fmt.Errorf("recipe %s is not installed on this host", recipeName)
+	}
+
+	if e.Uninstall == "" {
+		return fmt.Errorf("recipe %s does not define an uninstall task", recipeName)
+	}
+
+	r := types.OpenInstallationRecipe{
+		Name:        e.Recipe,
+		DisplayName: e.DisplayName,
+		Install:     e.Uninstall,
+	}
+
+	vars, err := uninstallVars(e.Vars)
+	if err != nil {
+		return fmt.Errorf("could not uninstall %s: %s", recipeName, err)
+	}
+
+	if err = u.recipeExecutor.Execute(ctx, r, vars); err != nil {
+		return fmt.Errorf("could not uninstall %s: %s", recipeName, err)
+	}
+
+	log.Debugf("uninstalled %s", recipeName)
+
+	return u.ledger.Append(execution.LedgerEntry{
+		Recipe:      e.Recipe,
+		DisplayName: e.DisplayName,
+		Status:      execution.RecipeStatusTypes.UNINSTALLED,
+		Timestamp:   utils.GetTimestamp(),
+	})
+}
+
+// uninstallVars restores the variables a recipe was installed with.  Secrets
+// are not kept in the ledger, so they are read from the environment instead.
+// It is an error for any of them to be missing there, since the uninstall task
+// would otherwise run without them.
+func uninstallVars(vars types.RecipeVars) (types.RecipeVars, error) {
+	restored := types.RecipeVars{}
+	missing := []string{}
+
+	for k, v := range vars {
+		if v == execution.RedactedValue {
+			var ok bool
+			if v, ok = os.LookupEnv(k); !ok {
+				missing = append(missing, k)
+				continue
+			}
+		}
+
+		restored[k] = v
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("secret variables must be set in the environment: %s", strings.Join(missing, ", "))
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,50 @@
+package install
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var (
+	uninstallRecipeNames []string
+)
+
+// UninstallCommand represents the uninstall command.
+var UninstallCommand = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Uninstall New Relic instrumentation.",
+	Long: `Uninstall New Relic instrumentation
+
+Runs the uninstall task of recipes previously installed on this host by the
+install command.  Installed recipes are recorded in a ledger in the config
+directory.
+`,
+	Example: "newrelic uninstall --recipe infrastructure-agent-installer",
+	Run: func(cmd *cobra.Command, args []string) {
+		config.InitFileLogger()
+
+		u := NewRecipeUninstaller(
+			execution.NewLedger(execution.DefaultLedgerPath()),
+			execution.NewGoTaskRecipeExecutor(),
+		)
+
+		for _, n := range uninstallRecipeNames {
+			if err := u.Uninstall(utils.SignalCtx, n); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("  %s has been uninstalled.\n", n)
+		}
+	},
+}
+
+func init() {
+	UninstallCommand.Flags().StringSliceVarP(&uninstallRecipeNames, "recipe", "n", []string{}, "the name of a recipe to uninstall")
+	utils.LogIfError(UninstallCommand.MarkFlagRequired("recipe"))
+}
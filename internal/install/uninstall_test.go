@@ -0,0 +1,169 @@
+// +build unit
+
+package install
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+type recordingExecutor struct {
+	executed []types.OpenInstallationRecipe
+	vars     []types.RecipeVars
+	err      error
+}
+
+func (e *recordingExecutor) Execute(ctx context.Context, r types.OpenInstallationRecipe, v types.RecipeVars) error {
+	e.executed = append(e.executed, r)
+	e.vars = append(e.vars, v)
+	return e.err
+}
+
+func (e *recordingExecutor) ExecutePreInstall(ctx context.Context, r types.OpenInstallationRecipe, v types.RecipeVars) error {
+	return nil
+}
+
+func newTestLedger(t *testing.T) (*execution.Ledger, func()) {
+	dir, err := ioutil.TempDir("", "newrelic")
+	require.NoError(t, err)
+
+	return execution.NewLedger(filepath.Join(dir, "ledger.jsonl")), func() { os.RemoveAll(dir) }
+}
+
+func TestUninstall(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	os.Setenv("TEST_UNINSTALL_SECRET", "fromEnv")
+	defer os.Unsetenv("TEST_UNINSTALL_SECRET")
+
+	require.NoError(t, l.Append(execution.LedgerEntry{
+		Recipe:    testRecipeName,
+		Status:    execution.RecipeStatusTypes.INSTALLED,
+		Uninstall: "uninstall",
+		Vars: types.RecipeVars{
+			"DB_PORT":               "5432",
+			"TEST_UNINSTALL_SECRET": execution.RedactedValue,
+		},
+	}))
+
+	// A later run that skipped the recipe does not change what is installed.
+	require.NoError(t, l.Append(execution.LedgerEntry{
+		Recipe: testRecipeName,
+		Status: execution.RecipeStatusTypes.SKIPPED,
+	}))
+
+	e := &recordingExecutor{}
+	u := NewRecipeUninstaller(l, e)
+
+	err := u.Uninstall(context.Background(), testRecipeName)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(e.executed))
+	require.Equal(t, "uninstall", e.executed[0].Install)
+	require.Equal(t, types.RecipeVars{"DB_PORT": "5432", "TEST_UNINSTALL_SECRET": "fromEnv"}, e.vars[0])
+
+	latest, err := l.Latest(testRecipeName)
+	require.NoError(t, err)
+	require.Equal(t, execution.RecipeStatusTypes.UNINSTALLED, latest.Status)
+
+	// A second uninstall finds nothing installed.
+	err = u.Uninstall(context.Background(), testRecipeName)
+	require.Error(t, err)
+	require.Equal(t, 1, len(e.executed))
+}
+
+func TestUninstall_MissingSecrets(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	os.Setenv("TEST_UNINSTALL_SECRET", "fromEnv")
+	defer os.Unsetenv("TEST_UNINSTALL_SECRET")
+
+	require.NoError(t, l.Append(execution.LedgerEntry{
+		Recipe:    testRecipeName,
+		Status:    execution.RecipeStatusTypes.INSTALLED,
+		Uninstall: "uninstall",
+		Vars: types.RecipeVars{
+			"TEST_UNINSTALL_SECRET":    execution.RedactedValue,
+			"TEST_UNINSTALL_MISSING_B": execution.RedactedValue,
+			"TEST_UNINSTALL_MISSING_A": execution.RedactedValue,
+		},
+	}))
+
+	e := &recordingExecutor{}
+	u := NewRecipeUninstaller(l, e)
+
+	err := u.Uninstall(context.Background(), testRecipeName)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "TEST_UNINSTALL_MISSING_A, TEST_UNINSTALL_MISSING_B")
+	require.Empty(t, e.executed)
+
+	latest, err := l.Latest(testRecipeName)
+	require.NoError(t, err)
+	require.Equal(t, execution.RecipeStatusTypes.INSTALLED, latest.Status)
+}
+
+func TestUninstall_NotInstalled(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	require.NoError(t, l.Append(execution.LedgerEntry{
+		Recipe:    testRecipeName,
+		Status:    execution.RecipeStatusTypes.FAILED,
+		Uninstall: "uninstall",
+	}))
+
+	e := &recordingExecutor{}
+	u := NewRecipeUninstaller(l, e)
+
+	require.Error(t, u.Uninstall(context.Background(), testRecipeName))
+	require.Error(t, u.Uninstall(context.Background(), anotherTestRecipeName))
+	require.Empty(t, e.executed)
+}
+
+func TestUninstall_NoUninstallTask(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	require.NoError(t, l.Append(execution.LedgerEntry{
+		Recipe: testRecipeName,
+		Status: execution.RecipeStatusTypes.INSTALLED,
+	}))
+
+	e := &recordingExecutor{}
+	u := NewRecipeUninstaller(l, e)
+
+	err := u.Uninstall(context.Background(), testRecipeName)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not define an uninstall task")
+	require.Empty(t, e.executed)
+}
+
+func TestUninstall_ExecuteFails(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	require.NoError(t, l.Append(execution.LedgerEntry{
+		Recipe:    testRecipeName,
+		Status:    execution.RecipeStatusTypes.INSTALLED,
+		Uninstall: "uninstall",
+	}))
+
+	e := &recordingExecutor{err: errors.New("uninstall failed")}
+	u := NewRecipeUninstaller(l, e)
+
+	require.Error(t, u.Uninstall(context.Background(), testRecipeName))
+
+	latest, err := l.Latest(testRecipeName)
+	require.NoError(t, err)
+	require.Equal(t, execution.RecipeStatusTypes.INSTALLED, latest.Status)
+}
@@ -16,30 +16,24 @@ func NewPlainProgress() *PlainProgress {
 }
 
 func (p *PlainProgress) Start(msg string) {
-	c := color.New(color.FgCyan)
-	c.Printf("==>")
-	x := color.New(color.Bold)
-	x.Printf(" %s", msg)
-
-	fmt.Printf("...\n")
+	p.print(msg, "...\n")
 }
 
 func (p *PlainProgress) Success(msg string) {
-	c := color.New(color.FgCyan)
-	c.Printf("==>")
-	x := color.New(color.Bold)
-	x.Printf(" %s", msg)
-
-	fmt.Printf("...success.\n\n")
+	p.print(msg, "...success.\n\n")
 }
 
 func (p *PlainProgress) Fail(msg string) {
+	p.print(msg, "...failed.\n\n")
+}
+
+func (p *PlainProgress) Stop() {}
+
+// print writes the message in a single write, so that progress from recipes
+// installing concurrently does not interleave mid-line.
+func (p *PlainProgress) print(msg string, suffix string) {
 	c := color.New(color.FgCyan)
-	c.Printf("==>")
 	x := color.New(color.Bold)
-	x.Printf(" %s", msg)
 
-	fmt.Printf("...failed.\n\n")
+	fmt.Fprint(color.Output, c.Sprint("==>")+x.Sprintf(" %s", msg)+suffix)
 }
-
-func (p *PlainProgress) Stop() {}
@@ -3,6 +3,7 @@ package validation
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"html/template"
 
 	"github.com/newrelic/newrelic-cli/internal/install/types"
@@ -40,7 +41,7 @@ func (m *PollingRecipeValidator) ValidateRecipe(ctx context.Context, dm types.Di
 func substituteHostname(dm types.DiscoveryManifest, r types.OpenInstallationRecipe) (string, error) {
 	tmpl, err := template.New("validationNRQL").Parse(string(r.ValidationNRQL))
 	if err != nil {
-		panic(err)
+		return "", fmt.Errorf("invalid validation query for %s: %s", r.Name, err)
 	}
 
 	v := struct {
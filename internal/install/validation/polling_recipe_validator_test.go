@@ -151,3 +151,13 @@ func TestValidate_QueryError(t *testing.T) {
 func getTestContext() context.Context {
 	return context.WithValue(context.Background(), TestIdentifierKey, true)
 }
+
+func TestSubstituteHostname_InvalidTemplate(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name:           "test-recipe",
+		ValidationNRQL: "SELECT count(*) FROM Sample WHERE hostname = '{{.HOSTNAME'",
+	}
+
+	_, err := substituteHostname(types.DiscoveryManifest{Hostname: "test"}, r)
+	require.Error(t, err)
+}
@@ -0,0 +1,11 @@
+package recipe
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Command represents the recipe command.
+var Command = &cobra.Command{
+	Use:   "recipe",
+	Short: "Tools for authoring New Relic install recipes",
+}
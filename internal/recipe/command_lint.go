@@ -0,0 +1,83 @@
+package recipe
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+)
+
+var cmdLint = &cobra.Command{
+	Use:   "lint <file>...",
+	Short: "Check recipe files for problems",
+	Long: `Check recipe files for problems
+
+The lint command checks the schema of each recipe file, including required
+fields, install target types, operating systems and platforms, and process
+match regular expressions.  It also checks the go-task definition in the
+install and uninstall blocks, and the template in validationNrql.  Errors and
+warnings are reported with the line they refer to.  The command fails if any
+errors are found.
+`,
+	Example: "newrelic recipe lint mysql.yml",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if failed := lintFiles(args, true); failed > 0 {
+			log.Fatalf("%d of %d recipe files have errors", failed, len(args))
+		}
+	},
+}
+
+var cmdValidate = &cobra.Command{
+	Use:   "validate <file>...",
+	Short: "Check that recipe files are valid",
+	Long: `Check that recipe files are valid
+
+The validate command runs the same checks as lint, but only reports errors.
+Use it to gate recipe changes in CI without failing on style warnings.
+`,
+	Example: "newrelic recipe validate mysql.yml",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if failed := lintFiles(args, false); failed > 0 {
+			log.Fatalf("%d of %d recipe files are invalid", failed, len(args))
+		}
+	},
+}
+
+// lintFiles prints the findings for each file and returns the number of files
+// with errors.
+func lintFiles(paths []string, showWarnings bool) int {
+	l := recipes.NewRecipeLinter()
+	failed := 0
+
+	for _, path := range paths {
+		findings, err := l.LintFile(path)
+		if err != nil {
+			log.Error(err)
+			failed++
+			continue
+		}
+
+		for _, f := range findings {
+			if f.Severity == recipes.LintSeverities.ERROR || showWarnings {
+				fmt.Printf("%s: %s\n", path, f)
+			}
+		}
+
+		if recipes.HasErrors(findings) {
+			failed++
+		} else {
+			fmt.Printf("%s: ok\n", path)
+		}
+	}
+
+	return failed
+}
+
+func init() {
+	Command.AddCommand(cmdLint)
+	Command.AddCommand(cmdValidate)
+}
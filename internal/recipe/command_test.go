@@ -0,0 +1,39 @@
+// +build unit
+
+package recipe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestRecipeCommand(t *testing.T) {
+	assert.Equal(t, "recipe", Command.Name())
+
+	testcobra.CheckCobraMetadata(t, Command)
+	testcobra.CheckCobraRequiredFlags(t, Command, []string{})
+}
+
+func TestLintCommand(t *testing.T) {
+	assert.Equal(t, "lint", cmdLint.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdLint)
+	testcobra.CheckCobraRequiredFlags(t, cmdLint, []string{})
+}
+
+func TestValidateCommand(t *testing.T) {
+	assert.Equal(t, "validate", cmdValidate.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdValidate)
+	testcobra.CheckCobraRequiredFlags(t, cmdValidate, []string{})
+}
+
+func TestTestCommand(t *testing.T) {
+	assert.Equal(t, "test", cmdTest.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdTest)
+	testcobra.CheckCobraRequiredFlags(t, cmdTest, []string{"scenario"})
+}
@@ -0,0 +1,123 @@
+package recipe
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var (
+	scenarioFile      string
+	showOutput        bool
+	allowHostCommands bool
+)
+
+var cmdTest = &cobra.Command{
+	Use:   "test <file>",
+	Short: "Run a recipe against a simulated host",
+	Long: `Run a recipe against a simulated host
+
+The test command runs a recipe against a scenario that describes a simulated
+host: its operating system and platform, running processes and log files.  It
+checks whether the recipe's install targets match the host, runs the recipe's
+filters, and runs the install steps in a temporary working directory.
+Commands listed as stubs in the scenario are replaced by scripts that record
+their arguments, print the given output and exit with the given code.  The
+outcome is compared to the scenario's expectations, and the command fails if
+any are not met.
+
+Install steps may only run stubbed commands and a small set of host commands
+that print or transform text, such as echo, grep and sed.  Any other command,
+such as curl, sudo or systemctl, fails the scenario unless it is stubbed.
+Commands called by their absolute path are not sandboxed.
+
+The --allowHostCommands flag keeps the host's PATH, so that commands which are
+not stubbed run for real.  Only use it on hosts you do not mind the recipe
+changing.
+`,
+	Example: "newrelic recipe test mysql.yml --scenario mysql-ubuntu.yml",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		r, err := recipes.NewRecipeFileFetcher().LoadRecipeFile(args[0])
+		if err != nil {
+			log.Fatalf("could not load recipe %s: %s", args[0], err)
+		}
+
+		s, err := install.LoadRecipeTestScenario(scenarioFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		t := install.NewRecipeTester()
+		if showOutput {
+			t.Stdout = os.Stdout
+		}
+
+		if allowHostCommands {
+			log.Warn("WARNING: host commands are allowed, commands the recipe runs that are not stubbed will run for real and may change this host")
+			t.AllowHostCommands = true
+		}
+
+		result, err := t.Test(utils.SignalCtx, *r, *s)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printTestResult(r.Name, result)
+
+		if !result.Passed() {
+			if !showOutput && result.Output != "" {
+				fmt.Printf("\nrecipe output:\n%s\n", strings.TrimRight(result.Output, "\n"))
+			}
+
+			log.Fatalf("%d of the scenario's expectations were not met", len(result.Failures))
+		}
+	},
+}
+
+func printTestResult(name string, result *install.RecipeTestResult) {
+	fmt.Printf("recipe:    %s\n", name)
+	fmt.Printf("matched:   %t\n", result.Matched)
+
+	if result.Filtered {
+		fmt.Printf("filtered:  %s\n", result.FilterReason)
+	}
+
+	fmt.Printf("status:    %s\n", result.Status)
+
+	if result.Error != "" {
+		fmt.Printf("error:     %s\n", result.Error)
+	}
+
+	if len(result.LogFiles) > 0 {
+		fmt.Printf("log files: %s\n", strings.Join(result.LogFiles, ", "))
+	}
+
+	for _, c := range result.Calls {
+		fmt.Printf("call:      %s\n", c)
+	}
+
+	for _, f := range result.Failures {
+		fmt.Printf("FAIL:      %s\n", f)
+	}
+
+	if result.Passed() {
+		fmt.Println("PASS")
+	}
+}
+
+func init() {
+	Command.AddCommand(cmdTest)
+
+	cmdTest.Flags().StringVarP(&scenarioFile, "scenario", "s", "", "the scenario file describing the simulated host and the expected outcome")
+	cmdTest.Flags().BoolVar(&showOutput, "showOutput", false, "stream the recipe's output while it runs")
+	cmdTest.Flags().BoolVar(&allowHostCommands, "allowHostCommands", false, "run commands that are not stubbed on this host instead of failing")
+	utils.LogIfError(cmdTest.MarkFlagRequired("scenario"))
+}
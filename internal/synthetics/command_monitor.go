@@ -0,0 +1,244 @@
+package synthetics
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/output"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
+	"github.com/newrelic/newrelic-client-go/pkg/synthetics"
+)
+
+var (
+	accountID    int
+	monitorID    string
+	opts         monitorOptions
+	since        string
+	resultsLimit int
+	getScript    bool
+)
+
+var cmdMonitor = &cobra.Command{
+	Use:   "monitor",
+	Short: "Manage New Relic Synthetics monitors",
+	Long: `Manage New Relic Synthetics monitors
+
+The monitor command allows users to list, create, update and delete ping,
+simple browser and scripted Synthetics monitors, and to view their recent check
+results. Use --help for more information.
+`,
+	Example: "newrelic synthetics monitor list",
+}
+
+var cmdMonitorList = &cobra.Command{
+	Use:   "list",
+	Short: "List the Synthetics monitors.",
+	Long: `List the Synthetics monitors
+
+The list command retrieves the Synthetics monitors for the account associated
+with your API key.
+`,
+	Example: `newrelic synthetics monitor list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			monitors, err := nrClient.Synthetics.ListMonitorsWithContext(utils.SignalCtx)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(monitors))
+		})
+	},
+}
+
+var cmdMonitorGet = &cobra.Command{
+	Use:   "get",
+	Short: "Get a Synthetics monitor.",
+	Long: `Get a Synthetics monitor
+
+The get command retrieves a specific Synthetics monitor by its ID.  Use
+--script to retrieve the script of a scripted monitor instead.
+`,
+	Example: `newrelic synthetics monitor get --id 6b2d7f5c-0e57-4e1b-9f2c-7a0f3d4a1b2c`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			if getScript {
+				script, err := nrClient.Synthetics.GetMonitorScriptWithContext(utils.SignalCtx, monitorID)
+				utils.LogIfFatal(err)
+
+				fmt.Print(script.Text)
+				return
+			}
+
+			monitor, err := nrClient.Synthetics.GetMonitorWithContext(utils.SignalCtx, monitorID)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(monitor))
+		})
+	},
+}
+
+var cmdMonitorCreate = &cobra.Command{
+	Use:   "create",
+	Short: "Create a Synthetics monitor.",
+	Long: `Create a Synthetics monitor
+
+The create command creates a new Synthetics monitor.  The monitor type must be
+one of ping, browser, scripted (scripted browser) or api (scripted API).  Ping
+and browser monitors require a URI.  Scripted monitors require a script, which
+is loaded from a local .js file with --script.
+`,
+	Example: `newrelic synthetics monitor create --name "Login flow" --type scripted --frequency 15 --locations AWS_US_EAST_1,AWS_EU_WEST_1 --script login.js`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			monitor := synthetics.Monitor{}
+			utils.LogIfFatal(applyMonitorOptions(&monitor, opts, func(string) bool { return true }))
+
+			var script *synthetics.MonitorScript
+			if isScripted(monitor.Type) {
+				if opts.ScriptFile == "" {
+					log.Fatal("--script is required for scripted monitors")
+				}
+
+				var err error
+				script, err = readMonitorScript(opts.ScriptFile)
+				utils.LogIfFatal(err)
+			}
+
+			created, err := createMonitor(utils.SignalCtx, &nrClient.Synthetics, monitor, script)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(created))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdMonitorUpdate = &cobra.Command{
+	Use:   "update",
+	Short: "Update a Synthetics monitor.",
+	Long: `Update a Synthetics monitor
+
+The update command targets an existing Synthetics monitor by its ID.  Only the
+settings that are provided are changed.  For scripted monitors, --script
+replaces the monitor's script with the contents of a local .js file.
+`,
+	Example: `newrelic synthetics monitor update --id 6b2d7f5c-0e57-4e1b-9f2c-7a0f3d4a1b2c --script login.js`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			monitor, err := nrClient.Synthetics.GetMonitorWithContext(utils.SignalCtx, monitorID)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(applyMonitorOptions(monitor, opts, cmd.Flags().Changed))
+
+			updated, err := nrClient.Synthetics.UpdateMonitorWithContext(utils.SignalCtx, *monitor)
+			utils.LogIfFatal(err)
+
+			if opts.ScriptFile != "" {
+				var script *synthetics.MonitorScript
+				script, err = readMonitorScript(opts.ScriptFile)
+				utils.LogIfFatal(err)
+
+				_, err = nrClient.Synthetics.UpdateMonitorScriptWithContext(utils.SignalCtx, monitorID, *script)
+				utils.LogIfFatal(err)
+			}
+
+			utils.LogIfFatal(output.Print(updated))
+			log.Info("success")
+		})
+	},
+}
+
+var cmdMonitorDelete = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a Synthetics monitor.",
+	Long: `Delete a Synthetics monitor
+
+The delete command deletes a Synthetics monitor by its ID.
+`,
+	Example: `newrelic synthetics monitor delete --id 6b2d7f5c-0e57-4e1b-9f2c-7a0f3d4a1b2c`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			utils.LogIfFatal(nrClient.Synthetics.DeleteMonitorWithContext(utils.SignalCtx, monitorID))
+
+			log.Info("success")
+		})
+	},
+}
+
+var cmdMonitorResults = &cobra.Command{
+	Use:   "results",
+	Short: "Show the recent check results of a Synthetics monitor.",
+	Long: `Show the recent check results of a Synthetics monitor
+
+The results command queries the SyntheticCheck events for the given monitor,
+and shows the time, result, duration, location and error of each recent check.
+`,
+	Example: `newrelic synthetics monitor results --accountId 12345678 --id 6b2d7f5c-0e57-4e1b-9f2c-7a0f3d4a1b2c --since "3 hours ago"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			query := buildResultsQuery(monitorID, since, resultsLimit)
+
+			result, err := nrClient.Nrdb.QueryWithContext(utils.SignalCtx, accountID, nrdb.NRQL(query))
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(output.Print(result.Results))
+		})
+	},
+}
+
+func addMonitorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&opts.Name, "name", "n", "", "the name of the monitor")
+	cmd.Flags().StringVarP(&opts.Type, "type", "t", "", "the type of the monitor: ping, browser, scripted or api")
+	cmd.Flags().UintVarP(&opts.Frequency, "frequency", "f", 10, "how often the monitor runs, in minutes")
+	cmd.Flags().StringVarP(&opts.URI, "uri", "u", "", "the URI checked by ping and browser monitors")
+	cmd.Flags().StringSliceVarP(&opts.Locations, "locations", "l", []string{}, "the locations the monitor runs from")
+	cmd.Flags().StringVar(&opts.Status, "status", "ENABLED", "the status of the monitor: ENABLED, MUTED or DISABLED")
+	cmd.Flags().Float64Var(&opts.SLAThreshold, "slaThreshold", 7.0, "the Apdex threshold used for SLA reports, in seconds")
+	cmd.Flags().StringVar(&opts.ValidationString, "validationString", "", "text that must be present in the response of ping monitors")
+	cmd.Flags().BoolVar(&opts.VerifySSL, "verifySSL", false, "verify the SSL certificate of ping monitors")
+	cmd.Flags().StringVarP(&opts.ScriptFile, "script", "s", "", "a local .js file containing the script of a scripted monitor")
+}
+
+func init() {
+	Command.AddCommand(cmdMonitor)
+
+	// List
+	cmdMonitor.AddCommand(cmdMonitorList)
+
+	// Get
+	cmdMonitor.AddCommand(cmdMonitorGet)
+	cmdMonitorGet.Flags().StringVarP(&monitorID, "id", "i", "", "the ID of the monitor")
+	cmdMonitorGet.Flags().BoolVar(&getScript, "script", false, "print the monitor's script instead of its settings")
+	utils.LogIfError(cmdMonitorGet.MarkFlagRequired("id"))
+
+	// Create
+	cmdMonitor.AddCommand(cmdMonitorCreate)
+	addMonitorFlags(cmdMonitorCreate)
+	utils.LogIfError(cmdMonitorCreate.MarkFlagRequired("name"))
+	utils.LogIfError(cmdMonitorCreate.MarkFlagRequired("type"))
+	utils.LogIfError(cmdMonitorCreate.MarkFlagRequired("locations"))
+
+	// Update
+	cmdMonitor.AddCommand(cmdMonitorUpdate)
+	cmdMonitorUpdate.Flags().StringVarP(&monitorID, "id", "i", "", "the ID of the monitor you want to update")
+	addMonitorFlags(cmdMonitorUpdate)
+	utils.LogIfError(cmdMonitorUpdate.MarkFlagRequired("id"))
+
+	// Delete
+	cmdMonitor.AddCommand(cmdMonitorDelete)
+	cmdMonitorDelete.Flags().StringVarP(&monitorID, "id", "i", "", "the ID of the monitor you want to delete")
+	utils.LogIfError(cmdMonitorDelete.MarkFlagRequired("id"))
+
+	// Results
+	cmdMonitor.AddCommand(cmdMonitorResults)
+	cmdMonitorResults.Flags().IntVarP(&accountID, "accountId", "a", 0, "the New Relic account ID where the monitor is located")
+	cmdMonitorResults.Flags().StringVarP(&monitorID, "id", "i", "", "the ID of the monitor")
+	cmdMonitorResults.Flags().StringVar(&since, "since", "1 day ago", "how far back to look for results, as a NRQL SINCE clause")
+	cmdMonitorResults.Flags().IntVar(&resultsLimit, "limit", 20, "the maximum number of results to show")
+	utils.LogIfError(cmdMonitorResults.MarkFlagRequired("accountId"))
+	utils.LogIfError(cmdMonitorResults.MarkFlagRequired("id"))
+}
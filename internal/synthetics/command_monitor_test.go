@@ -0,0 +1,60 @@
+// +build unit
+
+package synthetics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestMonitor(t *testing.T) {
+	assert.Equal(t, "monitor", cmdMonitor.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdMonitor)
+	testcobra.CheckCobraRequiredFlags(t, cmdMonitor, []string{})
+}
+
+func TestMonitorList(t *testing.T) {
+	assert.Equal(t, "list", cmdMonitorList.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdMonitorList)
+	testcobra.CheckCobraRequiredFlags(t, cmdMonitorList, []string{})
+}
+
+func TestMonitorGet(t *testing.T) {
+	assert.Equal(t, "get", cmdMonitorGet.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdMonitorGet)
+	testcobra.CheckCobraRequiredFlags(t, cmdMonitorGet, []string{"id"})
+}
+
+func TestMonitorCreate(t *testing.T) {
+	assert.Equal(t, "create", cmdMonitorCreate.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdMonitorCreate)
+	testcobra.CheckCobraRequiredFlags(t, cmdMonitorCreate, []string{"name", "type", "locations"})
+}
+
+func TestMonitorUpdate(t *testing.T) {
+	assert.Equal(t, "update", cmdMonitorUpdate.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdMonitorUpdate)
+	testcobra.CheckCobraRequiredFlags(t, cmdMonitorUpdate, []string{"id"})
+}
+
+func TestMonitorDelete(t *testing.T) {
+	assert.Equal(t, "delete", cmdMonitorDelete.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdMonitorDelete)
+	testcobra.CheckCobraRequiredFlags(t, cmdMonitorDelete, []string{"id"})
+}
+
+func TestMonitorResults(t *testing.T) {
+	assert.Equal(t, "results", cmdMonitorResults.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdMonitorResults)
+	testcobra.CheckCobraRequiredFlags(t, cmdMonitorResults, []string{"accountId", "id"})
+}
@@ -0,0 +1,18 @@
+// +build unit
+
+package synthetics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestSyntheticsCommand(t *testing.T) {
+	assert.Equal(t, "synthetics", Command.Name())
+
+	testcobra.CheckCobraMetadata(t, Command)
+	testcobra.CheckCobraRequiredFlags(t, Command, []string{})
+}
@@ -0,0 +1,173 @@
+package synthetics
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/pkg/synthetics"
+)
+
+// monitorTypeAliases maps the monitor type names accepted on the command line
+// to the Synthetics API monitor types.
+var monitorTypeAliases = map[string]synthetics.MonitorType{
+	"ping":           synthetics.MonitorTypes.Ping,
+	"simple":         synthetics.MonitorTypes.Ping,
+	"browser":        synthetics.MonitorTypes.Browser,
+	"scripted":       synthetics.MonitorTypes.ScriptedBrowser,
+	"script_browser": synthetics.MonitorTypes.ScriptedBrowser,
+	"api":            synthetics.MonitorTypes.APITest,
+	"script_api":     synthetics.MonitorTypes.APITest,
+}
+
+// monitorOptions holds the monitor settings that can be provided on the
+// command line.
+type monitorOptions struct {
+	Name             string
+	Type             string
+	Frequency        uint
+	URI              string
+	Locations        []string
+	Status           string
+	SLAThreshold     float64
+	ValidationString string
+	VerifySSL        bool
+	ScriptFile       string
+}
+
+func parseMonitorType(value string) (synthetics.MonitorType, error) {
+	if t, ok := monitorTypeAliases[strings.ToLower(value)]; ok {
+		return t, nil
+	}
+
+	return "", fmt.Errorf("monitor type must be one of ping, browser, scripted or api, got %q", value)
+}
+
+func parseMonitorStatus(value string) (synthetics.MonitorStatusType, error) {
+	switch s := synthetics.MonitorStatusType(strings.ToUpper(value)); s {
+	case synthetics.MonitorStatus.Enabled, synthetics.MonitorStatus.Muted, synthetics.MonitorStatus.Disabled:
+		return s, nil
+	}
+
+	return "", fmt.Errorf("monitor status must be one of ENABLED, MUTED or DISABLED, got %q", value)
+}
+
+func isScripted(t synthetics.MonitorType) bool {
+	return t == synthetics.MonitorTypes.ScriptedBrowser || t == synthetics.MonitorTypes.APITest
+}
+
+// applyMonitorOptions copies the options that were set on the command line
+// onto the given monitor.  The changed func reports whether a flag was set.
+func applyMonitorOptions(m *synthetics.Monitor, o monitorOptions, changed func(string) bool) error {
+	if changed("name") {
+		m.Name = o.Name
+	}
+
+	if changed("type") {
+		t, err := parseMonitorType(o.Type)
+		if err != nil {
+			return err
+		}
+
+		m.Type = t
+	}
+
+	if changed("frequency") {
+		m.Frequency = o.Frequency
+	}
+
+	if changed("uri") {
+		m.URI = o.URI
+	}
+
+	if changed("locations") {
+		m.Locations = o.Locations
+	}
+
+	if changed("status") {
+		s, err := parseMonitorStatus(o.Status)
+		if err != nil {
+			return err
+		}
+
+		m.Status = s
+	}
+
+	if changed("slaThreshold") {
+		m.SLAThreshold = o.SLAThreshold
+	}
+
+	if changed("validationString") {
+		m.Options.ValidationString = o.ValidationString
+	}
+
+	if changed("verifySSL") {
+		m.Options.VerifySSL = o.VerifySSL
+	}
+
+	if o.ScriptFile != "" && !isScripted(m.Type) {
+		return fmt.Errorf("a script can only be provided for scripted monitors, not %s monitors", m.Type)
+	}
+
+	if !isScripted(m.Type) && m.URI == "" {
+		return fmt.Errorf("a URI is required for %s monitors", m.Type)
+	}
+
+	return nil
+}
+
+// monitorCreator is the subset of the Synthetics client needed to create a
+// monitor with a script.
+type monitorCreator interface {
+	CreateMonitorWithContext(context.Context, synthetics.Monitor) (*synthetics.Monitor, error)
+	UpdateMonitorScriptWithContext(context.Context, string, synthetics.MonitorScript) (*synthetics.MonitorScript, error)
+	DeleteMonitorWithContext(context.Context, string) error
+}
+
+// createMonitor creates a monitor and, if a script is given, uploads it.  The
+// monitor is deleted again if its script cannot be uploaded, so that a failed
+// create does not leave a scripted monitor without its script behind.
+func createMonitor(ctx context.Context, client monitorCreator, m synthetics.Monitor, script *synthetics.MonitorScript) (*synthetics.Monitor, error) {
+	created, err := client.CreateMonitorWithContext(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if script == nil {
+		return created, nil
+	}
+
+	if _, err = client.UpdateMonitorScriptWithContext(ctx, created.ID, *script); err != nil {
+		if deleteErr := client.DeleteMonitorWithContext(ctx, created.ID); deleteErr != nil {
+			return nil, fmt.Errorf("could not upload the script of monitor %s: %s; the monitor could not be deleted and must be removed manually: %s",
+				created.ID, err, deleteErr)
+		}
+
+		return nil, fmt.Errorf("could not upload the monitor script, the monitor was not created: %s", err)
+	}
+
+	return created, nil
+}
+
+// readMonitorScript loads a monitor script from a local file.
+func readMonitorScript(file string) (*synthetics.MonitorScript, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read monitor script %s: %s", file, err)
+	}
+
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, fmt.Errorf("monitor script %s is empty", file)
+	}
+
+	return &synthetics.MonitorScript{Text: string(data)}, nil
+}
+
+func buildResultsQuery(monitorID string, since string, limit int) string {
+	return fmt.Sprintf(
+		"SELECT timestamp, result, duration, locationLabel, error FROM SyntheticCheck WHERE monitorId = '%s' SINCE %s LIMIT %d",
+		utils.EscapeNRQLString(monitorID), since, limit,
+	)
+}
@@ -0,0 +1,153 @@
+// +build unit
+
+package synthetics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/pkg/synthetics"
+)
+
+func TestParseMonitorType(t *testing.T) {
+	for value, expected := range map[string]synthetics.MonitorType{
+		"ping":     synthetics.MonitorTypes.Ping,
+		"BROWSER":  synthetics.MonitorTypes.Browser,
+		"scripted": synthetics.MonitorTypes.ScriptedBrowser,
+		"api":      synthetics.MonitorTypes.APITest,
+	} {
+		actual, err := parseMonitorType(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	_, err := parseMonitorType("cert_check")
+	require.Error(t, err)
+}
+
+func TestApplyMonitorOptions_All(t *testing.T) {
+	m := synthetics.Monitor{}
+	o := monitorOptions{
+		Name:      "Homepage",
+		Type:      "ping",
+		Frequency: 5,
+		URI:       "https://example.com",
+		Locations: []string{"AWS_US_EAST_1"},
+		Status:    "enabled",
+		VerifySSL: true,
+	}
+
+	require.NoError(t, applyMonitorOptions(&m, o, func(string) bool { return true }))
+
+	assert.Equal(t, "Homepage", m.Name)
+	assert.Equal(t, synthetics.MonitorTypes.Ping, m.Type)
+	assert.Equal(t, uint(5), m.Frequency)
+	assert.Equal(t, synthetics.MonitorStatus.Enabled, m.Status)
+	assert.True(t, m.Options.VerifySSL)
+}
+
+func TestApplyMonitorOptions_OnlyChanged(t *testing.T) {
+	m := synthetics.Monitor{Name: "Homepage", Type: synthetics.MonitorTypes.Ping, URI: "https://example.com", Frequency: 10}
+	o := monitorOptions{Name: "ignored", Frequency: 1}
+
+	changed := func(name string) bool { return name == "frequency" }
+	require.NoError(t, applyMonitorOptions(&m, o, changed))
+
+	assert.Equal(t, "Homepage", m.Name)
+	assert.Equal(t, uint(1), m.Frequency)
+}
+
+func TestApplyMonitorOptions_Validation(t *testing.T) {
+	all := func(string) bool { return true }
+
+	err := applyMonitorOptions(&synthetics.Monitor{}, monitorOptions{Type: "ping", Status: "ENABLED"}, all)
+	require.Error(t, err)
+
+	err = applyMonitorOptions(&synthetics.Monitor{}, monitorOptions{Type: "ping", Status: "ENABLED", URI: "https://example.com", ScriptFile: "a.js"}, all)
+	require.Error(t, err)
+
+	err = applyMonitorOptions(&synthetics.Monitor{}, monitorOptions{Type: "scripted", Status: "PAUSED"}, all)
+	require.Error(t, err)
+
+	err = applyMonitorOptions(&synthetics.Monitor{}, monitorOptions{Type: "scripted", Status: "MUTED", ScriptFile: "a.js"}, all)
+	require.NoError(t, err)
+}
+
+func TestReadMonitorScript(t *testing.T) {
+	f, err := ioutil.TempFile("", "monitor*.js")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("$browser.get('https://example.com');\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	script, err := readMonitorScript(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "$browser.get('https://example.com');\n", script.Text)
+
+	_, err = readMonitorScript("does-not-exist.js")
+	require.Error(t, err)
+}
+
+func TestBuildResultsQuery(t *testing.T) {
+	assert.Equal(t,
+		"SELECT timestamp, result, duration, locationLabel, error FROM SyntheticCheck WHERE monitorId = 'abc' SINCE 1 day ago LIMIT 20",
+		buildResultsQuery("abc", "1 day ago", 20),
+	)
+
+	assert.Equal(t,
+		`SELECT timestamp, result, duration, locationLabel, error FROM SyntheticCheck WHERE monitorId = 'abc\\' SINCE 1 day ago LIMIT 20`,
+		buildResultsQuery(`abc\`, "1 day ago", 20),
+	)
+}
+
+type mockMonitorCreator struct {
+	scriptErr error
+	deleteErr error
+	deleted   []string
+}
+
+func (c *mockMonitorCreator) CreateMonitorWithContext(ctx context.Context, m synthetics.Monitor) (*synthetics.Monitor, error) {
+	m.ID = "test-monitor"
+	return &m, nil
+}
+
+func (c *mockMonitorCreator) UpdateMonitorScriptWithContext(context.Context, string, synthetics.MonitorScript) (*synthetics.MonitorScript, error) {
+	return &synthetics.MonitorScript{}, c.scriptErr
+}
+
+func (c *mockMonitorCreator) DeleteMonitorWithContext(ctx context.Context, id string) error {
+	c.deleted = append(c.deleted, id)
+	return c.deleteErr
+}
+
+func TestCreateMonitor(t *testing.T) {
+	script := &synthetics.MonitorScript{Text: "c2NyaXB0"}
+
+	c := &mockMonitorCreator{}
+	created, err := createMonitor(context.Background(), c, synthetics.Monitor{Name: "test"}, script)
+	require.NoError(t, err)
+	assert.Equal(t, "test-monitor", created.ID)
+	assert.Empty(t, c.deleted)
+}
+
+func TestCreateMonitor_ScriptFails(t *testing.T) {
+	script := &synthetics.MonitorScript{Text: "c2NyaXB0"}
+
+	c := &mockMonitorCreator{scriptErr: errors.New("bad script")}
+	_, err := createMonitor(context.Background(), c, synthetics.Monitor{Name: "test"}, script)
+	require.Error(t, err)
+	assert.Equal(t, []string{"test-monitor"}, c.deleted)
+
+	c = &mockMonitorCreator{scriptErr: errors.New("bad script"), deleteErr: errors.New("delete failed")}
+	_, err = createMonitor(context.Background(), c, synthetics.Monitor{Name: "test"}, script)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "test-monitor")
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"context"
+	"strings"
 
 	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
 )
@@ -9,3 +10,9 @@ import (
 type NRDBClient interface {
 	QueryWithContext(context.Context, int, nrdb.NRQL) (*nrdb.NRDBResultContainer, error)
 }
+
+// EscapeNRQLString escapes a value for use in a single-quoted NRQL string.
+// Backslashes are escaped first, so they cannot escape the added quotes.
+func EscapeNRQLString(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", `\'`)
+}
@@ -0,0 +1,191 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+var (
+	alertPolicyResourceName   = "newrelic_alert_policy"
+	nrqlConditionResourceName = "newrelic_nrql_alert_condition"
+	nonLabelCharsRE           = regexp.MustCompile("[^a-z0-9]+")
+)
+
+// GenerateAlertPolicyHCL generates HCL for a newrelic_alert_policy resource and a
+// newrelic_nrql_alert_condition resource for each of the given conditions.  The
+// conditions reference the policy resource rather than its ID.
+func GenerateAlertPolicyHCL(resourceLabel string, shiftWidth int, policy alerts.AlertsPolicy, conditions []*alerts.NrqlAlertCondition) (string, error) {
+	p := ExportedAlertPolicy{Policy: policy, Conditions: conditions}
+	return generateAlertPolicyHCL(resourceLabel, shiftWidth, p, NrqlConditionLabels(resourceLabel, conditions))
+}
+
+func generateAlertPolicyHCL(resourceLabel string, shiftWidth int, p ExportedAlertPolicy, labels []string) (string, error) {
+	policy := p.Policy
+	h := NewHCLGen(shiftWidth)
+	h.WriteBlock("resource", []string{alertPolicyResourceName, resourceLabel}, func() {
+		h.WriteStringAttribute("name", policy.Name)
+		h.WriteStringAttributeIfNotEmpty("incident_preference", string(policy.IncidentPreference))
+		h.WriteIntAttributeIfNotZero("account_id", policy.AccountID)
+	})
+
+	for i, c := range p.Conditions {
+		if err := writeNrqlCondition(h, labels[i], resourceLabel, policy.AccountID, c); err != nil {
+			return "", err
+		}
+	}
+
+	return Format(h.String()), nil
+}
+
+// GenerateAlertPolicyImports returns the terraform import commands needed to
+// bring an existing policy and its conditions under management using the
+// resources generated by GenerateAlertPolicyHCL.
+func GenerateAlertPolicyImports(resourceLabel string, policy alerts.AlertsPolicy, conditions []*alerts.NrqlAlertCondition) []string {
+	p := ExportedAlertPolicy{Policy: policy, Conditions: conditions}
+	return generateAlertPolicyImports(resourceLabel, p, NrqlConditionLabels(resourceLabel, conditions))
+}
+
+func generateAlertPolicyImports(resourceLabel string, p ExportedAlertPolicy, labels []string) []string {
+	imports := []string{
+		importCommand(alertPolicyResourceName, resourceLabel, p.Policy.ID),
+	}
+
+	for i, c := range p.Conditions {
+		id := fmt.Sprintf("%s:%s:%s", p.Policy.ID, c.ID, strings.ToLower(string(c.Type)))
+		imports = append(imports, importCommand(nrqlConditionResourceName, labels[i], id))
+	}
+
+	return imports
+}
+
+// NrqlConditionLabels derives a unique resource label for each condition from
+// the policy resource label and the condition name.
+func NrqlConditionLabels(resourceLabel string, conditions []*alerts.NrqlAlertCondition) []string {
+	return nrqlConditionLabels(map[string]bool{}, resourceLabel, conditions)
+}
+
+// nrqlConditionLabels derives condition labels that are also unique among the
+// labels already used, so that conditions of different policies do not
+// collide.
+func nrqlConditionLabels(used map[string]bool, resourceLabel string, conditions []*alerts.NrqlAlertCondition) []string {
+	labels := make([]string, len(conditions))
+
+	for i, c := range conditions {
+		l := resourceLabel
+		if name := ToResourceLabel(c.Name); name != "" {
+			l += "_" + name
+		}
+
+		labels[i] = uniqueLabel(used, l)
+	}
+
+	return labels
+}
+
+// uniqueLabel returns the label, or if it has already been used, the label
+// with the lowest numeric suffix that has not.  The returned label is marked
+// as used.
+func uniqueLabel(used map[string]bool, label string) string {
+	l := label
+	for n := 2; used[l]; n++ {
+		l = fmt.Sprintf("%s_%d", label, n)
+	}
+
+	used[l] = true
+
+	return l
+}
+
+// ToResourceLabel converts an arbitrary name into a snake case string that is
+// safe to use as a Terraform resource label.
+func ToResourceLabel(name string) string {
+	l := strings.Trim(nonLabelCharsRE.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if l != "" && l[0] >= '0' && l[0] <= '9' {
+		l = "_" + l
+	}
+
+	return l
+}
+
+func writeNrqlCondition(h *HCLGen, label string, policyLabel string, accountID int, c *alerts.NrqlAlertCondition) error {
+	switch c.Type {
+	case alerts.NrqlConditionTypes.Static, alerts.NrqlConditionTypes.Baseline, alerts.NrqlConditionTypes.Outlier:
+	default:
+		return fmt.Errorf("unsupported type \"%s\" for NRQL condition \"%s\"", c.Type, c.Name)
+	}
+
+	h.WriteBlock("resource", []string{nrqlConditionResourceName, label}, func() {
+		h.WriteIntAttributeIfNotZero("account_id", accountID)
+		h.WriteReferenceAttribute("policy_id", fmt.Sprintf("%s.%s.id", alertPolicyResourceName, policyLabel))
+		h.WriteStringAttribute("type", strings.ToLower(string(c.Type)))
+		h.WriteStringAttribute("name", c.Name)
+		h.WriteStringAttributeIfNotEmpty("description", c.Description)
+		h.WriteStringAttributeIfNotEmpty("runbook_url", c.RunbookURL)
+		h.WriteBoolAttribute("enabled", c.Enabled)
+
+		if c.ViolationTimeLimitSeconds != 0 {
+			h.WriteIntAttribute("violation_time_limit_seconds", c.ViolationTimeLimitSeconds)
+		} else {
+			h.WriteStringAttributeIfNotEmpty("violation_time_limit", string(c.ViolationTimeLimit))
+		}
+
+		if c.ValueFunction != nil {
+			h.WriteStringAttribute("value_function", strings.ToLower(string(*c.ValueFunction)))
+		}
+
+		if c.BaselineDirection != nil {
+			h.WriteStringAttribute("baseline_direction", strings.ToLower(string(*c.BaselineDirection)))
+		}
+
+		if c.ExpectedGroups != nil {
+			h.WriteIntAttribute("expected_groups", *c.ExpectedGroups)
+		}
+		h.WriteBoolAttributeIfNotNil("open_violation_on_group_overlap", c.OpenViolationOnGroupOverlap)
+
+		if s := c.Signal; s != nil {
+			if s.AggregationWindow != nil {
+				h.WriteIntAttribute("aggregation_window", *s.AggregationWindow)
+			}
+			if s.FillOption != nil {
+				h.WriteStringAttribute("fill_option", strings.ToLower(string(*s.FillOption)))
+			}
+			h.WriteFloatAttributeIfNotNil("fill_value", s.FillValue)
+		}
+
+		if e := c.Expiration; e != nil {
+			if e.ExpirationDuration != nil {
+				h.WriteIntAttribute("expiration_duration", *e.ExpirationDuration)
+			}
+			h.WriteBoolAttribute("open_violation_on_expiration", e.OpenViolationOnExpiration)
+			h.WriteBoolAttribute("close_violations_on_expiration", e.CloseViolationsOnExpiration)
+		}
+
+		h.WriteBlock("nrql", []string{}, func() {
+			h.WriteMultilineStringAttribute("query", c.Nrql.Query)
+			h.WriteIntAttributeIfNotZero("evaluation_offset", c.Nrql.EvaluationOffset)
+		})
+
+		for _, t := range c.Terms {
+			priority := alerts.NrqlConditionPriorities.Critical
+			if t.Priority != "" {
+				priority = t.Priority
+			}
+
+			h.WriteBlock(strings.ToLower(string(priority)), []string{}, func() {
+				h.WriteStringAttribute("operator", strings.ToLower(string(t.Operator)))
+				h.WriteFloatAttributeIfNotNil("threshold", t.Threshold)
+				h.WriteIntAttributeIfNotZero("threshold_duration", t.ThresholdDuration)
+				h.WriteStringAttributeIfNotEmpty("threshold_occurrences", strings.ToLower(string(t.ThresholdOccurrences)))
+			})
+		}
+	})
+
+	return nil
+}
+
+func importCommand(resourceType string, label string, id string) string {
+	return fmt.Sprintf("terraform import %s.%s %s", resourceType, label, id)
+}
@@ -0,0 +1,116 @@
+// +build unit
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+var testPolicy = alerts.AlertsPolicy{
+	AccountID:          12345,
+	ID:                 "111",
+	IncidentPreference: alerts.AlertsIncidentPreferenceTypes.PER_CONDITION,
+	Name:               "Production",
+}
+
+func testConditions() []*alerts.NrqlAlertCondition {
+	threshold := 90.5
+	valueFunction := alerts.NrqlConditionValueFunctions.SingleValue
+	direction := alerts.NrqlBaselineDirections.UpperOnly
+
+	return []*alerts.NrqlAlertCondition{
+		{
+			ID: "222",
+			NrqlConditionBase: alerts.NrqlConditionBase{
+				Name:                      "High CPU",
+				Enabled:                   true,
+				Type:                      alerts.NrqlConditionTypes.Static,
+				ViolationTimeLimitSeconds: 3600,
+				Nrql:                      alerts.NrqlConditionQuery{Query: "SELECT average(cpuPercent) FROM SystemSample", EvaluationOffset: 3},
+				Terms: []alerts.NrqlConditionTerm{
+					{
+						Operator:             alerts.AlertsNRQLConditionTermsOperatorTypes.ABOVE,
+						Priority:             alerts.NrqlConditionPriorities.Critical,
+						Threshold:            &threshold,
+						ThresholdDuration:    300,
+						ThresholdOccurrences: alerts.ThresholdOccurrences.All,
+					},
+				},
+			},
+			ValueFunction: &valueFunction,
+		},
+		{
+			ID: "333",
+			NrqlConditionBase: alerts.NrqlConditionBase{
+				Name: "High CPU",
+				Type: alerts.NrqlConditionTypes.Baseline,
+				Nrql: alerts.NrqlConditionQuery{Query: "SELECT count(*) FROM Transaction"},
+			},
+			BaselineDirection: &direction,
+		},
+	}
+}
+
+func TestGenerateAlertPolicyHCL(t *testing.T) {
+	hcl, err := GenerateAlertPolicyHCL("production", 2, testPolicy, testConditions())
+	require.NoError(t, err)
+	hcl = requireValidHCL(t, hcl)
+
+	assert.Contains(t, hcl, `resource "newrelic_alert_policy" "production" {`)
+	assert.Contains(t, hcl, `  incident_preference = "PER_CONDITION"`)
+	assert.Contains(t, hcl, `resource "newrelic_nrql_alert_condition" "production_high_cpu" {`)
+	assert.Contains(t, hcl, `resource "newrelic_nrql_alert_condition" "production_high_cpu_2" {`)
+	assert.Contains(t, hcl, `  policy_id = newrelic_alert_policy.production.id`)
+	assert.Contains(t, hcl, `  type = "static"`)
+	assert.Contains(t, hcl, `  value_function = "single_value"`)
+	assert.Contains(t, hcl, `  baseline_direction = "upper_only"`)
+	assert.Contains(t, hcl, `  violation_time_limit_seconds = 3600`)
+	assert.Contains(t, hcl, `    evaluation_offset = 3`)
+	assert.Contains(t, hcl, `  critical {`)
+	assert.Contains(t, hcl, `    operator = "above"`)
+	assert.Contains(t, hcl, `    threshold = 90.5`)
+	assert.Contains(t, hcl, `    threshold_occurrences = "all"`)
+}
+
+func TestGenerateAlertPolicyHCL_UnsupportedType(t *testing.T) {
+	conditions := []*alerts.NrqlAlertCondition{
+		{NrqlConditionBase: alerts.NrqlConditionBase{Name: "Unknown", Type: "UNKNOWN"}},
+	}
+
+	_, err := GenerateAlertPolicyHCL("production", 2, testPolicy, conditions)
+	require.Error(t, err)
+}
+
+func TestGenerateAlertPolicyImports(t *testing.T) {
+	imports := GenerateAlertPolicyImports("production", testPolicy, testConditions())
+
+	assert.Equal(t, []string{
+		"terraform import newrelic_alert_policy.production 111",
+		"terraform import newrelic_nrql_alert_condition.production_high_cpu 111:222:static",
+		"terraform import newrelic_nrql_alert_condition.production_high_cpu_2 111:333:baseline",
+	}, imports)
+}
+
+func TestToResourceLabel(t *testing.T) {
+	assert.Equal(t, "high_cpu_on_web_01", ToResourceLabel("High CPU on web-01!"))
+	assert.Equal(t, "_5xx_errors", ToResourceLabel("5xx errors"))
+	assert.Equal(t, "", ToResourceLabel("!!!"))
+}
+
+func TestNrqlConditionLabels_Unique(t *testing.T) {
+	conditions := []*alerts.NrqlAlertCondition{
+		{NrqlConditionBase: alerts.NrqlConditionBase{Name: "a"}},
+		{NrqlConditionBase: alerts.NrqlConditionBase{Name: "a"}},
+		{NrqlConditionBase: alerts.NrqlConditionBase{Name: "a 2"}},
+		{NrqlConditionBase: alerts.NrqlConditionBase{Name: "!!!"}},
+		{NrqlConditionBase: alerts.NrqlConditionBase{Name: "!!!"}},
+	}
+
+	labels := NrqlConditionLabels("x", conditions)
+	assert.Equal(t, []string{"x_a", "x_a_2", "x_a_2_2", "x", "x_2"}, labels)
+}
@@ -0,0 +1,51 @@
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	label       string
+	file        string
+	outFile     string
+	shiftWidth  int
+	snakeCaseRE = regexp.MustCompile("^[a-z]+(_[a-z]+)*$")
+)
+
+// Command represents the terraform command.  It is bound to the utils command
+// from main, since generating HCL for live resources requires an API client.
+var Command = &cobra.Command{
+	Use:   "terraform",
+	Short: "Tools for working with Terraform",
+	Long: `Tools for working with Terraform
+
+The terraform commands can be used for generating Terraform HCL for simple observability
+as code use cases.
+`,
+	Example: `cat terraform.json | newrelic utils terraform dashboard --label my_dashboard_resource`,
+}
+
+func validateLabel(l string) error {
+	if ok := snakeCaseRE.MatchString(l); !ok {
+		return fmt.Errorf("resource label must be formatted with snake case: %s", l)
+	}
+
+	return nil
+}
+
+func writeHCL(hcl string) {
+	if outFile != "" {
+		if err := ioutil.WriteFile(outFile, []byte(hcl), 0644); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Info("success")
+	} else {
+		fmt.Print(hcl)
+	}
+}
@@ -0,0 +1,79 @@
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+var (
+	accountID   int
+	policyID    int
+	importsFile string
+)
+
+var cmdAlertPolicy = &cobra.Command{
+	Use:   "alert-policy",
+	Short: "Generate HCL for an alert policy and its NRQL conditions",
+	Long: `Generate HCL for an alert policy and its NRQL conditions
+
+This command fetches an existing alert policy and its NRQL conditions, and generates
+HCL configuration for the matching newrelic_alert_policy and newrelic_nrql_alert_condition
+resources.  Conditions reference the generated policy resource.
+
+The terraform import commands needed to bring the existing resources under management
+are appended to the output as comments, or can be written to a separate file using
+the --imports option.
+`,
+	Example: `newrelic utils terraform alert-policy --accountId 12345678 --policyId 1234 --label my_policy`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return validateLabel(label)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClient(func(nrClient *newrelic.NewRelic) {
+			policy, err := nrClient.Alerts.QueryPolicyWithContext(utils.SignalCtx, accountID, strconv.Itoa(policyID))
+			utils.LogIfFatal(err)
+
+			criteria := alerts.NrqlConditionsSearchCriteria{PolicyID: policy.ID}
+			conditions, err := nrClient.Alerts.SearchNrqlConditionsQueryWithContext(utils.SignalCtx, accountID, criteria)
+			utils.LogIfFatal(err)
+
+			hcl, err := GenerateAlertPolicyHCL(label, shiftWidth, *policy, conditions)
+			utils.LogIfFatal(err)
+
+			imports := GenerateAlertPolicyImports(label, *policy, conditions)
+
+			if importsFile != "" {
+				utils.LogIfFatal(ioutil.WriteFile(importsFile, []byte(strings.Join(imports, "\n")+"\n"), 0644))
+			} else {
+				hcl += fmt.Sprintf("\n# %s\n", strings.Join(imports, "\n# "))
+			}
+
+			writeHCL(hcl)
+		})
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdAlertPolicy)
+	cmdAlertPolicy.Flags().IntVarP(&accountID, "accountId", "a", 0, "the account ID the alert policy belongs to")
+	utils.LogIfError(cmdAlertPolicy.MarkFlagRequired("accountId"))
+
+	cmdAlertPolicy.Flags().IntVarP(&policyID, "policyId", "p", 0, "the ID of the alert policy to generate HCL for")
+	utils.LogIfError(cmdAlertPolicy.MarkFlagRequired("policyId"))
+
+	cmdAlertPolicy.Flags().StringVarP(&label, "label", "l", "", "the resource label to use for the alert policy resource")
+	utils.LogIfError(cmdAlertPolicy.MarkFlagRequired("label"))
+
+	cmdAlertPolicy.Flags().StringVarP(&outFile, "out", "o", "", "the file to send the generated HCL to")
+	cmdAlertPolicy.Flags().StringVarP(&importsFile, "imports", "i", "", "the file to send the terraform import commands to")
+	cmdAlertPolicy.Flags().IntVarP(&shiftWidth, "shiftWidth", "w", 2, "the indentation shift with of the output")
+}
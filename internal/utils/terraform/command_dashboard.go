@@ -0,0 +1,107 @@
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/dashboard"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+)
+
+var (
+	guid              string
+	accountIDVariable string
+)
+
+var cmdDashboard = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Generate HCL for the newrelic_one_dashboard resource",
+	Long: `Generate HCL for the newrelic_one_dashboard resource
+
+This command generates HCL configuration for newrelic_one_dashboard resources from
+exported JSON documents.  For more detail on exporting dashboards to JSON, see
+https://docs.newrelic.com/docs/query-your-data/explore-query-data/dashboards/manage-your-dashboard/#dash-json
+
+Input can be sourced from STDIN per the provided example, from a file using the --file option,
+or fetched directly from an existing dashboard using the --guid option.
+Output will be sent to STDOUT by default but can be redirected to a file with the --out option.
+
+Use the --accountIdVariable option to extract the account IDs used by the dashboard's
+queries into variable blocks, so the generated HCL can be reused across accounts.
+`,
+	Example: `cat terraform.json | newrelic utils terraform dashboard --label my_dashboard_resource
+  newrelic utils terraform dashboard --guid MjUyMDUyOHxWSVp8REFTSEJPQVJEfDE2NDYzMDQ --label my_dashboard_resource --accountIdVariable account_id`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := validateLabel(label); err != nil {
+			return err
+		}
+
+		if file != "" && guid != "" {
+			return fmt.Errorf("only one of --file or --guid may be provided")
+		}
+
+		if file != "" {
+			if _, err := os.Stat(file); os.IsNotExist(err) {
+				return fmt.Errorf("file not found: %s", file)
+			}
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if guid != "" {
+			client.WithClient(func(nrClient *newrelic.NewRelic) {
+				d, err := dashboard.FetchDashboardInput(utils.SignalCtx, &nrClient.NerdGraph, guid)
+				utils.LogIfFatal(err)
+
+				hcl, err := GenerateDashboardInputHCL(label, shiftWidth, accountIDVariable, *d)
+				utils.LogIfFatal(err)
+
+				writeHCL(hcl)
+			})
+
+			return
+		}
+
+		var input []byte
+		var err error
+		if file != "" {
+			input, err = ioutil.ReadFile(file)
+			if err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			input, err = ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		hcl, err := GenerateDashboardHCL(label, shiftWidth, accountIDVariable, input)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		writeHCL(hcl)
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdDashboard)
+	cmdDashboard.Flags().StringVarP(&label, "label", "l", "", "the resource label to use when generating resource HCL")
+	if err := cmdDashboard.MarkFlagRequired("label"); err != nil {
+		log.Error(err)
+	}
+
+	cmdDashboard.Flags().StringVarP(&file, "file", "f", "", "a file that contains exported dashboard JSON")
+	cmdDashboard.Flags().StringVarP(&guid, "guid", "g", "", "the GUID of an existing dashboard to generate HCL for")
+	cmdDashboard.Flags().StringVarP(&accountIDVariable, "accountIdVariable", "v", "", "extract account IDs into variables with the given name")
+	cmdDashboard.Flags().StringVarP(&outFile, "out", "o", "", "the file to send the generated HCL to")
+	cmdDashboard.Flags().IntVarP(&shiftWidth, "shiftWidth", "w", 2, "the indentation shift with of the output")
+}
@@ -0,0 +1,159 @@
+package terraform
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/credentials"
+	"github.com/newrelic/newrelic-cli/internal/dashboard"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/newrelic/newrelic-client-go/pkg/synthetics"
+)
+
+var outDir string
+
+var cmdExportAccount = &cobra.Command{
+	Use:   "export-account",
+	Short: "Generate HCL for the resources in an account",
+	Long: `Generate HCL for the resources in an account
+
+This command discovers the dashboards, alert policies and their NRQL conditions,
+workloads and Synthetics monitors in an account and generates HCL for them.  One
+file is written per resource type, along with an imports.sh script containing the
+terraform import commands needed to bring the existing resources under management.
+
+Synthetics monitors can only be listed for the account of the default profile,
+so they are left out, with a warning, when exporting any other account.
+`,
+	Example: `newrelic utils terraform export-account --accountId 12345678 --dir ./terraform`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client.WithClientAndProfile(func(nrClient *newrelic.NewRelic, profile *credentials.Profile) {
+			profileAccountID := 0
+			if profile != nil {
+				profileAccountID = profile.AccountID
+			}
+
+			resources, err := fetchAccountResources(utils.SignalCtx, nrClient, accountID, profileAccountID)
+			utils.LogIfFatal(err)
+
+			SortAccountResources(resources)
+
+			export, err := GenerateAccountHCL(shiftWidth, *resources)
+			utils.LogIfFatal(err)
+
+			utils.LogIfFatal(os.MkdirAll(outDir, 0755))
+
+			for _, name := range export.FileNames() {
+				utils.LogIfFatal(ioutil.WriteFile(filepath.Join(outDir, name), []byte(export.Files[name]), 0644))
+				log.Infof("wrote %s", filepath.Join(outDir, name))
+			}
+
+			importsPath := filepath.Join(outDir, importsScriptName)
+			utils.LogIfFatal(ioutil.WriteFile(importsPath, []byte(export.ImportsScript()), 0755))
+			log.Infof("wrote %s", importsPath)
+		})
+	},
+}
+
+func fetchAccountResources(ctx context.Context, nrClient *newrelic.NewRelic, accountID int, profileAccountID int) (*AccountResources, error) {
+	r := &AccountResources{}
+
+	guids, err := dashboard.ListAccountDashboardGUIDs(ctx, &nrClient.NerdGraph, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, guid := range guids {
+		log.Debugf("exporting dashboard %s", guid)
+
+		d, fetchErr := dashboard.FetchDashboardInput(ctx, &nrClient.NerdGraph, guid)
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+
+		r.Dashboards = append(r.Dashboards, ExportedDashboard{GUID: guid, Dashboard: *d})
+	}
+
+	policies, err := nrClient.Alerts.QueryPolicySearchWithContext(ctx, accountID, alerts.AlertsPoliciesSearchCriteriaInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range policies {
+		log.Debugf("exporting alert policy %s", p.ID)
+
+		conditions, searchErr := nrClient.Alerts.SearchNrqlConditionsQueryWithContext(ctx, accountID, alerts.NrqlConditionsSearchCriteria{PolicyID: p.ID})
+		if searchErr != nil {
+			return nil, searchErr
+		}
+
+		r.AlertPolicies = append(r.AlertPolicies, ExportedAlertPolicy{Policy: *p, Conditions: conditions})
+	}
+
+	r.Workloads, err = nrClient.Workloads.ListWorkloadsWithContext(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !monitorsInAccount(accountID, profileAccountID) {
+		log.Warnf("skipping Synthetics monitors, they can only be exported from the default profile's account %d", profileAccountID)
+		return r, nil
+	}
+
+	r.Monitors, err = fetchMonitors(ctx, nrClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// monitorsInAccount returns whether the monitors listed with the profile's
+// API key belong to the account being exported.  The Synthetics API lists the
+// monitors of the API key's account, regardless of the account requested.
+func monitorsInAccount(accountID int, profileAccountID int) bool {
+	return accountID == profileAccountID
+}
+
+func fetchMonitors(ctx context.Context, nrClient *newrelic.NewRelic) ([]ExportedMonitor, error) {
+	monitors, err := nrClient.Synthetics.ListMonitorsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := []ExportedMonitor{}
+	for _, m := range monitors {
+		em := ExportedMonitor{Monitor: *m}
+
+		if scriptedMonitorTypes[m.Type] {
+			var script *synthetics.MonitorScript
+			script, err = nrClient.Synthetics.GetMonitorScriptWithContext(ctx, m.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			em.Script = script.Text
+		}
+
+		exported = append(exported, em)
+	}
+
+	return exported, nil
+}
+
+func init() {
+	Command.AddCommand(cmdExportAccount)
+	cmdExportAccount.Flags().IntVarP(&accountID, "accountId", "a", 0, "the account ID to export resources from")
+	utils.LogIfError(cmdExportAccount.MarkFlagRequired("accountId"))
+
+	cmdExportAccount.Flags().StringVarP(&outDir, "dir", "d", ".", "the directory to write the generated files to")
+	cmdExportAccount.Flags().IntVarP(&shiftWidth, "shiftWidth", "w", 2, "the indentation shift with of the output")
+}
@@ -0,0 +1,44 @@
+// +build unit
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestTerraform(t *testing.T) {
+	assert.Equal(t, "terraform", Command.Name())
+
+	testcobra.CheckCobraMetadata(t, Command)
+}
+
+func TestTerraformDashboard(t *testing.T) {
+	assert.Equal(t, "dashboard", cmdDashboard.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdDashboard)
+	testcobra.CheckCobraRequiredFlags(t, cmdDashboard, []string{"label"})
+}
+
+func TestTerraformAlertPolicy(t *testing.T) {
+	assert.Equal(t, "alert-policy", cmdAlertPolicy.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdAlertPolicy)
+	testcobra.CheckCobraRequiredFlags(t, cmdAlertPolicy, []string{"accountId", "policyId", "label"})
+}
+
+func TestTerraformExportAccount(t *testing.T) {
+	assert.Equal(t, "export-account", cmdExportAccount.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdExportAccount)
+	testcobra.CheckCobraRequiredFlags(t, cmdExportAccount, []string{"accountId"})
+}
+
+func TestMonitorsInAccount(t *testing.T) {
+	assert.True(t, monitorsInAccount(12345, 12345))
+	assert.False(t, monitorsInAccount(12345, 67890))
+	assert.False(t, monitorsInAccount(12345, 0))
+}
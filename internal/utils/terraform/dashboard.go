@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -11,34 +12,44 @@ import (
 
 var (
 	dashboardResourceName = "newrelic_one_dashboard"
+	fallbackWidgetType    = "widget_json"
 	widgetTypes           = map[string]string{
-		"viz.area":      "widget_area",
-		"viz.bar":       "widget_bar",
-		"viz.billboard": "widget_billboard",
-		"viz.bullet":    "widget_bullet",
-		"viz.funnel":    "widget_funnel",
-		"viz.heatmap":   "widget_heatmap",
-		"viz.histogram": "widget_histogram",
-		"viz.json":      "widget_json",
-		"viz.line":      "widget_line",
-		"viz.markdown":  "widget_markdown",
-		"viz.pie":       "widget_pie",
-		"viz.table":     "widget_table",
+		"logger.log-table-widget": "widget_log_table",
+		"viz.area":                "widget_area",
+		"viz.bar":                 "widget_bar",
+		"viz.billboard":           "widget_billboard",
+		"viz.bullet":              "widget_bullet",
+		"viz.funnel":              "widget_funnel",
+		"viz.heatmap":             "widget_heatmap",
+		"viz.histogram":           "widget_histogram",
+		"viz.json":                "widget_json",
+		"viz.line":                "widget_line",
+		"viz.markdown":            "widget_markdown",
+		"viz.pie":                 "widget_pie",
+		"viz.stacked-bar":         "widget_stacked_bar",
+		"viz.table":               "widget_table",
+	}
+	billboardThresholdAttributes = map[string]string{
+		"CRITICAL": "critical",
+		"WARNING":  "warning",
 	}
 )
 
 type DashboardWidgetRawConfiguration struct {
-	DataFormatters    []string                   `json:"dataFormatters"`
-	NRQLQueries       []DashboardWidgetNRQLQuery `json:"nrqlQueries"`
-	LinkedEntityGUIDs []string                   `json:"linkedEntityGuids"`
-	Text              string                     `json:"text"`
-	Facet             DashboardWidgetFacet       `json:"facet"`
-	Legend            DashboardWidgetLegend      `json:"legend"`
-	YAxisLeft         DashboardWidgetYAxisLeft   `json:"yAxisLeft"`
+	DataFormatters    []interface{}                  `json:"dataFormatters"`
+	NRQLQueries       []DashboardWidgetNRQLQuery     `json:"nrqlQueries"`
+	LinkedEntityGUIDs []string                       `json:"linkedEntityGuids"`
+	Text              string                         `json:"text"`
+	Facet             *DashboardWidgetFacet          `json:"facet"`
+	Legend            *DashboardWidgetLegend         `json:"legend"`
+	YAxisLeft         *DashboardWidgetYAxisLeft      `json:"yAxisLeft"`
+	PlatformOptions   *DashboardWidgetPlatformOption `json:"platformOptions"`
+	Thresholds        []DashboardWidgetThreshold     `json:"thresholds"`
+	Limit             *float64                       `json:"limit"`
 }
 
 type DashboardWidgetFacet struct {
-	ShowOtherSeries bool `json:"showOtherSeries"`
+	ShowOtherSeries *bool `json:"showOtherSeries"`
 }
 
 type DashboardWidgetNRQLQuery struct {
@@ -47,20 +58,71 @@ type DashboardWidgetNRQLQuery struct {
 }
 
 type DashboardWidgetLegend struct {
-	Enabled bool `json:"enabled"`
+	Enabled *bool `json:"enabled"`
 }
 
 type DashboardWidgetYAxisLeft struct {
-	Zero bool `json:"zero"`
+	Zero *bool    `json:"zero"`
+	Min  *float64 `json:"min"`
+	Max  *float64 `json:"max"`
+}
+
+type DashboardWidgetPlatformOption struct {
+	IgnoreTimeRange *bool `json:"ignoreTimeRange"`
 }
 
-func GenerateDashboardHCL(resourceLabel string, shiftWidth int, input []byte) (string, error) {
+type DashboardWidgetThreshold struct {
+	AlertSeverity string   `json:"alertSeverity"`
+	Value         *float64 `json:"value"`
+}
+
+// GenerateDashboardHCL generates HCL for a newrelic_one_dashboard resource from
+// exported dashboard JSON.  When accountIDVariable is not empty, account IDs
+// referenced by NRQL queries are extracted into variable blocks.
+func GenerateDashboardHCL(resourceLabel string, shiftWidth int, accountIDVariable string, input []byte) (string, error) {
 	var d dashboards.DashboardInput
 	if err := json.Unmarshal(input, &d); err != nil {
-		log.Fatal(err)
+		return "", fmt.Errorf("failed unmarshaling dashboard JSON: %s", err)
 	}
 
+	return GenerateDashboardInputHCL(resourceLabel, shiftWidth, accountIDVariable, d)
+}
+
+// GenerateDashboardInputHCL generates HCL for a newrelic_one_dashboard resource
+// from a dashboard definition.
+func GenerateDashboardInputHCL(resourceLabel string, shiftWidth int, accountIDVariable string, d dashboards.DashboardInput) (string, error) {
+	configs := map[*dashboards.DashboardWidgetInput]*DashboardWidgetRawConfiguration{}
+	for i := range d.Pages {
+		for j := range d.Pages[i].Widgets {
+			w := &d.Pages[i].Widgets[j]
+
+			config, err := unmarshalDashboardWidgetRawConfiguration(w.Title, w.Visualization.ID, w.RawConfiguration)
+			if err != nil {
+				return "", err
+			}
+
+			configs[w] = config
+		}
+	}
+
+	accountIDVariables := map[int]string{}
 	h := NewHCLGen(shiftWidth)
+
+	if accountIDVariable != "" {
+		for _, id := range dashboardAccountIDs(d, configs) {
+			name := accountIDVariable
+			if len(accountIDVariables) > 0 {
+				name = fmt.Sprintf("%s_%d", accountIDVariable, len(accountIDVariables)+1)
+			}
+			accountIDVariables[id] = name
+
+			h.WriteBlock("variable", []string{name}, func() {
+				h.WriteReferenceAttribute("type", "number")
+				h.WriteIntAttribute("default", id)
+			})
+		}
+	}
+
 	h.WriteBlock("resource", []string{dashboardResourceName, resourceLabel}, func() {
 		h.WriteStringAttribute("name", d.Name)
 		h.WriteStringAttributeIfNotEmpty("description", d.Description)
@@ -71,49 +133,122 @@ func GenerateDashboardHCL(resourceLabel string, shiftWidth int, input []byte) (s
 				h.WriteStringAttribute("name", p.Name)
 				h.WriteStringAttributeIfNotEmpty("description", p.Description)
 
-				for _, w := range p.Widgets {
-					requireValidVisualizationID(w.Visualization.ID)
+				for i := range p.Widgets {
+					w := &p.Widgets[i]
+					writeDashboardWidget(h, w, configs[w], accountIDVariables)
+				}
+			})
+		}
+	})
+
+	return Format(h.String()), nil
+}
+
+func writeBillboardThresholds(h *HCLGen, title string, thresholds []DashboardWidgetThreshold) {
+	for _, t := range thresholds {
+		attr, ok := billboardThresholdAttributes[strings.ToUpper(t.AlertSeverity)]
+		if !ok {
+			log.Warnf("unsupported threshold severity \"%s\" for widget \"%s\", skipping", t.AlertSeverity, title)
+			continue
+		}
+
+		h.WriteFloatAttributeIfNotNil(attr, t.Value)
+	}
+}
 
-					h.WriteBlock(widgetTypes[w.Visualization.ID], []string{}, func() {
-						h.WriteStringAttribute("title", w.Title)
-						h.WriteIntAttribute("row", w.Layout.Row)
-						h.WriteIntAttribute("column", w.Layout.Column)
-						h.WriteIntAttribute("height", w.Layout.Height)
-						h.WriteIntAttribute("width", w.Layout.Width)
+func writeDashboardWidget(h *HCLGen, w *dashboards.DashboardWidgetInput, config *DashboardWidgetRawConfiguration, accountIDVariables map[int]string) {
+	widgetType := widgetTypes[w.Visualization.ID]
+	if widgetType == "" {
+		log.Warnf("unrecognized widget type \"%s\" for widget \"%s\", falling back to %s", w.Visualization.ID, w.Title, fallbackWidgetType)
+		widgetType = fallbackWidgetType
+	}
 
-						config := unmarshalDashboardWidgetRawConfiguration(w.Title, widgetTypes[w.Visualization.ID], w.RawConfiguration)
+	h.WriteBlock(widgetType, []string{}, func() {
+		h.WriteStringAttribute("title", w.Title)
+		h.WriteIntAttribute("row", w.Layout.Row)
+		h.WriteIntAttribute("column", w.Layout.Column)
+		h.WriteIntAttribute("height", w.Layout.Height)
+		h.WriteIntAttribute("width", w.Layout.Width)
 
-						h.WriteStringSliceAttributeIfNotEmpty("linked_entity_guids", config.LinkedEntityGUIDs)
-						h.WriteMultilineStringAttributeIfNotEmpty("text", config.Text)
+		linkedEntityGUIDs := config.LinkedEntityGUIDs
+		for _, g := range w.LinkedEntityGUIDs {
+			linkedEntityGUIDs = append(linkedEntityGUIDs, string(g))
+		}
+		h.WriteStringSliceAttributeIfNotEmpty("linked_entity_guids", linkedEntityGUIDs)
+
+		if widgetType == "widget_markdown" {
+			h.WriteMultilineStringAttributeIfNotEmpty("text", config.Text)
+		}
+
+		if o := config.PlatformOptions; o != nil {
+			h.WriteBoolAttributeIfNotNil("ignore_time_range", o.IgnoreTimeRange)
+		}
+
+		if f := config.Facet; f != nil {
+			h.WriteBoolAttributeIfNotNil("facet_show_other_series", f.ShowOtherSeries)
+		}
+
+		if l := config.Legend; l != nil {
+			h.WriteBoolAttributeIfNotNil("legend_enabled", l.Enabled)
+		}
 
-						for _, q := range config.NRQLQueries {
-							h.WriteBlock("nrql_query", []string{}, func() {
-								h.WriteIntAttributeIfNotZero("account_id", q.AccountID)
-								h.WriteMultilineStringAttribute("query", q.Query)
-							})
-						}
-					})
+		if y := config.YAxisLeft; y != nil {
+			h.WriteFloatAttributeIfNotNil("y_axis_left_min", y.Min)
+			h.WriteFloatAttributeIfNotNil("y_axis_left_max", y.Max)
+			if widgetType == "widget_line" {
+				h.WriteBoolAttributeIfNotNil("y_axis_left_zero", y.Zero)
+			}
+		}
+
+		switch widgetType {
+		case "widget_billboard":
+			writeBillboardThresholds(h, w.Title, config.Thresholds)
+		case "widget_bullet":
+			h.WriteFloatAttributeIfNotNil("limit", config.Limit)
+		}
+
+		for _, q := range config.NRQLQueries {
+			h.WriteBlock("nrql_query", []string{}, func() {
+				if v, ok := accountIDVariables[q.AccountID]; ok {
+					h.WriteReferenceAttribute("account_id", "var."+v)
+				} else {
+					h.WriteIntAttributeIfNotZero("account_id", q.AccountID)
 				}
+				h.WriteMultilineStringAttribute("query", q.Query)
 			})
 		}
 	})
-
-	return h.String(), nil
 }
 
-func unmarshalDashboardWidgetRawConfiguration(title string, widgetType string, b []byte) *DashboardWidgetRawConfiguration {
-	var c DashboardWidgetRawConfiguration
-	err := json.Unmarshal(b, &c)
-	if err != nil {
-		log.Fatalf("failed unmarshaling rawConfiguration for widget \"%s\" of type \"%s\"", title, widgetType)
-		panic(err)
+// dashboardAccountIDs returns the distinct account IDs referenced by the
+// dashboard's NRQL queries, in the order they first appear.
+func dashboardAccountIDs(d dashboards.DashboardInput, configs map[*dashboards.DashboardWidgetInput]*DashboardWidgetRawConfiguration) []int {
+	ids := []int{}
+	seen := map[int]bool{}
+
+	for i := range d.Pages {
+		for j := range d.Pages[i].Widgets {
+			for _, q := range configs[&d.Pages[i].Widgets[j]].NRQLQueries {
+				if q.AccountID != 0 && !seen[q.AccountID] {
+					seen[q.AccountID] = true
+					ids = append(ids, q.AccountID)
+				}
+			}
+		}
 	}
 
-	return &c
+	return ids
 }
 
-func requireValidVisualizationID(id string) {
-	if widgetTypes[id] == "" {
-		log.Fatalf("unrecognized widget type \"%s\"", id)
+func unmarshalDashboardWidgetRawConfiguration(title string, visualizationID string, b []byte) (*DashboardWidgetRawConfiguration, error) {
+	var c DashboardWidgetRawConfiguration
+	if len(b) == 0 {
+		return &c, nil
+	}
+
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed unmarshaling rawConfiguration for widget \"%s\" of type \"%s\": %s", title, visualizationID, err)
 	}
+
+	return &c, nil
 }
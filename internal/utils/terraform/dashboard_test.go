@@ -0,0 +1,97 @@
+// +build unit
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDashboardJSON = []byte(`{
+	"name": "Apache",
+	"permissions": "PUBLIC_READ_WRITE",
+	"pages": [{
+		"name": "Overview",
+		"widgets": [
+			{
+				"title": "Servers",
+				"layout": {"column": 1, "row": 1, "height": 3, "width": 4},
+				"visualization": {"id": "viz.billboard"},
+				"rawConfiguration": {
+					"nrqlQueries": [{"accountId": 12345, "query": "SELECT uniqueCount(entityName) FROM ApacheSample"}],
+					"thresholds": [{"alertSeverity": "CRITICAL", "value": 10}, {"alertSeverity": "WARNING", "value": 5.5}, {"alertSeverity": "NOT_ALERTING", "value": 1}],
+					"dataFormatters": [{"name": "Servers", "type": "decimal"}]
+				}
+			},
+			{
+				"title": "Requests",
+				"layout": {"column": 5, "row": 1, "height": 3, "width": 8},
+				"visualization": {"id": "viz.line"},
+				"linkedEntityGuids": ["MTIzNA"],
+				"rawConfiguration": {
+					"nrqlQueries": [
+						{"accountId": 12345, "query": "SELECT count(*) FROM Transaction TIMESERIES"},
+						{"accountId": 67890, "query": "SELECT count(*) FROM Transaction TIMESERIES"}
+					],
+					"facet": {"showOtherSeries": true},
+					"legend": {"enabled": false},
+					"yAxisLeft": {"zero": false, "max": 100},
+					"platformOptions": {"ignoreTimeRange": true}
+				}
+			},
+			{
+				"title": "Custom",
+				"layout": {"column": 1, "row": 4, "height": 3, "width": 4},
+				"visualization": {"id": "nr.custom-viz"},
+				"rawConfiguration": {"nrqlQueries": [{"accountId": 12345, "query": "SELECT 1"}]}
+			}
+		]
+	}]
+}`)
+
+func TestGenerateDashboardHCL(t *testing.T) {
+	hcl, err := GenerateDashboardHCL("apache", 2, "", testDashboardJSON)
+	require.NoError(t, err)
+	hcl = requireValidHCL(t, hcl)
+
+	assert.Contains(t, hcl, `resource "newrelic_one_dashboard" "apache" {`)
+	assert.Contains(t, hcl, `  permissions = "public_read_write"`)
+	assert.Contains(t, hcl, `      critical = 10`)
+	assert.Contains(t, hcl, `      warning = 5.5`)
+	assert.NotContains(t, hcl, "not_alerting")
+	assert.Contains(t, hcl, `      linked_entity_guids = ["MTIzNA"]`)
+	assert.Contains(t, hcl, `      facet_show_other_series = true`)
+	assert.Contains(t, hcl, `      legend_enabled = false`)
+	assert.Contains(t, hcl, `      ignore_time_range = true`)
+	assert.Contains(t, hcl, `      y_axis_left_max = 100`)
+	assert.Contains(t, hcl, `      y_axis_left_zero = false`)
+	assert.Contains(t, hcl, `        account_id = 67890`)
+	assert.NotContains(t, hcl, "variable")
+}
+
+func TestGenerateDashboardHCL_UnknownWidgetType(t *testing.T) {
+	hcl, err := GenerateDashboardHCL("apache", 2, "", testDashboardJSON)
+	require.NoError(t, err)
+	hcl = requireValidHCL(t, hcl)
+
+	assert.Contains(t, hcl, "    widget_json {\n      title = \"Custom\"")
+}
+
+func TestGenerateDashboardHCL_AccountIDVariables(t *testing.T) {
+	hcl, err := GenerateDashboardHCL("apache", 2, "account_id", testDashboardJSON)
+	require.NoError(t, err)
+	hcl = requireValidHCL(t, hcl)
+
+	assert.Contains(t, hcl, "variable \"account_id\" {\n  type = number\n  default = 12345\n}")
+	assert.Contains(t, hcl, "variable \"account_id_2\" {\n  type = number\n  default = 67890\n}")
+	assert.Contains(t, hcl, `        account_id = var.account_id`)
+	assert.Contains(t, hcl, `        account_id = var.account_id_2`)
+	assert.NotContains(t, hcl, `account_id = 12345`)
+}
+
+func TestGenerateDashboardHCL_InvalidJSON(t *testing.T) {
+	_, err := GenerateDashboardHCL("apache", 2, "", []byte(`{"name":`))
+	require.Error(t, err)
+}
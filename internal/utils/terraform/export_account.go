@@ -0,0 +1,265 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+	"github.com/newrelic/newrelic-client-go/pkg/synthetics"
+	"github.com/newrelic/newrelic-client-go/pkg/workloads"
+)
+
+var (
+	workloadResourceName      = "newrelic_workload"
+	monitorResourceName       = "newrelic_synthetics_monitor"
+	monitorScriptResourceName = "newrelic_synthetics_monitor_script"
+	dashboardsFileName        = "dashboards.tf"
+	alertsFileName            = "alerts.tf"
+	workloadsFileName         = "workloads.tf"
+	syntheticsFileName        = "synthetics.tf"
+	importsScriptName         = "imports.sh"
+	importsScriptHeader       = "#!/bin/sh\nset -e\n\n"
+	scriptedMonitorTypes      = map[synthetics.MonitorType]bool{synthetics.MonitorTypes.ScriptedBrowser: true, synthetics.MonitorTypes.APITest: true}
+	accountExportFileNames    = []string{dashboardsFileName, alertsFileName, workloadsFileName, syntheticsFileName}
+)
+
+// AccountResources holds the resources discovered in an account that are to
+// be exported to Terraform.
+type AccountResources struct {
+	Dashboards    []ExportedDashboard
+	AlertPolicies []ExportedAlertPolicy
+	Workloads     []*workloads.Workload
+	Monitors      []ExportedMonitor
+}
+
+// ExportedDashboard is a dashboard definition along with the GUID used to
+// import it.
+type ExportedDashboard struct {
+	GUID      string
+	Dashboard dashboards.DashboardInput
+}
+
+// ExportedAlertPolicy is an alert policy along with its NRQL conditions.
+type ExportedAlertPolicy struct {
+	Policy     alerts.AlertsPolicy
+	Conditions []*alerts.NrqlAlertCondition
+}
+
+// ExportedMonitor is a Synthetics monitor along with its script, if any.
+type ExportedMonitor struct {
+	Monitor synthetics.Monitor
+	Script  string
+}
+
+// AccountExport is the result of generating HCL for an account's resources.
+type AccountExport struct {
+	// Files maps a file name to the HCL generated for a single resource type.
+	// Resource types without any resources are omitted.
+	Files map[string]string
+	// Imports are the terraform import commands for every generated resource.
+	Imports []string
+}
+
+// FileNames returns the generated file names in a stable order.
+func (e *AccountExport) FileNames() []string {
+	names := []string{}
+	for _, n := range accountExportFileNames {
+		if _, ok := e.Files[n]; ok {
+			names = append(names, n)
+		}
+	}
+
+	return names
+}
+
+// ImportsScript returns a shell script that runs all of the import commands.
+func (e *AccountExport) ImportsScript() string {
+	return importsScriptHeader + strings.Join(e.Imports, "\n") + "\n"
+}
+
+// GenerateAccountHCL generates HCL for each of the given resources, grouped
+// into one file per resource type, along with the matching import commands.
+func GenerateAccountHCL(shiftWidth int, r AccountResources) (*AccountExport, error) {
+	e := &AccountExport{
+		Files: map[string]string{},
+	}
+
+	if err := e.addDashboards(shiftWidth, r.Dashboards); err != nil {
+		return nil, err
+	}
+
+	if err := e.addAlertPolicies(shiftWidth, r.AlertPolicies); err != nil {
+		return nil, err
+	}
+
+	e.addWorkloads(shiftWidth, r.Workloads)
+	e.addMonitors(shiftWidth, r.Monitors)
+
+	return e, nil
+}
+
+func (e *AccountExport) addDashboards(shiftWidth int, exported []ExportedDashboard) error {
+	if len(exported) == 0 {
+		return nil
+	}
+
+	hcl := []string{}
+	labels := newLabeler()
+	for _, d := range exported {
+		l := labels.next(d.Dashboard.Name, "dashboard")
+
+		h, err := GenerateDashboardInputHCL(l, shiftWidth, "", d.Dashboard)
+		if err != nil {
+			return fmt.Errorf("failed generating HCL for dashboard \"%s\": %s", d.Dashboard.Name, err)
+		}
+
+		hcl = append(hcl, h)
+		e.Imports = append(e.Imports, importCommand(dashboardResourceName, l, d.GUID))
+	}
+
+	e.Files[dashboardsFileName] = Format(strings.Join(hcl, "\n"))
+
+	return nil
+}
+
+func (e *AccountExport) addAlertPolicies(shiftWidth int, exported []ExportedAlertPolicy) error {
+	if len(exported) == 0 {
+		return nil
+	}
+
+	hcl := []string{}
+	labels := newLabeler()
+	// Condition labels are unique across the account, since conditions of
+	// different policies can derive the same label from policy and condition
+	// names.
+	conditionLabels := newLabeler()
+	for _, p := range exported {
+		l := labels.next(p.Policy.Name, "policy")
+		cl := nrqlConditionLabels(conditionLabels.used, l, p.Conditions)
+
+		h, err := generateAlertPolicyHCL(l, shiftWidth, p, cl)
+		if err != nil {
+			return fmt.Errorf("failed generating HCL for alert policy \"%s\": %s", p.Policy.Name, err)
+		}
+
+		hcl = append(hcl, h)
+		e.Imports = append(e.Imports, generateAlertPolicyImports(l, p, cl)...)
+	}
+
+	e.Files[alertsFileName] = Format(strings.Join(hcl, "\n"))
+
+	return nil
+}
+
+func (e *AccountExport) addWorkloads(shiftWidth int, exported []*workloads.Workload) {
+	if len(exported) == 0 {
+		return
+	}
+
+	h := NewHCLGen(shiftWidth)
+	labels := newLabeler()
+	for _, w := range exported {
+		l := labels.next(w.Name, "workload")
+
+		h.WriteBlock("resource", []string{workloadResourceName, l}, func() {
+			h.WriteStringAttribute("name", w.Name)
+			h.WriteIntAttributeIfNotZero("account_id", w.Account.ID)
+
+			guids := []string{}
+			for _, entity := range w.Entities {
+				guids = append(guids, entity.GUID)
+			}
+			h.WriteStringSliceAttributeIfNotEmpty("entity_guids", guids)
+
+			for _, q := range w.EntitySearchQueries {
+				h.WriteBlock("entity_search_query", []string{}, func() {
+					h.WriteStringAttribute("query", q.Query)
+				})
+			}
+
+			h.WriteIntSliceAttributeIfNotEmpty("scope_account_ids", w.ScopeAccounts.AccountIDs)
+		})
+
+		id := fmt.Sprintf("%d:%d:%s", w.Account.ID, w.ID, w.GUID)
+		e.Imports = append(e.Imports, importCommand(workloadResourceName, l, id))
+	}
+
+	e.Files[workloadsFileName] = Format(h.String())
+}
+
+func (e *AccountExport) addMonitors(shiftWidth int, exported []ExportedMonitor) {
+	if len(exported) == 0 {
+		return
+	}
+
+	h := NewHCLGen(shiftWidth)
+	labels := newLabeler()
+	for _, em := range exported {
+		m := em.Monitor
+		l := labels.next(m.Name, "monitor")
+
+		h.WriteBlock("resource", []string{monitorResourceName, l}, func() {
+			h.WriteStringAttribute("name", m.Name)
+			h.WriteStringAttribute("type", string(m.Type))
+			h.WriteIntAttribute("frequency", int(m.Frequency))
+			h.WriteStringAttribute("status", string(m.Status))
+			h.WriteStringSliceAttributeIfNotEmpty("locations", m.Locations)
+			h.WriteStringAttributeIfNotEmpty("uri", m.URI)
+			if m.SLAThreshold != 0 {
+				h.WriteFloatAttribute("sla_threshold", m.SLAThreshold)
+			}
+			h.WriteStringAttributeIfNotEmpty("validation_string", m.Options.ValidationString)
+			if m.Options.VerifySSL {
+				h.WriteBoolAttribute("verify_ssl", true)
+			}
+			if m.Options.BypassHEADRequest {
+				h.WriteBoolAttribute("bypass_head_request", true)
+			}
+			if m.Options.TreatRedirectAsFailure {
+				h.WriteBoolAttribute("treat_redirect_as_failure", true)
+			}
+		})
+		e.Imports = append(e.Imports, importCommand(monitorResourceName, l, m.ID))
+
+		if scriptedMonitorTypes[m.Type] && em.Script != "" {
+			h.WriteBlock("resource", []string{monitorScriptResourceName, l}, func() {
+				h.WriteReferenceAttribute("monitor_id", fmt.Sprintf("%s.%s.id", monitorResourceName, l))
+				h.WriteMultilineStringAttribute("text", em.Script)
+			})
+			e.Imports = append(e.Imports, importCommand(monitorScriptResourceName, l, m.ID))
+		}
+	}
+
+	e.Files[syntheticsFileName] = Format(h.String())
+}
+
+// labeler hands out unique resource labels derived from resource names.
+type labeler struct {
+	used map[string]bool
+}
+
+func newLabeler() *labeler {
+	return &labeler{
+		used: map[string]bool{},
+	}
+}
+
+func (l *labeler) next(name string, fallback string) string {
+	label := ToResourceLabel(name)
+	if label == "" {
+		label = fallback
+	}
+
+	return uniqueLabel(l.used, label)
+}
+
+// SortAccountResources orders each resource type by name, so that repeated
+// exports of the same account produce the same labels and files.
+func SortAccountResources(r *AccountResources) {
+	sort.SliceStable(r.Dashboards, func(i, j int) bool { return r.Dashboards[i].Dashboard.Name < r.Dashboards[j].Dashboard.Name })
+	sort.SliceStable(r.AlertPolicies, func(i, j int) bool { return r.AlertPolicies[i].Policy.Name < r.AlertPolicies[j].Policy.Name })
+	sort.SliceStable(r.Workloads, func(i, j int) bool { return r.Workloads[i].Name < r.Workloads[j].Name })
+	sort.SliceStable(r.Monitors, func(i, j int) bool { return r.Monitors[i].Monitor.Name < r.Monitors[j].Monitor.Name })
+}
@@ -0,0 +1,125 @@
+// +build unit
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+	"github.com/newrelic/newrelic-client-go/pkg/nerdgraph"
+	"github.com/newrelic/newrelic-client-go/pkg/synthetics"
+	"github.com/newrelic/newrelic-client-go/pkg/workloads"
+)
+
+func TestGenerateAccountHCL(t *testing.T) {
+	r := AccountResources{
+		Dashboards: []ExportedDashboard{
+			{GUID: "MjUy", Dashboard: dashboards.DashboardInput{Name: "Apache"}},
+			{GUID: "MzYz", Dashboard: dashboards.DashboardInput{Name: "Apache"}},
+		},
+		AlertPolicies: []ExportedAlertPolicy{
+			{Policy: testPolicy, Conditions: testConditions()},
+		},
+		Workloads: []*workloads.Workload{
+			{
+				Account:             nerdgraph.AccountReference{ID: 12345},
+				ID:                  42,
+				GUID:                "NDI",
+				Name:                "Checkout",
+				Entities:            []workloads.EntityRef{{GUID: "MTIzNA"}},
+				EntitySearchQueries: []workloads.EntitySearchQuery{{Query: "name like 'checkout'"}},
+				ScopeAccounts:       workloads.ScopeAccounts{AccountIDs: []int{12345, 67890}},
+			},
+		},
+		Monitors: []ExportedMonitor{
+			{Monitor: synthetics.Monitor{ID: "abc", Name: "Home page", Type: synthetics.MonitorTypes.Ping, Frequency: 5, Status: "ENABLED", URI: "https://example.com"}},
+			{Monitor: synthetics.Monitor{ID: "def", Name: "Login", Type: synthetics.MonitorTypes.ScriptedBrowser, Frequency: 15, Status: "ENABLED"}, Script: "$browser.get('https://example.com');"},
+		},
+	}
+
+	e, err := GenerateAccountHCL(2, r)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dashboards.tf", "alerts.tf", "workloads.tf", "synthetics.tf"}, e.FileNames())
+	for _, name := range e.FileNames() {
+		e.Files[name] = requireValidHCL(t, e.Files[name])
+	}
+
+	assert.Contains(t, e.Files["dashboards.tf"], `resource "newrelic_one_dashboard" "apache" {`)
+	assert.Contains(t, e.Files["dashboards.tf"], `resource "newrelic_one_dashboard" "apache_2" {`)
+	assert.Contains(t, e.Files["alerts.tf"], `resource "newrelic_alert_policy" "production" {`)
+	assert.Contains(t, e.Files["workloads.tf"], `  entity_guids = ["MTIzNA"]`)
+	assert.Contains(t, e.Files["workloads.tf"], `  scope_account_ids = [12345, 67890]`)
+	assert.Contains(t, e.Files["workloads.tf"], `    query = "name like 'checkout'"`)
+	assert.Contains(t, e.Files["synthetics.tf"], `resource "newrelic_synthetics_monitor" "home_page" {`)
+	assert.Contains(t, e.Files["synthetics.tf"], `resource "newrelic_synthetics_monitor_script" "login" {`)
+	assert.Contains(t, e.Files["synthetics.tf"], `  monitor_id = newrelic_synthetics_monitor.login.id`)
+	assert.NotContains(t, e.Files["synthetics.tf"], `newrelic_synthetics_monitor_script" "home_page"`)
+
+	assert.Equal(t, []string{
+		"terraform import newrelic_one_dashboard.apache MjUy",
+		"terraform import newrelic_one_dashboard.apache_2 MzYz",
+		"terraform import newrelic_alert_policy.production 111",
+		"terraform import newrelic_nrql_alert_condition.production_high_cpu 111:222:static",
+		"terraform import newrelic_nrql_alert_condition.production_high_cpu_2 111:333:baseline",
+		"terraform import newrelic_workload.checkout 12345:42:NDI",
+		"terraform import newrelic_synthetics_monitor.home_page abc",
+		"terraform import newrelic_synthetics_monitor.login def",
+		"terraform import newrelic_synthetics_monitor_script.login def",
+	}, e.Imports)
+
+	assert.Contains(t, e.ImportsScript(), "#!/bin/sh\n")
+}
+
+func TestGenerateAccountHCL_Empty(t *testing.T) {
+	e, err := GenerateAccountHCL(2, AccountResources{})
+	require.NoError(t, err)
+
+	assert.Empty(t, e.FileNames())
+	assert.Empty(t, e.Imports)
+}
+
+func TestGenerateAccountHCL_ConditionLabelsUniqueAcrossPolicies(t *testing.T) {
+	condition := func(id string, name string) *alerts.NrqlAlertCondition {
+		c := testConditions()[0]
+		c.ID = id
+		c.Name = name
+		return c
+	}
+
+	r := AccountResources{
+		AlertPolicies: []ExportedAlertPolicy{
+			{Policy: alerts.AlertsPolicy{ID: "1", Name: "a"}, Conditions: []*alerts.NrqlAlertCondition{condition("10", "b c")}},
+			{Policy: alerts.AlertsPolicy{ID: "2", Name: "a b"}, Conditions: []*alerts.NrqlAlertCondition{condition("20", "c")}},
+		},
+	}
+
+	e, err := GenerateAccountHCL(2, r)
+	require.NoError(t, err)
+
+	requireValidHCL(t, e.Files["alerts.tf"])
+	assert.Contains(t, e.Files["alerts.tf"], `resource "newrelic_nrql_alert_condition" "a_b_c" {`)
+	assert.Contains(t, e.Files["alerts.tf"], `resource "newrelic_nrql_alert_condition" "a_b_c_2" {`)
+	assert.Contains(t, e.Files["alerts.tf"], "= newrelic_alert_policy.a_b.id")
+
+	assert.Equal(t, []string{
+		"terraform import newrelic_alert_policy.a 1",
+		"terraform import newrelic_nrql_alert_condition.a_b_c 1:10:static",
+		"terraform import newrelic_alert_policy.a_b 2",
+		"terraform import newrelic_nrql_alert_condition.a_b_c_2 2:20:static",
+	}, e.Imports)
+}
+
+func TestLabeler_Unique(t *testing.T) {
+	l := newLabeler()
+
+	assert.Equal(t, "web", l.next("Web", "dashboard"))
+	assert.Equal(t, "web_2", l.next("web", "dashboard"))
+	assert.Equal(t, "web_2_2", l.next("Web 2", "dashboard"))
+	assert.Equal(t, "dashboard", l.next("!!!", "dashboard"))
+	assert.Equal(t, "dashboard_2", l.next("", "dashboard"))
+}
@@ -0,0 +1,85 @@
+package terraform
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	attributeLineRE = regexp.MustCompile(`^(\s*)([A-Za-z_][A-Za-z0-9_-]*)\s*=\s*(.*)$`)
+	heredocStartRE  = regexp.MustCompile(`^<<-?([A-Za-z_][A-Za-z0-9_]*)$`)
+)
+
+// Format normalizes generated HCL in the same spirit as terraform fmt, while
+// preserving the generator's indentation width.  The equals signs of adjacent
+// attributes are aligned, trailing whitespace and repeated blank lines are
+// removed, and the output ends with a single newline.  Heredoc content is
+// left untouched.
+func Format(src string) string {
+	lines := strings.Split(src, "\n")
+	out := make([]string, 0, len(lines))
+	group := []int{}
+	heredoc := ""
+
+	flush := func() {
+		alignAttributes(out, group)
+		group = group[:0]
+	}
+
+	for _, line := range lines {
+		if heredoc != "" {
+			out = append(out, line)
+			if strings.TrimSpace(line) == heredoc {
+				heredoc = ""
+			}
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t")
+
+		if line == "" {
+			flush()
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, line)
+			}
+			continue
+		}
+
+		m := attributeLineRE.FindStringSubmatch(line)
+		if m == nil {
+			flush()
+			out = append(out, line)
+			continue
+		}
+
+		if len(group) > 0 && attributeLineRE.FindStringSubmatch(out[group[0]])[1] != m[1] {
+			flush()
+		}
+
+		group = append(group, len(out))
+		out = append(out, line)
+
+		if h := heredocStartRE.FindStringSubmatch(m[3]); h != nil {
+			flush()
+			heredoc = h[1]
+		}
+	}
+	flush()
+
+	return strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n"
+}
+
+func alignAttributes(lines []string, group []int) {
+	width := 0
+	for _, i := range group {
+		m := attributeLineRE.FindStringSubmatch(lines[i])
+		if len(m[2]) > width {
+			width = len(m[2])
+		}
+	}
+
+	for _, i := range group {
+		m := attributeLineRE.FindStringSubmatch(lines[i])
+		lines[i] = m[1] + m[2] + strings.Repeat(" ", width-len(m[2])) + " = " + m[3]
+	}
+}
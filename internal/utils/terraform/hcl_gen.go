@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -19,7 +20,8 @@ func NewHCLGen(shiftWidth int) *HCLGen {
 }
 
 func (h *HCLGen) WriteMultilineStringAttribute(label string, value string) {
-	h.WriteString(fmt.Sprintf("%s%s = <<EOT\n%s\nEOT\n", h.i, label, value))
+	marker := heredocMarker(value)
+	h.WriteString(fmt.Sprintf("%s%s = <<%s\n%s\n%s\n", h.i, label, marker, escapeTemplate(value), marker))
 }
 
 func (h *HCLGen) WriteMultilineStringAttributeIfNotEmpty(label string, value string) {
@@ -29,7 +31,7 @@ func (h *HCLGen) WriteMultilineStringAttributeIfNotEmpty(label string, value str
 }
 
 func (h *HCLGen) WriteStringAttribute(label string, value string) {
-	h.WriteString(fmt.Sprintf("%s%s = \"%s\"\n", h.i, label, strings.ReplaceAll(value, "\"", "\\\"")))
+	h.WriteString(fmt.Sprintf("%s%s = %s\n", h.i, label, quoteString(value)))
 }
 
 func (h *HCLGen) WriteStringAttributeIfNotEmpty(label string, value string) {
@@ -39,7 +41,12 @@ func (h *HCLGen) WriteStringAttributeIfNotEmpty(label string, value string) {
 }
 
 func (h *HCLGen) WriteStringSliceAttribute(label string, value []string) {
-	h.WriteString(fmt.Sprintf("%s%s = [\"%s\"]", h.i, label, strings.Join(value, "\",\"")))
+	s := make([]string, len(value))
+	for i, v := range value {
+		s[i] = quoteString(v)
+	}
+
+	h.WriteString(fmt.Sprintf("%s%s = [%s]\n", h.i, label, strings.Join(s, ", ")))
 }
 
 func (h *HCLGen) WriteStringSliceAttributeIfNotEmpty(label string, value []string) {
@@ -48,6 +55,21 @@ func (h *HCLGen) WriteStringSliceAttributeIfNotEmpty(label string, value []strin
 	}
 }
 
+func (h *HCLGen) WriteIntSliceAttribute(label string, value []int) {
+	s := make([]string, len(value))
+	for i, v := range value {
+		s[i] = strconv.Itoa(v)
+	}
+
+	h.WriteString(fmt.Sprintf("%s%s = [%s]\n", h.i, label, strings.Join(s, ", ")))
+}
+
+func (h *HCLGen) WriteIntSliceAttributeIfNotEmpty(label string, value []int) {
+	if len(value) > 0 {
+		h.WriteIntSliceAttribute(label, value)
+	}
+}
+
 func (h *HCLGen) WriteIntAttribute(label string, value int) {
 	h.WriteString(fmt.Sprintf("%s%s = %d\n", h.i, label, value))
 }
@@ -58,10 +80,36 @@ func (h *HCLGen) WriteIntAttributeIfNotZero(label string, value int) {
 	}
 }
 
+func (h *HCLGen) WriteFloatAttribute(label string, value float64) {
+	h.WriteString(fmt.Sprintf("%s%s = %s\n", h.i, label, strconv.FormatFloat(value, 'f', -1, 64)))
+}
+
+func (h *HCLGen) WriteFloatAttributeIfNotNil(label string, value *float64) {
+	if value != nil {
+		h.WriteFloatAttribute(label, *value)
+	}
+}
+
+func (h *HCLGen) WriteBoolAttribute(label string, value bool) {
+	h.WriteString(fmt.Sprintf("%s%s = %t\n", h.i, label, value))
+}
+
+func (h *HCLGen) WriteBoolAttributeIfNotNil(label string, value *bool) {
+	if value != nil {
+		h.WriteBoolAttribute(label, *value)
+	}
+}
+
+// WriteReferenceAttribute writes an unquoted expression, such as a reference
+// to another resource's attribute.
+func (h *HCLGen) WriteReferenceAttribute(label string, value string) {
+	h.WriteString(fmt.Sprintf("%s%s = %s\n", h.i, label, value))
+}
+
 func (h *HCLGen) WriteBlock(name string, labels []string, f func()) {
 	h.WriteString(fmt.Sprintf("\n%s%s ", h.i, name))
 	for _, l := range labels {
-		h.WriteString(fmt.Sprintf("\"%s\" ", l))
+		h.WriteString(fmt.Sprintf("%s ", quoteString(l)))
 	}
 	h.WriteString("{\n")
 
@@ -79,3 +127,51 @@ func (h *HCLGen) indent() {
 func (h *HCLGen) unindent() {
 	h.i = h.i[0 : len(h.i)-h.shiftWidth]
 }
+
+// quoteString returns value as a quoted HCL string, escaping quotes,
+// backslashes, control characters and template sequences.
+func quoteString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+
+	for _, r := range escapeTemplate(value) {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				b.WriteString(fmt.Sprintf(`\u%04x`, r))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	b.WriteByte('"')
+	return b.String()
+}
+
+// escapeTemplate escapes the interpolation and directive sequences that HCL
+// would otherwise evaluate in quoted strings and heredocs.
+func escapeTemplate(value string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(value)
+}
+
+// heredocMarker returns a heredoc delimiter that does not occur in value, so
+// the value cannot terminate the heredoc early.
+func heredocMarker(value string) string {
+	marker := "EOT"
+	for i := 1; strings.Contains(value, marker); i++ {
+		marker = fmt.Sprintf("EOT%d", i)
+	}
+
+	return marker
+}
@@ -0,0 +1,128 @@
+// +build unit
+
+package terraform
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var alignedEqualsRE = regexp.MustCompile(`(\S) +=`)
+
+// requireValidHCL parses the generated HCL and fails the test if it is not
+// valid.  The HCL is returned with attribute alignment removed, so that tests
+// can match individual lines regardless of their neighbours.
+func requireValidHCL(t *testing.T, src string) string {
+	_, diags := hclsyntax.ParseConfig([]byte(src), "generated.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), "generated HCL is invalid: %s\n%s", diags.Error(), src)
+
+	return alignedEqualsRE.ReplaceAllString(src, "$1 =")
+}
+
+// parseAttribute parses the generated HCL and evaluates the named top level
+// attribute as a string.
+func parseAttribute(t *testing.T, src string, name string) string {
+	f, diags := hclsyntax.ParseConfig([]byte(src), "generated.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), "generated HCL is invalid: %s\n%s", diags.Error(), src)
+
+	attrs, diags := f.Body.JustAttributes()
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.Contains(t, attrs, name)
+
+	v, diags := attrs[name].Expr.Value(nil)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	return v.AsString()
+}
+
+var escapingTests = []string{
+	`plain`,
+	`with "quotes"`,
+	`C:\Program Files\New Relic`,
+	"multiple\nlines\r\nand\ttabs",
+	"control \x01 characters",
+	`SELECT count(*) FROM Transaction WHERE name = '${name}'`,
+	`a %{if true}directive%{endif}`,
+	`already escaped $${foo} and %%{bar}`,
+	`unicode ✓ characters`,
+}
+
+func TestWriteStringAttribute_Escaping(t *testing.T) {
+	for _, value := range escapingTests {
+		h := NewHCLGen(2)
+		h.WriteStringAttribute("value", value)
+
+		assert.Equal(t, value, parseAttribute(t, h.String(), "value"))
+	}
+}
+
+func TestWriteMultilineStringAttribute_Escaping(t *testing.T) {
+	for _, value := range append(escapingTests, "EOT", "first\nEOT\nlast", "EOT1\nEOT") {
+		h := NewHCLGen(2)
+		h.WriteMultilineStringAttribute("value", value)
+
+		assert.Equal(t, value+"\n", parseAttribute(t, h.String(), "value"))
+	}
+}
+
+func TestWriteStringSliceAttribute_Escaping(t *testing.T) {
+	h := NewHCLGen(2)
+	h.WriteBlock("resource", []string{"test", `label "with" quotes`}, func() {
+		h.WriteStringSliceAttribute("values", escapingTests)
+	})
+
+	requireValidHCL(t, h.String())
+}
+
+func TestHeredocMarker(t *testing.T) {
+	assert.Equal(t, "EOT", heredocMarker("SELECT 1"))
+	assert.Equal(t, "EOT1", heredocMarker("EOT"))
+	assert.Equal(t, "EOT2", heredocMarker("EOT\nEOT1"))
+}
+
+func TestFormat(t *testing.T) {
+	src := `
+resource "test" "test" {  
+  name = "test"
+  description = "a test"
+
+
+  nested {
+    query = <<EOT
+SELECT   count(*)
+  x = 1
+EOT
+    account_id = 1
+  }
+}
+`
+
+	expected := `resource "test" "test" {
+  name        = "test"
+  description = "a test"
+
+  nested {
+    query = <<EOT
+SELECT   count(*)
+  x = 1
+EOT
+    account_id = 1
+  }
+}
+`
+
+	assert.Equal(t, expected, Format(src))
+	requireValidHCL(t, Format(src))
+}
+
+func TestFormat_Idempotent(t *testing.T) {
+	hcl, err := GenerateDashboardHCL("apache", 4, "account_id", testDashboardJSON)
+	require.NoError(t, err)
+
+	assert.Equal(t, hcl, Format(hcl))
+}
@@ -39,3 +39,12 @@ func TestStructToMap(t *testing.T) {
 
 	assert.Equal(t, expected, result)
 }
+
+func TestEscapeNRQLString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "abc", EscapeNRQLString("abc"))
+	assert.Equal(t, `it\'s`, EscapeNRQLString("it's"))
+	assert.Equal(t, `abc\\`, EscapeNRQLString(`abc\`))
+	assert.Equal(t, `\\\' OR 1=1`, EscapeNRQLString(`\' OR 1=1`))
+}